@@ -2,7 +2,8 @@ package structures
 
 import (
 	"errors"
-	"math"
+	"iter"
+	"strconv"
 )
 
 // AdjacencyList represents a directed weighted graph implemented using an adjacency list.
@@ -10,6 +11,15 @@ type AdjacencyList struct {
 	list     map[string][]*Edge
 	vertices []*Vertex
 	edges    []*Edge
+	// Vertices by value, so that they are found without a scan.
+	index map[string]*Vertex
+	// Paths found by the last call to Dijkstra.
+	paths *PathTree
+	// What AddEdge does when the edge already exists, and the ID given to the last edge added.
+	policy ParallelEdgePolicy
+	lastID int
+	// Incremented on every modification, used to detect modification during iteration.
+	version int
 }
 
 // AddVertex adds a new vertex to the graph.
@@ -18,7 +28,10 @@ func (g *AdjacencyList) AddVertex(value string) error {
 		return errors.New("Vertex already exists: " + value)
 	}
 	g.list[value] = make([]*Edge, 0)
-	g.vertices = append(g.vertices, &Vertex{value: value})
+	vertex := &Vertex{value: value}
+	g.vertices = append(g.vertices, vertex)
+	g.index[value] = vertex
+	g.version++
 	return nil
 }
 
@@ -45,39 +58,73 @@ func (g *AdjacencyList) RemoveVertex(value string) error {
 	delete(g.list, value)
 	// Remove from the vertices array.
 	g.vertices = removeFromVertexArray(g.vertices, value)
+	delete(g.index, value)
 	// Remove all edges that contain the vertex from the edges array.
 	g.edges = removeFromEdgesArrayContaining(g.edges, value)
+	g.version++
 	return nil
 }
 
-// AddEdge adds a new edge to the graph.
-func (g *AdjacencyList) AddEdge(from string, to string, weight int) error {
+// AddEdge adds a new edge to the graph and returns its ID.
+// Edges that already join the same vertices in the same direction are handled by the parallel edge policy.
+func (g *AdjacencyList) AddEdge(from string, to string, weight int) (int, error) {
 	if g.list[from] == nil || g.list[to] == nil {
-		return errors.New("Vertices do not exist in graph: " + from + ", " + to)
+		return -1, errors.New("Vertices do not exist in graph: " + from + ", " + to)
 	}
-	g.list[from] = append(g.list[from], &Edge{vertices: [2]string{from, to}, weight: weight})
-	g.edges = append(g.edges, &Edge{vertices: [2]string{from, to}, weight: weight})
-	return nil
+	replaced, err := parallelEdgesHelper(g.getEdgesBetween(from, to), g.policy, from+"->"+to)
+	if err != nil {
+		return -1, err
+	}
+	for _, edge := range replaced {
+		g.removeEdge(edge)
+	}
+	g.lastID++
+	// The same edge is kept in both places so that its attributes are shared.
+	edge := &Edge{id: g.lastID, vertices: [2]string{from, to}, weight: weight}
+	g.list[from] = append(g.list[from], edge)
+	g.edges = append(g.edges, edge)
+	g.version++
+	return edge.id, nil
 }
 
-// RemoveEdge removes an edge from the graph.
+// RemoveEdge removes an edge from the graph, the first one added if there are parallel edges.
 func (g *AdjacencyList) RemoveEdge(from string, to string) error {
-	if g.list[from] == nil {
+	edge := g.getEdge(from, to)
+	if edge == nil {
 		return errors.New("Edge does not exist in graph: " + from + "->" + to)
 	}
-	removed := removeFromEdgesArray(g.edges, from, to)
-	if len(removed) >= len(g.edges) {
-		return errors.New("Edge does not exist in graph: " + from + "->" + to)
+	g.removeEdge(edge)
+	return nil
+}
+
+// RemoveEdgeByID removes the edge with the given ID from the graph.
+func (g *AdjacencyList) RemoveEdgeByID(id int) error {
+	edge := getEdgeByIDHelper(g.edges, id)
+	if edge == nil {
+		return errors.New("Edge does not exist in graph: " + strconv.Itoa(id))
 	}
-	g.edges = removed
+	g.removeEdge(edge)
 	return nil
 }
 
+// EdgesBetween returns the edges from one vertex to another in insertion order.
+func (g *AdjacencyList) EdgesBetween(from string, to string) []*Edge {
+	return g.getEdgesBetween(from, to)
+}
+
+// SetParallelEdgePolicy sets what AddEdge does when the edge already exists, parallel edges are allowed by default.
+// Edges already in the graph are kept.
+func (g *AdjacencyList) SetParallelEdgePolicy(policy ParallelEdgePolicy) {
+	g.policy = policy
+}
+
 // Clear removes all nodes from the graph.
 func (g *AdjacencyList) Clear() {
 	g.list = map[string][]*Edge{}
 	g.vertices = make([]*Vertex, 0)
 	g.edges = make([]*Edge, 0)
+	g.index = make(map[string]*Vertex)
+	g.version++
 }
 
 // IsEmpty returns true if the graph is empty.
@@ -86,17 +133,26 @@ func (g *AdjacencyList) IsEmpty() bool {
 }
 
 // DFS performs a depth first traversal from the source vertex.
-func (g *AdjacencyList) DFS(source string) []string {
+// Returns an error if the source does not exist.
+func (g *AdjacencyList) DFS(source string) ([]string, error) {
+	vertex := g.getVertex(source)
+	if vertex == nil {
+		return nil, errors.New("Vertex does not exist: " + source)
+	}
 	temp := make([]string, 0)
 	result := &temp
-	dfsHelper(g.getVertex(source), &result, g)
-	return *result
+	dfsHelper(vertex, &result, g)
+	return *result, nil
 }
 
 // BFS performs a breadth first traversal from the source vertex.
-func (g *AdjacencyList) BFS(source string) []string {
-	// The front of the slice represents the front of the queue.
+// Returns an error if the source does not exist.
+func (g *AdjacencyList) BFS(source string) ([]string, error) {
 	vertex := g.getVertex(source)
+	if vertex == nil {
+		return nil, errors.New("Vertex does not exist: " + source)
+	}
+	// The front of the slice represents the front of the queue.
 	result := []string{vertex.value}
 	queue := []*Vertex{vertex}
 	for len(queue) > 0 {
@@ -110,54 +166,97 @@ func (g *AdjacencyList) BFS(source string) []string {
 			}
 		}
 	}
-	return result
+	return result, nil
 }
 
 // Dijkstra finds the shortest path to all vertices in the graph from the source vertex.
-func (g *AdjacencyList) Dijkstra(source string) {
-	g.resetPath()
-	pq := &PriorityQueue{}
-	sourceVertex := g.getVertex(source)
-	sourceVertex.distance = 0
-	pq.Enqueue(sourceVertex)
-	for !pq.IsEmpty() {
-		vertex, _ := pq.Dequeue()
-		edges := g.getOutgoingEdges(vertex)
-		for _, edge := range edges {
-			var destID string
-			if vertex.value == edge.vertices[0] {
-				destID = edge.vertices[1]
-			} else {
-				destID = edge.vertices[0]
-			}
-			destVertex := g.getVertex(destID)
-			newDistance := edge.weight + vertex.distance
-
-			if newDistance < destVertex.distance {
-				destVertex.distance = newDistance
-				destVertex.previous = vertex
-				pq.Enqueue(destVertex)
-			}
-		}
-	}
+// The paths are kept for GetShortestPath until the next call, prefer ShortestPaths.
+// Returns an error if the source does not exist or an edge has a negative weight.
+func (g *AdjacencyList) Dijkstra(source string) error {
+	var err error
+	g.paths, err = ShortestPaths(g, source)
+	return err
 }
 
 // GetShortestPath returns the shortest path between the target and Dijkstra's source.
 func (g *AdjacencyList) GetShortestPath(target string) ([]*DijkstraResult, error) {
-	result := make([]*DijkstraResult, 0)
-	currentNode := g.getVertex(target)
-	if currentNode.previous == nil {
-		return nil, errors.New("Vertex cannot be reached: " + target)
-	}
-	for currentNode != nil {
-		result = append(result, &DijkstraResult{Value: currentNode.value, Distance: currentNode.distance})
-		currentNode = currentNode.previous
-	}
-	// Reverse the result.
-	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
-		result[i], result[j] = result[j], result[i]
-	}
-	return result, nil
+	return getShortestPathHelper(g, g.paths, target)
+}
+
+// ToList returns a copy of the graph as an adjacency list, with the same edge IDs and attributes.
+func (g *AdjacencyList) ToList() *AdjacencyList {
+	return toListHelper(g)
+}
+
+// ToMatrix returns a copy of the graph as an adjacency matrix, with the same edge IDs and attributes.
+func (g *AdjacencyList) ToMatrix() *AdjacencyMatrix {
+	return toMatrixHelper(g)
+}
+
+// ToCSR returns a read-only copy of the graph in compressed sparse row form, with the same edge IDs and attributes.
+func (g *AdjacencyList) ToCSR() *CSRGraph {
+	return toCSRHelper(g)
+}
+
+// Vertices returns an iterator over the vertices of the graph in insertion order.
+func (g *AdjacencyList) Vertices() iter.Seq[string] {
+	return verticesSeq(g, g.vertices)
+}
+
+// Edges returns an iterator over the edges of the graph in insertion order.
+func (g *AdjacencyList) Edges() iter.Seq[*Edge] {
+	return edgesSeq(g, g.edges)
+}
+
+// Neighbours returns an iterator over the neighbours of a vertex and the weights of the edges leading to them.
+func (g *AdjacencyList) Neighbours(value string) iter.Seq2[string, int] {
+	return neighboursSeq(g, value)
+}
+
+// OutgoingEdges returns an iterator over the edges leaving a vertex, to read their attributes.
+func (g *AdjacencyList) OutgoingEdges(value string) iter.Seq[*Edge] {
+	return outgoingEdgesSeq(g, value)
+}
+
+// VertexAttribute returns the value of a named attribute of a vertex, false if it has not been set.
+func (g *AdjacencyList) VertexAttribute(value string, name string) (any, bool) {
+	return vertexAttributeHelper(g, value, name)
+}
+
+// SetVertexAttribute sets the value of a named attribute of a vertex.
+func (g *AdjacencyList) SetVertexAttribute(value string, name string, attribute any) error {
+	return setVertexAttributeHelper(g, value, name, attribute)
+}
+
+// EdgeAttribute returns the value of a named attribute of an edge, false if it has not been set.
+// Of parallel edges the first one added is read, use EdgeAttributeByID for the others.
+func (g *AdjacencyList) EdgeAttribute(from string, to string, name string) (any, bool) {
+	return edgeAttributeHelper(g, from, to, name)
+}
+
+// SetEdgeAttribute sets the value of a named attribute of an edge, the first one added if there are parallel edges.
+func (g *AdjacencyList) SetEdgeAttribute(from string, to string, name string, attribute any) error {
+	return setEdgeAttributeHelper(g, from, to, name, attribute)
+}
+
+// SetEdgeFloatWeight sets a float64 weight on an edge, the integer weight is unchanged.
+func (g *AdjacencyList) SetEdgeFloatWeight(from string, to string, weight float64) error {
+	return setEdgeFloatWeightHelper(g, from, to, weight)
+}
+
+// EdgeAttributeByID returns the value of a named attribute of the edge with the given ID, false if it has not been set.
+func (g *AdjacencyList) EdgeAttributeByID(id int, name string) (any, bool) {
+	return edgeAttributeByIDHelper(g, id, name)
+}
+
+// SetEdgeAttributeByID sets the value of a named attribute of the edge with the given ID.
+func (g *AdjacencyList) SetEdgeAttributeByID(id int, name string, attribute any) error {
+	return setEdgeAttributeByIDHelper(g, id, name, attribute)
+}
+
+// SetEdgeFloatWeightByID sets a float64 weight on the edge with the given ID, the integer weight is unchanged.
+func (g *AdjacencyList) SetEdgeFloatWeightByID(id int, weight float64) error {
+	return setEdgeFloatWeightByIDHelper(g, id, weight)
 }
 
 // NumberOfVertices returns the number of vertices in the graph.
@@ -171,14 +270,34 @@ func (g *AdjacencyList) NumberOfEdges() int {
 }
 
 func (g *AdjacencyList) getVertex(value string) *Vertex {
-	for _, vertex := range g.vertices {
-		if vertex.value == value {
-			return vertex
+	return g.index[value]
+}
+
+func (g *AdjacencyList) getEdge(from string, to string) *Edge {
+	for _, edge := range g.list[from] {
+		if edge.vertices[1] == to {
+			return edge
 		}
 	}
 	return nil
 }
 
+func (g *AdjacencyList) getEdgesBetween(from string, to string) []*Edge {
+	result := make([]*Edge, 0)
+	for _, edge := range g.list[from] {
+		if edge.vertices[1] == to {
+			result = append(result, edge)
+		}
+	}
+	return result
+}
+
+func (g *AdjacencyList) removeEdge(edge *Edge) {
+	g.list[edge.vertices[0]] = removeEdgeFromArray(g.list[edge.vertices[0]], edge)
+	g.edges = removeEdgeFromArray(g.edges, edge)
+	g.version++
+}
+
 func (g *AdjacencyList) getOutgoingEdges(vertex *Vertex) []*Edge {
 	return g.list[vertex.value]
 }
@@ -196,9 +315,14 @@ func (g *AdjacencyList) getNeighbours(vertex *Vertex) []*Vertex {
 	return result
 }
 
-func (g *AdjacencyList) resetPath() {
-	for _, vertex := range g.vertices {
-		vertex.distance = math.MaxInt32
-		vertex.previous = nil
-	}
+func (g *AdjacencyList) getParallelEdgePolicy() ParallelEdgePolicy {
+	return g.policy
+}
+
+func (g *AdjacencyList) getVersion() int {
+	return g.version
+}
+
+func (g *AdjacencyList) directed() DirectedWeightedGraph {
+	return g
 }
@@ -2,14 +2,25 @@ package structures
 
 import (
 	"errors"
-	"math"
+	"iter"
+	"strconv"
 )
 
 // AdjacencyMatrix represents a directed weighted graph implemented using an adjacency matrix.
 type AdjacencyMatrix struct {
-	matrix   map[string]map[string]*Edge
+	// The edges from one vertex to another in insertion order, empty if there are none.
+	matrix   map[string]map[string][]*Edge
 	vertices []*Vertex
 	edges    []*Edge
+	// Vertices by value, so that they are found without a scan.
+	index map[string]*Vertex
+	// Paths found by the last call to Dijkstra.
+	paths *PathTree
+	// What AddEdge does when the edge already exists, and the ID given to the last edge added.
+	policy ParallelEdgePolicy
+	lastID int
+	// Incremented on every modification, used to detect modification during iteration.
+	version int
 }
 
 // AddVertex adds a new vertex to the graph.
@@ -17,18 +28,12 @@ func (g *AdjacencyMatrix) AddVertex(value string) error {
 	if g.matrix[value] != nil {
 		return errors.New("Vertex already exists in graph: " + value)
 	}
-	newRow := make(map[string]*Edge)
-
-	// Use new pointers for each insertion.
-	for key, column := range g.matrix {
-		// Add new column to matrix.
-		column[value] = &Edge{vertices: [2]string{"", ""}, status: 0}
-		// Add old row keys onto the new row.
-		newRow[key] = &Edge{vertices: [2]string{"", ""}, status: 0}
-	}
-	newRow[value] = &Edge{vertices: [2]string{"", ""}, status: 0}
-	g.matrix[value] = newRow
-	g.vertices = append(g.vertices, &Vertex{value: value})
+	// Cells are created when the first edge is added.
+	g.matrix[value] = make(map[string][]*Edge)
+	vertex := &Vertex{value: value}
+	g.vertices = append(g.vertices, vertex)
+	g.index[value] = vertex
+	g.version++
 	return nil
 }
 
@@ -54,43 +59,72 @@ func (g *AdjacencyMatrix) RemoveVertex(value string) error {
 	}
 	delete(g.matrix, value)
 	g.vertices = removeFromVertexArray(g.vertices, value)
+	delete(g.index, value)
 	g.edges = removeFromEdgesArrayContaining(g.edges, value)
+	g.version++
 	return nil
 }
 
-// AddEdge adds a new edge to the graph.
-func (g *AdjacencyMatrix) AddEdge(from string, to string, weight int) error {
-	if g.matrix[from] == nil || g.matrix[from][to] == nil {
-		return errors.New("Vertices do not exist in graph: " + from + ", " + to)
+// AddEdge adds a new edge to the graph and returns its ID.
+// Edges that already join the same vertices in the same direction are handled by the parallel edge policy.
+func (g *AdjacencyMatrix) AddEdge(from string, to string, weight int) (int, error) {
+	if g.matrix[from] == nil || g.matrix[to] == nil {
+		return -1, errors.New("Vertices do not exist in graph: " + from + ", " + to)
 	}
-	edge := &Edge{vertices: [2]string{from, to}, status: 1, weight: weight}
-	g.matrix[from][to] = edge
-	g.edges = append(g.edges, &Edge{vertices: [2]string{from, to}, status: 1, weight: weight})
-	return nil
+	replaced, err := parallelEdgesHelper(g.getEdgesBetween(from, to), g.policy, from+"->"+to)
+	if err != nil {
+		return -1, err
+	}
+	for _, edge := range replaced {
+		g.removeEdge(edge)
+	}
+	g.lastID++
+	// The same edge is kept in both places so that its attributes are shared.
+	edge := &Edge{id: g.lastID, vertices: [2]string{from, to}, weight: weight}
+	g.matrix[from][to] = append(g.matrix[from][to], edge)
+	g.edges = append(g.edges, edge)
+	g.version++
+	return edge.id, nil
 }
 
-// RemoveEdge removes an edge from the graph.
+// RemoveEdge removes an edge from the graph, the first one added if there are parallel edges.
 func (g *AdjacencyMatrix) RemoveEdge(from string, to string) error {
-	if g.matrix[from] == nil {
+	edge := g.getEdge(from, to)
+	if edge == nil {
 		return errors.New("Edge does not exist in graph: " + from + "->" + to)
 	}
-	edge := g.matrix[from][to]
-	if edge == nil || edge.status == 0 {
-		return errors.New("Edge does not exist in graph: " + from + "->" + to)
+	g.removeEdge(edge)
+	return nil
+}
+
+// RemoveEdgeByID removes the edge with the given ID from the graph.
+func (g *AdjacencyMatrix) RemoveEdgeByID(id int) error {
+	edge := getEdgeByIDHelper(g.edges, id)
+	if edge == nil {
+		return errors.New("Edge does not exist in graph: " + strconv.Itoa(id))
 	}
-	edge.status = 0
-	edge.weight = 0
-	edge.vertices = [2]string{}
-	removed := removeFromEdgesArray(g.edges, from, to)
-	g.edges = removed
+	g.removeEdge(edge)
 	return nil
 }
 
+// EdgesBetween returns the edges from one vertex to another in insertion order.
+func (g *AdjacencyMatrix) EdgesBetween(from string, to string) []*Edge {
+	return g.getEdgesBetween(from, to)
+}
+
+// SetParallelEdgePolicy sets what AddEdge does when the edge already exists, parallel edges are allowed by default.
+// Edges already in the graph are kept.
+func (g *AdjacencyMatrix) SetParallelEdgePolicy(policy ParallelEdgePolicy) {
+	g.policy = policy
+}
+
 // Clear removes all nodes from the graph.
 func (g *AdjacencyMatrix) Clear() {
-	g.matrix = map[string]map[string]*Edge{}
+	g.matrix = map[string]map[string][]*Edge{}
 	g.vertices = make([]*Vertex, 0)
 	g.edges = make([]*Edge, 0)
+	g.index = make(map[string]*Vertex)
+	g.version++
 }
 
 // IsEmpty returns true if the graph is empty.
@@ -98,18 +132,27 @@ func (g *AdjacencyMatrix) IsEmpty() bool {
 	return len(g.vertices) == 0
 }
 
-// DFS performs a depth first traversal from the source vertex
-func (g *AdjacencyMatrix) DFS(source string) []string {
+// DFS performs a depth first traversal from the source vertex.
+// Returns an error if the source does not exist.
+func (g *AdjacencyMatrix) DFS(source string) ([]string, error) {
+	vertex := g.getVertex(source)
+	if vertex == nil {
+		return nil, errors.New("Vertex does not exist: " + source)
+	}
 	temp := make([]string, 0)
 	result := &temp
-	dfsHelper(g.getVertex(source), &result, g)
-	return *result
+	dfsHelper(vertex, &result, g)
+	return *result, nil
 }
 
 // BFS performs a breadth first traversal from the source vertex.
-func (g *AdjacencyMatrix) BFS(source string) []string {
-	// The front of the slice represents the front of the queue.
+// Returns an error if the source does not exist.
+func (g *AdjacencyMatrix) BFS(source string) ([]string, error) {
 	vertex := g.getVertex(source)
+	if vertex == nil {
+		return nil, errors.New("Vertex does not exist: " + source)
+	}
+	// The front of the slice represents the front of the queue.
 	result := []string{vertex.value}
 	queue := []*Vertex{vertex}
 	for len(queue) > 0 {
@@ -123,54 +166,97 @@ func (g *AdjacencyMatrix) BFS(source string) []string {
 			}
 		}
 	}
-	return result
+	return result, nil
 }
 
 // Dijkstra finds the shortest path to all vertices in the graph from the source vertex.
-func (g *AdjacencyMatrix) Dijkstra(source string) {
-	g.resetPath()
-	pq := &PriorityQueue{}
-	sourceVertex := g.getVertex(source)
-	sourceVertex.distance = 0
-	pq.Enqueue(sourceVertex)
-	for !pq.IsEmpty() {
-		vertex, _ := pq.Dequeue()
-		edges := g.getOutgoingEdges(vertex)
-		for _, edge := range edges {
-			var destID string
-			if vertex.value == edge.vertices[0] {
-				destID = edge.vertices[1]
-			} else {
-				destID = edge.vertices[0]
-			}
-			destVertex := g.getVertex(destID)
-			newDistance := edge.weight + vertex.distance
-
-			if newDistance < destVertex.distance {
-				destVertex.distance = newDistance
-				destVertex.previous = vertex
-				pq.Enqueue(destVertex)
-			}
-		}
-	}
+// The paths are kept for GetShortestPath until the next call, prefer ShortestPaths.
+// Returns an error if the source does not exist or an edge has a negative weight.
+func (g *AdjacencyMatrix) Dijkstra(source string) error {
+	var err error
+	g.paths, err = ShortestPaths(g, source)
+	return err
 }
 
 // GetShortestPath returns the shortest path between the target and Dijkstra's source.
 func (g *AdjacencyMatrix) GetShortestPath(target string) ([]*DijkstraResult, error) {
-	result := make([]*DijkstraResult, 0)
-	currentNode := g.getVertex(target)
-	if currentNode.previous == nil {
-		return nil, errors.New("Vertex cannot be reached: " + target)
-	}
-	for currentNode != nil {
-		result = append(result, &DijkstraResult{Value: currentNode.value, Distance: currentNode.distance})
-		currentNode = currentNode.previous
-	}
-	// Reverse the result.
-	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
-		result[i], result[j] = result[j], result[i]
-	}
-	return result, nil
+	return getShortestPathHelper(g, g.paths, target)
+}
+
+// ToList returns a copy of the graph as an adjacency list, with the same edge IDs and attributes.
+func (g *AdjacencyMatrix) ToList() *AdjacencyList {
+	return toListHelper(g)
+}
+
+// ToMatrix returns a copy of the graph as an adjacency matrix, with the same edge IDs and attributes.
+func (g *AdjacencyMatrix) ToMatrix() *AdjacencyMatrix {
+	return toMatrixHelper(g)
+}
+
+// ToCSR returns a read-only copy of the graph in compressed sparse row form, with the same edge IDs and attributes.
+func (g *AdjacencyMatrix) ToCSR() *CSRGraph {
+	return toCSRHelper(g)
+}
+
+// Vertices returns an iterator over the vertices of the graph in insertion order.
+func (g *AdjacencyMatrix) Vertices() iter.Seq[string] {
+	return verticesSeq(g, g.vertices)
+}
+
+// Edges returns an iterator over the edges of the graph in insertion order.
+func (g *AdjacencyMatrix) Edges() iter.Seq[*Edge] {
+	return edgesSeq(g, g.edges)
+}
+
+// Neighbours returns an iterator over the neighbours of a vertex and the weights of the edges leading to them.
+func (g *AdjacencyMatrix) Neighbours(value string) iter.Seq2[string, int] {
+	return neighboursSeq(g, value)
+}
+
+// OutgoingEdges returns an iterator over the edges leaving a vertex, to read their attributes.
+func (g *AdjacencyMatrix) OutgoingEdges(value string) iter.Seq[*Edge] {
+	return outgoingEdgesSeq(g, value)
+}
+
+// VertexAttribute returns the value of a named attribute of a vertex, false if it has not been set.
+func (g *AdjacencyMatrix) VertexAttribute(value string, name string) (any, bool) {
+	return vertexAttributeHelper(g, value, name)
+}
+
+// SetVertexAttribute sets the value of a named attribute of a vertex.
+func (g *AdjacencyMatrix) SetVertexAttribute(value string, name string, attribute any) error {
+	return setVertexAttributeHelper(g, value, name, attribute)
+}
+
+// EdgeAttribute returns the value of a named attribute of an edge, false if it has not been set.
+// Of parallel edges the first one added is read, use EdgeAttributeByID for the others.
+func (g *AdjacencyMatrix) EdgeAttribute(from string, to string, name string) (any, bool) {
+	return edgeAttributeHelper(g, from, to, name)
+}
+
+// SetEdgeAttribute sets the value of a named attribute of an edge, the first one added if there are parallel edges.
+func (g *AdjacencyMatrix) SetEdgeAttribute(from string, to string, name string, attribute any) error {
+	return setEdgeAttributeHelper(g, from, to, name, attribute)
+}
+
+// SetEdgeFloatWeight sets a float64 weight on an edge, the integer weight is unchanged.
+func (g *AdjacencyMatrix) SetEdgeFloatWeight(from string, to string, weight float64) error {
+	return setEdgeFloatWeightHelper(g, from, to, weight)
+}
+
+// EdgeAttributeByID returns the value of a named attribute of the edge with the given ID, false if it has not been set.
+func (g *AdjacencyMatrix) EdgeAttributeByID(id int, name string) (any, bool) {
+	return edgeAttributeByIDHelper(g, id, name)
+}
+
+// SetEdgeAttributeByID sets the value of a named attribute of the edge with the given ID.
+func (g *AdjacencyMatrix) SetEdgeAttributeByID(id int, name string, attribute any) error {
+	return setEdgeAttributeByIDHelper(g, id, name, attribute)
+}
+
+// SetEdgeFloatWeightByID sets a float64 weight on the edge with the given ID, the integer weight is unchanged.
+func (g *AdjacencyMatrix) SetEdgeFloatWeightByID(id int, weight float64) error {
+	return setEdgeFloatWeightByIDHelper(g, id, weight)
 }
 
 // NumberOfVertices returns the number of vertices in the graph.
@@ -184,20 +270,33 @@ func (g *AdjacencyMatrix) NumberOfEdges() int {
 }
 
 func (g *AdjacencyMatrix) getVertex(value string) *Vertex {
-	for _, vertex := range g.vertices {
-		if vertex.value == value {
-			return vertex
-		}
+	return g.index[value]
+}
+
+func (g *AdjacencyMatrix) getEdge(from string, to string) *Edge {
+	if cell := g.matrix[from][to]; len(cell) > 0 {
+		return cell[0]
 	}
 	return nil
 }
 
+func (g *AdjacencyMatrix) getEdgesBetween(from string, to string) []*Edge {
+	return append(make([]*Edge, 0), g.matrix[from][to]...)
+}
+
+func (g *AdjacencyMatrix) removeEdge(edge *Edge) {
+	row := g.matrix[edge.vertices[0]]
+	row[edge.vertices[1]] = removeEdgeFromArray(row[edge.vertices[1]], edge)
+	g.edges = removeEdgeFromArray(g.edges, edge)
+	g.version++
+}
+
 func (g *AdjacencyMatrix) getOutgoingEdges(vertex *Vertex) []*Edge {
+	// Iterate over the vertices array rather than the row so that the order is deterministic.
 	result := make([]*Edge, 0)
-	for _, column := range g.matrix[vertex.value] {
-		if column.status > 0 {
-			result = append(result, column)
-		}
+	row := g.matrix[vertex.value]
+	for _, v := range g.vertices {
+		result = append(result, row[v.value]...)
 	}
 	return result
 }
@@ -215,9 +314,14 @@ func (g *AdjacencyMatrix) getNeighbours(vertex *Vertex) []*Vertex {
 	return result
 }
 
-func (g *AdjacencyMatrix) resetPath() {
-	for _, vertex := range g.vertices {
-		vertex.distance = math.MaxInt32
-		vertex.previous = nil
-	}
+func (g *AdjacencyMatrix) getParallelEdgePolicy() ParallelEdgePolicy {
+	return g.policy
+}
+
+func (g *AdjacencyMatrix) getVersion() int {
+	return g.version
+}
+
+func (g *AdjacencyMatrix) directed() DirectedWeightedGraph {
+	return g
 }
@@ -0,0 +1,206 @@
+package structures
+
+import (
+	"errors"
+	"slices"
+)
+
+// AllPairsPathsOf holds the shortest paths between every pair of vertices as a distance and next hop table.
+// It is a snapshot of the graph at the time it was computed and is not affected by later changes to the graph.
+type AllPairsPathsOf[W Weight] struct {
+	vertices []string
+	index    map[string]int
+	distance [][]W
+	// Index of the vertex after i on the shortest path from i to j, -1 if j cannot be reached from i.
+	next [][]int
+}
+
+// AllPairsPaths holds all pairs shortest paths with integer weights.
+type AllPairsPaths = AllPairsPathsOf[int]
+
+// Vertices returns the vertices of the table in the graph's order.
+func (p *AllPairsPathsOf[W]) Vertices() []string {
+	return slices.Clone(p.vertices)
+}
+
+// Reachable returns true if there is a path between two vertices.
+func (p *AllPairsPathsOf[W]) Reachable(from string, to string) bool {
+	i, j, ok := p.getIndices(from, to)
+	return ok && p.next[i][j] != -1
+}
+
+// Distance returns the length of the shortest path between two vertices.
+func (p *AllPairsPathsOf[W]) Distance(from string, to string) (W, error) {
+	if !p.Reachable(from, to) {
+		return 0, errors.New("Vertex cannot be reached: " + from + "->" + to)
+	}
+	return p.distance[p.index[from]][p.index[to]], nil
+}
+
+// NextHop returns the vertex after from on the shortest path between two vertices.
+func (p *AllPairsPathsOf[W]) NextHop(from string, to string) (string, error) {
+	if !p.Reachable(from, to) {
+		return "", errors.New("Vertex cannot be reached: " + from + "->" + to)
+	}
+	return p.vertices[p.next[p.index[from]][p.index[to]]], nil
+}
+
+// Path returns the vertices on the shortest path between two vertices, with their distances from the first vertex.
+func (p *AllPairsPathsOf[W]) Path(from string, to string) ([]*DijkstraResultOf[W], error) {
+	if !p.Reachable(from, to) {
+		return nil, errors.New("Vertex cannot be reached: " + from + "->" + to)
+	}
+	i, j := p.index[from], p.index[to]
+	result := []*DijkstraResultOf[W]{{Value: from, Distance: 0}}
+	for current := i; current != j; {
+		current = p.next[current][j]
+		result = append(result, &DijkstraResultOf[W]{Value: p.vertices[current], Distance: p.distance[i][current]})
+	}
+	return result, nil
+}
+
+func (p *AllPairsPathsOf[W]) getIndices(from string, to string) (int, int, bool) {
+	i, ok := p.index[from]
+	if !ok {
+		return 0, 0, false
+	}
+	j, ok := p.index[to]
+	return i, j, ok
+}
+
+// Creates a table where every vertex only reaches itself.
+func newAllPairsPaths[W Weight](g DirectedWeightedGraph) *AllPairsPathsOf[W] {
+	paths := &AllPairsPathsOf[W]{index: make(map[string]int)}
+	for vertex := range g.Vertices() {
+		paths.index[vertex] = len(paths.vertices)
+		paths.vertices = append(paths.vertices, vertex)
+	}
+	n := len(paths.vertices)
+	paths.distance = make([][]W, n)
+	paths.next = make([][]int, n)
+	for i := range n {
+		paths.distance[i] = make([]W, n)
+		paths.next[i] = make([]int, n)
+		for j := range n {
+			paths.next[i][j] = -1
+		}
+		paths.next[i][i] = i
+	}
+	return paths
+}
+
+// FloydWarshall finds the shortest paths between every pair of vertices, allowing negative edge weights.
+// Returns a NegativeCycleError if the graph contains a negative cycle.
+// On an undirected graph any negative edge is a negative cycle, as it can be walked back and forth.
+// Time: O(V^3).
+func FloydWarshall(graph Graph) (*AllPairsPaths, error) {
+	return floydWarshallHelper(graph.directed(), (*Edge).Weight)
+}
+
+// FloydWarshallBy finds the shortest paths between every pair of vertices using the Floyd-Warshall algorithm,
+// with the weights read from a named numeric edge attribute, or the float weights if the name is empty.
+// Returns an error if any edge lacks the attribute, or a NegativeCycleError if the graph contains a negative cycle.
+func FloydWarshallBy(graph Graph, attribute string) (*AllPairsPathsOf[float64], error) {
+	g := graph.directed()
+	weights, err := attributeWeights(g, attribute)
+	if err != nil {
+		return nil, err
+	}
+	return floydWarshallHelper(g, func(edge *Edge) float64 { return weights[edge] })
+}
+
+// Generic Floyd-Warshall algorithm with the weight of each edge given by a function.
+func floydWarshallHelper[W Weight](g DirectedWeightedGraph, weightOf func(edge *Edge) W) (*AllPairsPathsOf[W], error) {
+	paths := newAllPairsPaths[W](g)
+	for edge := range g.Edges() {
+		i, j := paths.index[edge.From()], paths.index[edge.To()]
+		if paths.next[i][j] == -1 || weightOf(edge) < paths.distance[i][j] {
+			paths.distance[i][j] = weightOf(edge)
+			paths.next[i][j] = j
+		}
+	}
+	n := len(paths.vertices)
+	for k := range n {
+		for i := range n {
+			if paths.next[i][k] == -1 {
+				continue
+			}
+			for j := range n {
+				if paths.next[k][j] == -1 {
+					continue
+				}
+				distance := paths.distance[i][k] + paths.distance[k][j]
+				if paths.next[i][j] == -1 || distance < paths.distance[i][j] {
+					paths.distance[i][j] = distance
+					paths.next[i][j] = paths.next[i][k]
+				}
+			}
+		}
+	}
+	for i := range n {
+		if paths.distance[i][i] < 0 {
+			// The next hops around a negative cycle are unreliable, find a witness with Bellman-Ford instead.
+			_, err := virtualSourcePaths(g, weightOf)
+			return nil, err
+		}
+	}
+	return paths, nil
+}
+
+// Johnson finds the shortest paths between every pair of vertices, allowing negative edge weights.
+// It is faster than FloydWarshall on sparse graphs. Returns a NegativeCycleError if the graph contains a negative cycle.
+// The edges are reweighted to be non-negative using Bellman-Ford, then Dijkstra's algorithm is run from every vertex.
+// Time: O(VE log V).
+func Johnson(graph Graph) (*AllPairsPaths, error) {
+	g := graph.directed()
+	potentials, err := virtualSourcePaths(g, (*Edge).Weight)
+	if err != nil {
+		return nil, err
+	}
+	h := potentials.distance
+	c := g.ToCSR()
+	reweight := func(i int) int {
+		edge := c.outgoing[i]
+		return edge.weight + h[edge.From()] - h[edge.To()]
+	}
+
+	paths := newAllPairsPaths[int](g)
+	for i, source := range paths.vertices {
+		distance, previous := dijkstraCore(c, c.ids[source], reweight)
+		tree := newPathTree(c, c.ids[source], distance, previous, 0)
+		for vertex, distance := range tree.distance {
+			paths.distance[i][paths.index[vertex]] = distance - h[source] + h[vertex]
+		}
+		// The next hop to a vertex is the next hop to its predecessor, unless the predecessor is the source.
+		var nextHop func(vertex string) int
+		nextHop = func(vertex string) int {
+			j := paths.index[vertex]
+			if paths.next[i][j] == -1 {
+				previous := tree.previous[vertex]
+				if previous == source {
+					paths.next[i][j] = j
+				} else {
+					paths.next[i][j] = nextHop(previous)
+				}
+			}
+			return paths.next[i][j]
+		}
+		for vertex := range tree.distance {
+			nextHop(vertex)
+		}
+	}
+	return paths, nil
+}
+
+// Returns the shortest distances from a virtual source with an edge of weight 0 to every vertex.
+// Every vertex is reachable, so a NegativeCycleError is returned for any negative cycle in the graph.
+func virtualSourcePaths[W Weight](g DirectedWeightedGraph, weightOf func(edge *Edge) W) (*PathTreeOf[W], error) {
+	paths := &PathTreeOf[W]{distance: make(map[string]W), previous: make(map[string]string)}
+	for vertex := range g.Vertices() {
+		paths.distance[vertex] = 0
+	}
+	if err := bellmanFordCore(g, paths, weightOf); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
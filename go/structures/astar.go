@@ -0,0 +1,77 @@
+package structures
+
+import "errors"
+
+// AStar finds the shortest path from the source to the target, guided by a heuristic estimate of the distance to the target.
+// The path is shortest if the heuristic never overestimates. Also returns the number of vertices expanded.
+// Returns an error if any edge weight is negative.
+func AStar(graph Graph, source string, target string, heuristic func(vertex string) int) ([]*DijkstraResult, int, error) {
+	g := graph.directed()
+	if err := checkDijkstra(g, source, target); err != nil {
+		return nil, 0, err
+	}
+	return aStarHelper(g, source, target, heuristic, (*Edge).Weight)
+}
+
+// AStarBy finds the shortest path from the source to the target like AStar,
+// with the weights read from a named numeric edge attribute, or the float weights if the name is empty.
+// Returns an error if any edge lacks the attribute or has a negative weight.
+func AStarBy(graph Graph, source string, target string, attribute string, heuristic func(vertex string) float64) ([]*DijkstraResultOf[float64], int, error) {
+	g := graph.directed()
+	for _, vertex := range []string{source, target} {
+		if g.getVertex(vertex) == nil {
+			return nil, 0, errors.New("Vertex does not exist: " + vertex)
+		}
+	}
+	weights, err := attributeWeights(g, attribute)
+	if err != nil {
+		return nil, 0, err
+	}
+	for edge := range g.Edges() {
+		if weights[edge] < 0 {
+			return nil, 0, errors.New("A* does not support negative edge weights: " + edge.From() + "->" + edge.To())
+		}
+	}
+	return aStarHelper(g, source, target, heuristic, func(edge *Edge) float64 { return weights[edge] })
+}
+
+// Generic A* search with the weight of each edge given by a function, which must return non-negative weights.
+func aStarHelper[W Weight](g DirectedWeightedGraph, source string, target string, heuristic func(vertex string) W, weightOf func(edge *Edge) W) ([]*DijkstraResultOf[W], int, error) {
+	paths := &PathTreeOf[W]{
+		source:   source,
+		distance: map[string]W{source: 0},
+		previous: make(map[string]string),
+		version:  g.getVersion(),
+	}
+	estimates := map[string]W{source: heuristic(source)}
+	pq := NewPriorityQueue(func(vertex string) W { return paths.distance[vertex] + estimates[vertex] })
+	pq.Push(source)
+	expanded := 0
+	for !pq.IsEmpty() {
+		vertex, _ := pq.Pop()
+		if vertex == target {
+			path, err := paths.PathTo(target)
+			return path, expanded, err
+		}
+		expanded++
+		for edge := range g.OutgoingEdges(vertex) {
+			neighbour := edge.To()
+			newDistance := paths.distance[vertex] + weightOf(edge)
+			if distance, ok := paths.distance[neighbour]; ok && distance <= newDistance {
+				continue
+			}
+			if _, ok := estimates[neighbour]; !ok {
+				estimates[neighbour] = heuristic(neighbour)
+			}
+			paths.distance[neighbour] = newDistance
+			paths.previous[neighbour] = vertex
+			// Vertices that were already expanded are queued again, which only happens if the heuristic is inconsistent.
+			if pq.Contains(neighbour) {
+				pq.DecreaseKey(neighbour)
+			} else {
+				pq.Push(neighbour)
+			}
+		}
+	}
+	return nil, expanded, errors.New("Vertex cannot be reached: " + target)
+}
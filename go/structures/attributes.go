@@ -0,0 +1,126 @@
+package structures
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Generic vertex attribute getter.
+func vertexAttributeHelper(g DirectedWeightedGraph, value string, name string) (any, bool) {
+	vertex := g.getVertex(value)
+	if vertex == nil {
+		return nil, false
+	}
+	attribute, ok := vertex.attributes[name]
+	return attribute, ok
+}
+
+// Generic vertex attribute setter.
+func setVertexAttributeHelper(g DirectedWeightedGraph, value string, name string, attribute any) error {
+	vertex := g.getVertex(value)
+	if vertex == nil {
+		return errors.New("Vertex does not exist: " + value)
+	}
+	if vertex.attributes == nil {
+		vertex.attributes = make(map[string]any)
+	}
+	vertex.attributes[name] = attribute
+	return nil
+}
+
+// Generic edge attribute getter.
+func edgeAttributeHelper(g DirectedWeightedGraph, from string, to string, name string) (any, bool) {
+	edge := g.getEdge(from, to)
+	if edge == nil {
+		return nil, false
+	}
+	return edge.Attribute(name)
+}
+
+// Generic edge attribute setter.
+func setEdgeAttributeHelper(g DirectedWeightedGraph, from string, to string, name string, attribute any) error {
+	edge := g.getEdge(from, to)
+	if edge == nil {
+		return errors.New("Edge does not exist in graph: " + from + "->" + to)
+	}
+	if edge.attributes == nil {
+		edge.attributes = make(map[string]any)
+	}
+	edge.attributes[name] = attribute
+	return nil
+}
+
+// Generic edge float weight setter.
+func setEdgeFloatWeightHelper(g DirectedWeightedGraph, from string, to string, weight float64) error {
+	edge := g.getEdge(from, to)
+	if edge == nil {
+		return errors.New("Edge does not exist in graph: " + from + "->" + to)
+	}
+	edge.floatWeight = &weight
+	return nil
+}
+
+// Generic edge lookup by ID, every edge with the ID, as both directions of an undirected edge share it.
+func edgesByIDHelper(g DirectedWeightedGraph, id int) []*Edge {
+	result := make([]*Edge, 0)
+	for edge := range g.Edges() {
+		if edge.id == id {
+			result = append(result, edge)
+		}
+	}
+	return result
+}
+
+// Generic edge attribute getter by edge ID.
+func edgeAttributeByIDHelper(g DirectedWeightedGraph, id int, name string) (any, bool) {
+	edges := edgesByIDHelper(g, id)
+	if len(edges) == 0 {
+		return nil, false
+	}
+	return edges[0].Attribute(name)
+}
+
+// Generic edge attribute setter by edge ID.
+func setEdgeAttributeByIDHelper(g DirectedWeightedGraph, id int, name string, attribute any) error {
+	edges := edgesByIDHelper(g, id)
+	if len(edges) == 0 {
+		return errors.New("Edge does not exist in graph: " + strconv.Itoa(id))
+	}
+	for _, edge := range edges {
+		if edge.attributes == nil {
+			edge.attributes = make(map[string]any)
+		}
+		edge.attributes[name] = attribute
+	}
+	return nil
+}
+
+// Generic edge float weight setter by edge ID.
+func setEdgeFloatWeightByIDHelper(g DirectedWeightedGraph, id int, weight float64) error {
+	edges := edgesByIDHelper(g, id)
+	if len(edges) == 0 {
+		return errors.New("Edge does not exist in graph: " + strconv.Itoa(id))
+	}
+	for _, edge := range edges {
+		edge.floatWeight = &weight
+	}
+	return nil
+}
+
+// Reads the weight of every edge from a named numeric attribute, or the float weights if the name is empty.
+// Returns an error if any edge lacks the attribute or its weight is not a number.
+func attributeWeights(g DirectedWeightedGraph, attribute string) (map[*Edge]float64, error) {
+	result := make(map[*Edge]float64, g.NumberOfEdges())
+	for edge := range g.Edges() {
+		weight, err := edge.WeightBy(attribute)
+		if err != nil {
+			return nil, err
+		}
+		// NaN is not equal to itself and cannot be compared with other distances.
+		if weight != weight {
+			return nil, errors.New("Edge weight is not a number: " + edge.From() + "->" + edge.To())
+		}
+		result[edge] = weight
+	}
+	return result, nil
+}
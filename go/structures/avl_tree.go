@@ -0,0 +1,332 @@
+package structures
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+)
+
+// AVLTreeOf is a self-balancing binary search tree.
+// The heights of the two subtrees of any node differ by at most one, so all operations take O(log n) time.
+type AVLTreeOf[K cmp.Ordered, V any] struct {
+	root *BinaryTreeNodeOf[K, V]
+	size int
+	// Incremented on every insertion or deletion, used to detect modification during iteration.
+	version int
+}
+
+// AVLTree is an AVL tree of ints.
+type AVLTree = AVLTreeOf[int, struct{}]
+
+// Insert adds a new key to the tree with an empty value.
+func (t *AVLTreeOf[K, V]) Insert(elem K) error {
+	var value V
+	return t.InsertValue(elem, value)
+}
+
+// InsertValue adds a new key and its value to the tree.
+func (t *AVLTreeOf[K, V]) InsertValue(elem K, value V) error {
+	root, err := avlInsertHelper(t.root, elem, value)
+	if err != nil {
+		return err
+	}
+	t.root = root
+	t.root.parent = nil
+	t.size++
+	t.version++
+	return nil
+}
+
+// InsertAll inserts all elements in a slice sequentially.
+func (t *AVLTreeOf[K, V]) InsertAll(elems []K) error {
+	var e error
+	for _, elem := range elems {
+		err := t.Insert(elem)
+		if err != nil {
+			e = err
+		}
+	}
+	return e
+}
+
+// Delete removes a node from the tree.
+func (t *AVLTreeOf[K, V]) Delete(elem K) error {
+	root, err := avlDeleteHelper(t.root, elem)
+	if err != nil {
+		return err
+	}
+	t.root = root
+	if t.root != nil {
+		t.root.parent = nil
+	}
+	t.size--
+	t.version++
+	return nil
+}
+
+// Search returns true if the key exists in the tree.
+func (t *AVLTreeOf[K, V]) Search(elem K) bool {
+	return searchHelper(elem, t.root) != nil
+}
+
+// Get returns the value stored with a given key.
+func (t *AVLTreeOf[K, V]) Get(elem K) (V, error) {
+	node := searchHelper(elem, t.root)
+	if node == nil {
+		var zero V
+		return zero, errors.New("Key does not exist: " + fmt.Sprint(elem))
+	}
+	return node.value, nil
+}
+
+// Put stores a value with a given key, overriding the value if the key already exists.
+func (t *AVLTreeOf[K, V]) Put(elem K, value V) {
+	node := searchHelper(elem, t.root)
+	if node != nil {
+		node.value = value
+		return
+	}
+	t.InsertValue(elem, value)
+}
+
+// Depth returns the depth of a given element, -1 if the element is not found.
+func (t *AVLTreeOf[K, V]) Depth(elem K) int {
+	return depthHelper(elem, t.root)
+}
+
+// Height returns the number of nodes on the longest path from the root to a leaf.
+func (t *AVLTreeOf[K, V]) Height() int {
+	return avlHeight(t.root)
+}
+
+// Clear removes all nodes in the tree.
+func (t *AVLTreeOf[K, V]) Clear() {
+	t.root = nil
+	t.size = 0
+	t.version++
+}
+
+// IsEmpty returns true if the tree contains no nodes.
+func (t *AVLTreeOf[K, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Size returns the number of nodes in the tree.
+func (t *AVLTreeOf[K, V]) Size() int {
+	return t.size
+}
+
+// InOrder returns the keys of the tree's in order traversal as a slice.
+func (t *AVLTreeOf[K, V]) InOrder() []K {
+	temp := make([]K, 0)
+	result := &temp
+	inOrderHelper(t.root, &result)
+	return *result
+}
+
+// All returns an iterator over the keys and values of the tree in order.
+func (t *AVLTreeOf[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		inOrderSeqHelper(t.root, versionedYield(&t.version, yield), false)
+	}
+}
+
+// Backward returns an iterator over the keys and values of the tree in reverse order.
+func (t *AVLTreeOf[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		inOrderSeqHelper(t.root, versionedYield(&t.version, yield), true)
+	}
+}
+
+// Keys returns an iterator over the keys of the tree in order.
+func (t *AVLTreeOf[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range t.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the tree, ordered by key.
+func (t *AVLTreeOf[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range t.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// PreOrder returns an iterator over the keys and values of the tree's pre order traversal.
+func (t *AVLTreeOf[K, V]) PreOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		preOrderSeqHelper(t.root, versionedYield(&t.version, yield))
+	}
+}
+
+// PostOrder returns an iterator over the keys and values of the tree's post order traversal.
+func (t *AVLTreeOf[K, V]) PostOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		postOrderSeqHelper(t.root, versionedYield(&t.version, yield))
+	}
+}
+
+// LevelOrder returns an iterator over the keys and values of the tree's level order traversal.
+func (t *AVLTreeOf[K, V]) LevelOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		levelOrderSeqHelper(t.root, versionedYield(&t.version, yield))
+	}
+}
+
+// CheckInvariants returns an error describing the first violated AVL tree property, nil if the tree is valid.
+func (t *AVLTreeOf[K, V]) CheckInvariants() error {
+	err := checkBinarySearchTree(t.root, t.size)
+	if err != nil {
+		return err
+	}
+	_, err = checkAVLHelper(t.root)
+	return err
+}
+
+func avlInsertHelper[K cmp.Ordered, V any](node *BinaryTreeNodeOf[K, V], elem K, value V) (*BinaryTreeNodeOf[K, V], error) {
+	if node == nil {
+		return &BinaryTreeNodeOf[K, V]{key: elem, value: value, size: 1, height: 1}, nil
+	}
+	if elem == node.key {
+		return node, errors.New("Value already exists: " + fmt.Sprint(elem))
+	}
+	if elem < node.key {
+		child, err := avlInsertHelper(node.left, elem, value)
+		if err != nil {
+			return node, err
+		}
+		node.left = child
+		child.parent = node
+	} else {
+		child, err := avlInsertHelper(node.right, elem, value)
+		if err != nil {
+			return node, err
+		}
+		node.right = child
+		child.parent = node
+	}
+	return avlRebalance(node), nil
+}
+
+func avlDeleteHelper[K cmp.Ordered, V any](node *BinaryTreeNodeOf[K, V], elem K) (*BinaryTreeNodeOf[K, V], error) {
+	if node == nil {
+		return nil, errors.New("Delete failed, value does not exist: " + fmt.Sprint(elem))
+	}
+	if elem < node.key {
+		child, err := avlDeleteHelper(node.left, elem)
+		if err != nil {
+			return node, err
+		}
+		node.left = child
+		if child != nil {
+			child.parent = node
+		}
+	} else if elem > node.key {
+		child, err := avlDeleteHelper(node.right, elem)
+		if err != nil {
+			return node, err
+		}
+		node.right = child
+		if child != nil {
+			child.parent = node
+		}
+	} else {
+		if node.left == nil || node.right == nil {
+			// Replace with left or right child, whichever exists.
+			replacement := node.left
+			if replacement == nil {
+				replacement = node.right
+			}
+			if replacement != nil {
+				replacement.parent = node.parent
+			}
+			return replacement, nil
+		}
+		// Element has two children.
+		// Take over the minimum of the right subtree, then delete it from there.
+		minInRightSubtree := getMinInSubtree(node.right)
+		node.key, node.value = minInRightSubtree.key, minInRightSubtree.value
+		child, _ := avlDeleteHelper(node.right, minInRightSubtree.key)
+		node.right = child
+		if child != nil {
+			child.parent = node
+		}
+	}
+	return avlRebalance(node), nil
+}
+
+// Restores the AVL property at a node whose subtrees are balanced and returns the new subtree root.
+func avlRebalance[K, V any](node *BinaryTreeNodeOf[K, V]) *BinaryTreeNodeOf[K, V] {
+	updateSize(node)
+	avlUpdateHeight(node)
+	balance := avlBalance(node)
+	if balance > 1 {
+		if avlBalance(node.left) < 0 {
+			node.left = rotateLeft(node.left)
+			avlUpdateHeight(node.left.left)
+			avlUpdateHeight(node.left)
+		}
+		node = rotateRight(node)
+		avlUpdateHeight(node.right)
+		avlUpdateHeight(node)
+	} else if balance < -1 {
+		if avlBalance(node.right) > 0 {
+			node.right = rotateRight(node.right)
+			avlUpdateHeight(node.right.right)
+			avlUpdateHeight(node.right)
+		}
+		node = rotateLeft(node)
+		avlUpdateHeight(node.left)
+		avlUpdateHeight(node)
+	}
+	return node
+}
+
+func avlHeight[K, V any](node *BinaryTreeNodeOf[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func avlUpdateHeight[K, V any](node *BinaryTreeNodeOf[K, V]) {
+	node.height = 1 + max(avlHeight(node.left), avlHeight(node.right))
+}
+
+// Positive when the left subtree is taller.
+func avlBalance[K, V any](node *BinaryTreeNodeOf[K, V]) int {
+	return avlHeight(node.left) - avlHeight(node.right)
+}
+
+// Returns the actual height of a subtree after checking the stored heights and balance factors.
+func checkAVLHelper[K, V any](node *BinaryTreeNodeOf[K, V]) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+	left, err := checkAVLHelper(node.left)
+	if err != nil {
+		return 0, err
+	}
+	right, err := checkAVLHelper(node.right)
+	if err != nil {
+		return 0, err
+	}
+	height := 1 + max(left, right)
+	if node.height != height {
+		return 0, errors.New("Stored height of " + fmt.Sprint(node.key) + " is " + strconv.Itoa(node.height) + ", expected " + strconv.Itoa(height))
+	}
+	if left-right > 1 || right-left > 1 {
+		return 0, errors.New("Node is unbalanced: " + fmt.Sprint(node.key))
+	}
+	return height, nil
+}
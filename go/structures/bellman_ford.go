@@ -0,0 +1,104 @@
+package structures
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// NegativeCycleError is returned by shortest path algorithms when the graph contains a cycle of negative total weight,
+// since no shortest path exists through such a cycle.
+type NegativeCycleError struct {
+	// Cycle lists the vertices of a negative cycle in order, the last vertex has an edge back to the first.
+	Cycle []string
+}
+
+func (e *NegativeCycleError) Error() string {
+	return "Graph contains a negative cycle: " + strings.Join(append(slices.Clone(e.Cycle), e.Cycle[0]), " -> ")
+}
+
+// BellmanFord finds the shortest paths from the source vertex to all reachable vertices, allowing negative edge weights.
+// Returns a NegativeCycleError if a negative cycle is reachable from the source.
+func BellmanFord(graph Graph, source string) (*PathTree, error) {
+	return bellmanFordHelper(graph.directed(), source, (*Edge).Weight)
+}
+
+// BellmanFordBy finds the shortest paths from the source vertex using the Bellman-Ford algorithm,
+// with the weights read from a named numeric edge attribute, or the float weights if the name is empty.
+// Returns an error if any edge lacks the attribute, or a NegativeCycleError if a negative cycle is reachable from the source.
+func BellmanFordBy(graph Graph, source string, attribute string) (*PathTreeOf[float64], error) {
+	g := graph.directed()
+	weights, err := attributeWeights(g, attribute)
+	if err != nil {
+		return nil, err
+	}
+	return bellmanFordHelper(g, source, func(edge *Edge) float64 { return weights[edge] })
+}
+
+// Generic Bellman-Ford algorithm with the weight of each edge given by a function.
+func bellmanFordHelper[W Weight](g DirectedWeightedGraph, source string, weightOf func(edge *Edge) W) (*PathTreeOf[W], error) {
+	if g.getVertex(source) == nil {
+		return nil, errors.New("Vertex does not exist: " + source)
+	}
+	paths := &PathTreeOf[W]{
+		source:   source,
+		distance: map[string]W{source: 0},
+		previous: make(map[string]string),
+		version:  g.getVersion(),
+	}
+	if err := bellmanFordCore(g, paths, weightOf); err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// Relaxes the edges of the graph until the distances in paths are shortest, starting from the distances already set.
+// Returns a NegativeCycleError if a negative cycle is reachable from any vertex with a distance.
+func bellmanFordCore[W Weight](g DirectedWeightedGraph, paths *PathTreeOf[W], weightOf func(edge *Edge) W) error {
+	relax := func(edge *Edge) bool {
+		distance, ok := paths.distance[edge.From()]
+		if !ok {
+			return false
+		}
+		newDistance := distance + weightOf(edge)
+		if current, ok := paths.distance[edge.To()]; ok && current <= newDistance {
+			return false
+		}
+		paths.distance[edge.To()] = newDistance
+		paths.previous[edge.To()] = edge.From()
+		return true
+	}
+
+	// After i passes every shortest path with at most i edges is known, so V - 1 passes suffice without negative cycles.
+	for range g.NumberOfVertices() - 1 {
+		relaxed := false
+		for edge := range g.Edges() {
+			if relax(edge) {
+				relaxed = true
+			}
+		}
+		if !relaxed {
+			return nil
+		}
+	}
+	for edge := range g.Edges() {
+		if relax(edge) {
+			return &NegativeCycleError{Cycle: findPredecessorCycle(paths.previous, edge.To(), g.NumberOfVertices())}
+		}
+	}
+	return nil
+}
+
+// Returns the cycle in the predecessor graph that a vertex leads back to, in edge order.
+// Walking back once per vertex guarantees the walk has entered the cycle.
+func findPredecessorCycle(previous map[string]string, vertex string, numberOfVertices int) []string {
+	for range numberOfVertices {
+		vertex = previous[vertex]
+	}
+	cycle := []string{vertex}
+	for current := previous[vertex]; current != vertex; current = previous[current] {
+		cycle = append(cycle, current)
+	}
+	slices.Reverse(cycle)
+	return cycle
+}
@@ -0,0 +1,130 @@
+package structures
+
+import (
+	"slices"
+)
+
+// Result of a single depth first search that finds every kind of single point of failure, as indices into an undirected view.
+type biconnectivity struct {
+	view       *undirectedView
+	points     []int
+	bridges    []int
+	components [][]int
+}
+
+// Generic Tarjan's algorithm for biconnectivity over the undirected graph underlying g.
+// Each pair of adjacent vertices is joined once, by the first edge between them, and self loops are ignored,
+// so a pair of opposite edges counts as one connection.
+// Time: O(V + E).
+func biconnectivityHelper(g Graph) *biconnectivity {
+	view := newUndirectedView(g)
+	n := len(view.vertices)
+	adjacency := make([][]int, n)
+	for vertex := range n {
+		seen := make(map[int]bool)
+		for _, i := range view.incident[vertex] {
+			neighbour := view.other(i, vertex)
+			if neighbour != vertex && !seen[neighbour] {
+				seen[neighbour] = true
+				adjacency[vertex] = append(adjacency[vertex], i)
+			}
+		}
+	}
+
+	result := &biconnectivity{view: view}
+	// Order in which vertices were discovered, and the earliest discovered vertex reachable
+	// through the search tree below a vertex and one back edge.
+	discovered := make([]int, n)
+	low := make([]int, n)
+	for vertex := range n {
+		discovered[vertex] = -1
+	}
+	isPoint := make([]bool, n)
+	count := 0
+	// Edges of the components that have not been completed yet.
+	stack := make([]int, 0)
+	var visit func(vertex int, parentEdge int)
+	visit = func(vertex int, parentEdge int) {
+		discovered[vertex], low[vertex] = count, count
+		count++
+		children := 0
+		for _, i := range adjacency[vertex] {
+			neighbour := view.other(i, vertex)
+			if i == parentEdge {
+				continue
+			}
+			if discovered[neighbour] == -1 {
+				children++
+				stack = append(stack, i)
+				visit(neighbour, i)
+				low[vertex] = min(low[vertex], low[neighbour])
+				if low[neighbour] > discovered[vertex] {
+					result.bridges = append(result.bridges, i)
+				}
+				// Nothing below the neighbour reaches above this vertex, so the edges pushed since form a component.
+				if low[neighbour] >= discovered[vertex] {
+					// The root separates its subtrees only if it has more than one.
+					if parentEdge != -1 || children > 1 {
+						isPoint[vertex] = true
+					}
+					index := slices.Index(stack, i)
+					members := make(map[int]bool)
+					for _, j := range stack[index:] {
+						members[view.index[view.edges[j].From()]] = true
+						members[view.index[view.edges[j].To()]] = true
+					}
+					stack = stack[:index]
+					component := make([]int, 0, len(members))
+					for member := range members {
+						component = append(component, member)
+					}
+					slices.Sort(component)
+					result.components = append(result.components, component)
+				}
+			} else if discovered[neighbour] < discovered[vertex] {
+				stack = append(stack, i)
+				low[vertex] = min(low[vertex], discovered[neighbour])
+			}
+		}
+	}
+	for vertex := range n {
+		if discovered[vertex] == -1 {
+			visit(vertex, -1)
+		}
+		if isPoint[vertex] {
+			result.points = append(result.points, vertex)
+		}
+	}
+	slices.Sort(result.bridges)
+	slices.SortFunc(result.components, slices.Compare)
+	return result
+}
+
+// ArticulationPoints returns the vertices whose removal disconnects their component, with edges treated as undirected.
+func ArticulationPoints(g Graph) []string {
+	b := biconnectivityHelper(g)
+	return b.view.names(b.points)
+}
+
+// Bridges returns the edges whose removal disconnects their component, with edges treated as undirected.
+// Opposite edges between the same vertices count as one connection, the first of them is returned.
+func Bridges(g Graph) []*Edge {
+	b := biconnectivityHelper(g)
+	result := make([]*Edge, len(b.bridges))
+	for i, bridge := range b.bridges {
+		result[i] = b.view.edges[bridge]
+	}
+	return result
+}
+
+// BiconnectedComponents returns the maximal sets of vertices that stay connected after removing any one of them, with edges treated as undirected.
+// Components share their articulation points, vertices without edges are in no component.
+// Vertices of each component are in insertion order, and components are ordered by their vertices.
+func BiconnectedComponents(g Graph) [][]string {
+	b := biconnectivityHelper(g)
+	result := make([][]string, len(b.components))
+	for i, component := range b.components {
+		result[i] = b.view.names(component)
+	}
+	return result
+}
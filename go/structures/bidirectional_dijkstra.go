@@ -0,0 +1,113 @@
+package structures
+
+import "errors"
+
+// One direction of a bidirectional search.
+type dijkstraSearch struct {
+	paths *PathTree
+	pq    *PriorityQueue[string]
+	// Returns the edges leaving a vertex in the direction of the search.
+	neighbours func(vertex string) []*Edge
+	forward    bool
+}
+
+func newDijkstraSearch(g DirectedWeightedGraph, source string, neighbours func(vertex string) []*Edge, forward bool) *dijkstraSearch {
+	search := &dijkstraSearch{
+		paths: &PathTree{
+			source:   source,
+			distance: map[string]int{source: 0},
+			previous: make(map[string]string),
+			version:  g.getVersion(),
+		},
+		neighbours: neighbours,
+		forward:    forward,
+	}
+	search.pq = NewPriorityQueue(func(vertex string) int { return search.paths.distance[vertex] })
+	search.pq.Push(source)
+	return search
+}
+
+// Expands the closest vertex and returns the vertices whose distances improved.
+func (s *dijkstraSearch) expand() []string {
+	vertex, _ := s.pq.Pop()
+	improved := make([]string, 0)
+	for _, edge := range s.neighbours(vertex) {
+		neighbour := edge.To()
+		if !s.forward {
+			neighbour = edge.From()
+		}
+		newDistance := s.paths.distance[vertex] + edge.Weight()
+		if distance, ok := s.paths.distance[neighbour]; ok && distance <= newDistance {
+			continue
+		}
+		s.paths.distance[neighbour] = newDistance
+		s.paths.previous[neighbour] = vertex
+		if s.pq.Contains(neighbour) {
+			s.pq.DecreaseKey(neighbour)
+		} else {
+			s.pq.Push(neighbour)
+		}
+		improved = append(improved, neighbour)
+	}
+	return improved
+}
+
+// Distance of the closest unexpanded vertex.
+func (s *dijkstraSearch) top() int {
+	vertex, _ := s.pq.Peek()
+	return s.paths.distance[vertex]
+}
+
+// BidirectionalDijkstra finds the shortest path from the source to the target by searching from both ends.
+// Also returns the number of vertices expanded. Returns an error if any edge weight is negative.
+// Searches forward from the source and backward from the target until the searches meet.
+func BidirectionalDijkstra(graph Graph, source string, target string) ([]*DijkstraResult, int, error) {
+	g := graph.directed()
+	if err := checkDijkstra(g, source, target); err != nil {
+		return nil, 0, err
+	}
+	incoming := make(map[string][]*Edge)
+	for edge := range g.Edges() {
+		incoming[edge.To()] = append(incoming[edge.To()], edge)
+	}
+	forward := newDijkstraSearch(g, source, func(vertex string) []*Edge {
+		return g.getOutgoingEdges(g.getVertex(vertex))
+	}, true)
+	backward := newDijkstraSearch(g, target, func(vertex string) []*Edge {
+		return incoming[vertex]
+	}, false)
+
+	// The shortest path found so far goes through the meeting vertex.
+	best, meeting, found := 0, source, source == target
+	expanded := 0
+	for !forward.pq.IsEmpty() && !backward.pq.IsEmpty() {
+		// No path through an unexpanded vertex can be shorter than the sum of both frontiers.
+		if found && forward.top()+backward.top() >= best {
+			break
+		}
+		// Expand the smaller frontier.
+		search, other := forward, backward
+		if backward.pq.Len() < forward.pq.Len() {
+			search, other = backward, forward
+		}
+		expanded++
+		for _, vertex := range search.expand() {
+			if distance, ok := other.paths.distance[vertex]; ok {
+				if length := search.paths.distance[vertex] + distance; !found || length < best {
+					best, meeting, found = length, vertex, true
+				}
+			}
+		}
+	}
+	if !found {
+		return nil, expanded, errors.New("Vertex cannot be reached: " + target)
+	}
+
+	// Join the forward path to the meeting vertex with the backward path from it.
+	path, _ := forward.paths.PathTo(meeting)
+	for vertex := meeting; vertex != target; {
+		vertex = backward.paths.previous[vertex]
+		path = append(path, &DijkstraResult{Value: vertex, Distance: best - backward.paths.distance[vertex]})
+	}
+	return path, expanded, nil
+}
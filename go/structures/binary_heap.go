@@ -1,8 +1,10 @@
 package structures
 
 import (
+	"cmp"
 	"errors"
-	"strconv"
+	"fmt"
+	"iter"
 )
 
 const (
@@ -12,228 +14,339 @@ const (
 	MaxHeap = iota
 )
 
-// BinaryHeap can represent either a max or a min heap.
-type BinaryHeap struct {
+// HeapHandleOf refers to an element in a heap, it is used to update or remove the element later.
+type HeapHandleOf[T any] struct {
+	value T
+	// Position of the element in the heap's slice, -1 once the element has been removed.
+	index int
+	heap  *BinaryHeapOf[T]
+}
+
+// BinaryHeapOf can represent either a max or a min heap.
+// The heap is stored in a slice where the children of index i are at 2i + 1 and 2i + 2.
+// Heaps are created with NewBinaryHeap for naturally ordered elements or NewBinaryHeapFunc with a comparator.
+// A heap made without a comparator, such as the zero value, orders built-in numeric and string elements naturally.
+// Duplicate elements are allowed.
+type BinaryHeapOf[T any] struct {
 	HeapType uint32
-	root     *BinaryTreeNode
-	size     int
+	// Returns a negative number when a < b, zero when a == b and a positive number when a > b.
+	compare func(a T, b T) int
+	items   []*HeapHandleOf[T]
+	// Incremented on every modification, used to detect modification during iteration.
+	version int
+}
+
+// HeapHandle refers to an element in a heap of ints.
+type HeapHandle = HeapHandleOf[int]
+
+// BinaryHeap is a binary heap of ints.
+type BinaryHeap = BinaryHeapOf[int]
+
+// NewBinaryHeap returns an empty heap of naturally ordered elements.
+func NewBinaryHeap[T cmp.Ordered](heapType uint32) *BinaryHeapOf[T] {
+	return &BinaryHeapOf[T]{HeapType: heapType, compare: cmp.Compare[T]}
+}
+
+// NewBinaryHeapFunc returns an empty heap ordered by a comparator, which must not be nil.
+func NewBinaryHeapFunc[T any](heapType uint32, compare func(a T, b T) int) *BinaryHeapOf[T] {
+	return &BinaryHeapOf[T]{HeapType: heapType, compare: compare}
+}
+
+// Value returns the element the handle refers to.
+func (h *HeapHandleOf[T]) Value() T {
+	return h.value
+}
+
+// Insert adds a new element to the heap and returns its handle.
+// Time: O(log n).
+func (heap *BinaryHeapOf[T]) Insert(elem T) *HeapHandleOf[T] {
+	handle := &HeapHandleOf[T]{value: elem, index: len(heap.items), heap: heap}
+	heap.items = append(heap.items, handle)
+	heap.siftUp(handle.index)
+	heap.version++
+	return handle
 }
 
-// Insert adds a new element to the heap.
-func (heap *BinaryHeap) Insert(elem int) error {
-	toInsert := &BinaryTreeNode{value: elem}
-	if heap.root == nil {
-		heap.root = toInsert
-		heap.size = 1
-		return nil
+// InsertAll adds all elements in a slice and returns their handles in the same order.
+// Time: O(n + k) where k is the number of new elements.
+func (heap *BinaryHeapOf[T]) InsertAll(elems []T) []*HeapHandleOf[T] {
+	handles := make([]*HeapHandleOf[T], len(elems))
+	for i, elem := range elems {
+		handles[i] = &HeapHandleOf[T]{value: elem, index: len(heap.items), heap: heap}
+		heap.items = append(heap.items, handles[i])
 	}
-	if heap.size == 1 && heap.getPriority(heap.root, toInsert) == 0 {
-		return errors.New("Value already exists in heap: " + strconv.Itoa(elem))
+	heap.heapify()
+	heap.version++
+	return handles
+}
+
+// Heapify replaces the contents of the heap with the elements in a slice and returns their handles in the same order.
+// Time: O(n).
+func (heap *BinaryHeapOf[T]) Heapify(elems []T) []*HeapHandleOf[T] {
+	heap.Clear()
+	return heap.InsertAll(elems)
+}
+
+// RemoveTop removes and returns the element at the top of the heap.
+// Time: O(log n).
+func (heap *BinaryHeapOf[T]) RemoveTop() (T, error) {
+	if heap.IsEmpty() {
+		var zero T
+		return zero, errors.New("Heap is empty")
+	}
+	return heap.removeAt(0), nil
+}
+
+// Top returns the value at the top of the heap.
+func (heap *BinaryHeapOf[T]) Top() (T, error) {
+	if heap.IsEmpty() {
+		var zero T
+		return zero, errors.New("Heap is empty")
 	}
-	insertAtNode := heap.getInsertNode()
+	return heap.items[0].value, nil
+}
 
-	toInsert.parent = insertAtNode
-	if isLeaf(insertAtNode) {
-		insertAtNode.left = toInsert
-	} else {
-		insertAtNode.right = toInsert
+// Update replaces the element a handle refers to and restores the heap order.
+// Time: O(log n).
+func (heap *BinaryHeapOf[T]) Update(handle *HeapHandleOf[T], elem T) error {
+	if err := heap.checkHandle(handle); err != nil {
+		return err
 	}
-	for toInsert.parent != nil && heap.getPriority(toInsert.parent, toInsert) > 0 {
-		if heap.getPriority(toInsert.parent, toInsert) == 0 {
-			return errors.New("Value already exists in heap: " + strconv.Itoa(elem))
-		}
-		heap.swap(toInsert.parent, toInsert)
+	handle.value = elem
+	if !heap.siftDown(handle.index) {
+		heap.siftUp(handle.index)
 	}
-	heap.size++
+	heap.version++
 	return nil
 }
 
-// InsertAll inserts all elements in a slice sequentially.
-func (heap *BinaryHeap) InsertAll(elems []int) error {
-	var e error
-	for _, elem := range elems {
-		err := heap.Insert(elem)
-		if err != nil {
-			e = err
-		}
+// DecreaseKey replaces the element a handle refers to with one of equal or higher priority,
+// that is a smaller element in a min heap or a larger element in a max heap.
+// Time: O(log n).
+func (heap *BinaryHeapOf[T]) DecreaseKey(handle *HeapHandleOf[T], elem T) error {
+	if err := heap.checkHandle(handle); err != nil {
+		return err
 	}
-	return e
-}
-
-// RemoveTop removes and returns the root of the heap.
-// The only error that can be thrown at this point is empty heap.
-func (heap *BinaryHeap) RemoveTop() (int, error) {
-	if heap.root == nil {
-		return 0, errors.New("Heap is empty")
-	}
-	newRoot := heap.getRemoveNode()
-	value := heap.root.value
-	if isRoot(newRoot) {
-		heap.Clear()
-		return value, nil
-	}
-	if getRelationship(newRoot.parent, newRoot) == LeftChild {
-		newRoot.parent.left = nil
-	} else {
-		newRoot.parent.right = nil
-	}
-	newRoot.parent = nil
-	newRoot.left = heap.root.left
-	newRoot.right = heap.root.right
-
-	currentNode := newRoot
-	didSwap := false
-	for !isLeaf(currentNode) {
-		// Because of the insertion strategy,
-		// it is impossible for the left child to not exist if currentNode is not a leaf.
-		var leftPriority, rightPriority int
-		leftPriority = heap.getPriority(currentNode.left, currentNode)
-		if currentNode.right != nil {
-			rightPriority = heap.getPriority(currentNode.right, currentNode)
-		}
-		// Check for empty variable using 0 is safe because it same values would be caught in Insert.
-		if rightPriority == 0 { // There is only one child (left child).
-			if leftPriority < 0 {
-				heap.swap(currentNode, currentNode.left)
-				didSwap = true
-			} else {
-				break
-			}
-		} else { // Both children exist.
-			if heap.getPriority(currentNode, currentNode.left) < 0 && heap.getPriority(currentNode, currentNode.right) < 0 {
-				// Node is in the correct position.
-				break
-			}
-			// Otherwise, keep bubbling.
-			leftRightPriority := heap.getPriority(currentNode.left, currentNode.right)
-			if leftRightPriority < 0 { // Left is smaller, so bubble left.
-				heap.swap(currentNode, currentNode.left)
-			} else {
-				heap.swap(currentNode, currentNode.right)
-			}
-			didSwap = true
-		}
+	if heap.getPriority(elem, handle.value) > 0 {
+		return errors.New("New value has a lower priority than the current value: " + fmt.Sprint(elem))
 	}
-	// New root was immediately placed in the right position, swap pointers manually.
-	// This can only occur when deleting from a heap of size 3 (resulting in size 2).
-	if !didSwap {
-		heap.root = newRoot
-		heap.root.parent = nil
-		// No need to check the right child, since it cannot exist in a heap of size 2.
-		if heap.root.left != nil {
-			heap.root.left.parent = heap.root
-		}
+	handle.value = elem
+	heap.siftUp(handle.index)
+	heap.version++
+	return nil
+}
+
+// Remove deletes the element a handle refers to and returns it.
+// Time: O(log n).
+func (heap *BinaryHeapOf[T]) Remove(handle *HeapHandleOf[T]) (T, error) {
+	if err := heap.checkHandle(handle); err != nil {
+		var zero T
+		return zero, err
 	}
-	heap.size--
-	return value, nil
+	return heap.removeAt(handle.index), nil
 }
 
-// Top returns the value at the top of the heap.
-func (heap *BinaryHeap) Top() (int, error) {
-	if heap.root == nil {
-		return 0, errors.New("Heap is empty")
+// Merge moves all elements of another heap into this one, leaving the other heap empty.
+// Handles from the other heap remain valid and now refer to this heap. Elements are ordered by this heap's settings.
+// Time: O(n + m).
+func (heap *BinaryHeapOf[T]) Merge(other *BinaryHeapOf[T]) {
+	if other == heap || other.IsEmpty() {
+		return
+	}
+	for _, handle := range other.items {
+		handle.index = len(heap.items)
+		handle.heap = heap
+		heap.items = append(heap.items, handle)
+	}
+	other.items = nil
+	other.version++
+	heap.heapify()
+	heap.version++
+}
+
+// All returns an iterator over the elements of the heap in priority order, without removing them.
+// Stopping after k elements takes O(k log k) time.
+func (heap *BinaryHeapOf[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if heap.IsEmpty() {
+			return
+		}
+		version := heap.version
+		// The frontier is a min heap of the indices whose parents have already been yielded.
+		frontier := []int{0}
+		less := func(i int, j int) bool {
+			return heap.less(frontier[i], frontier[j])
+		}
+		swap := func(i int, j int) {
+			frontier[i], frontier[j] = frontier[j], frontier[i]
+		}
+		for len(frontier) > 0 {
+			index := frontier[0]
+			last := len(frontier) - 1
+			swap(0, last)
+			frontier = frontier[:last]
+			siftDownHelper(0, len(frontier), less, swap)
+			if !yield(heap.items[index].value) {
+				return
+			}
+			checkVersion(version, heap.version)
+			for _, child := range []int{2*index + 1, 2*index + 2} {
+				if child < len(heap.items) {
+					frontier = append(frontier, child)
+					siftUpHelper(len(frontier)-1, less, swap)
+				}
+			}
+		}
 	}
-	return heap.root.value, nil
 }
 
 // Clear removes all elements in the heap.
-func (heap *BinaryHeap) Clear() {
-	heap.root = nil
-	heap.size = 0
+func (heap *BinaryHeapOf[T]) Clear() {
+	for _, handle := range heap.items {
+		handle.index = -1
+	}
+	heap.items = nil
+	heap.version++
 }
 
 // Size returns the number of elements in the heap.
-func (heap *BinaryHeap) Size() int {
-	return heap.size
+func (heap *BinaryHeapOf[T]) Size() int {
+	return len(heap.items)
 }
 
 // IsEmpty returns true if the heap has no elements.
-func (heap *BinaryHeap) IsEmpty() bool {
-	return heap.size == 0
+func (heap *BinaryHeapOf[T]) IsEmpty() bool {
+	return len(heap.items) == 0
 }
 
-func (heap *BinaryHeap) getInsertNode() *BinaryTreeNode {
-	return getInsertNodeHelper(heap.root)
+func (heap *BinaryHeapOf[T]) checkHandle(handle *HeapHandleOf[T]) error {
+	if handle == nil || handle.heap != heap || handle.index < 0 {
+		return errors.New("Handle does not refer to an element in the heap")
+	}
+	return nil
 }
 
-func getInsertNodeHelper(node *BinaryTreeNode) *BinaryTreeNode {
-	if node.left == nil || node.right == nil {
-		return node
+// Removes the element at a given index by replacing it with the last element.
+func (heap *BinaryHeapOf[T]) removeAt(index int) T {
+	handle := heap.items[index]
+	last := len(heap.items) - 1
+	heap.swap(index, last)
+	heap.items[last] = nil
+	heap.items = heap.items[:last]
+	if index < last && !heap.siftDown(index) {
+		heap.siftUp(index)
 	}
-	if subtreeSize(node.left) <= subtreeSize(node.right) {
-		return getInsertNodeHelper(node.left)
+	handle.index = -1
+	heap.version++
+	return handle.value
+}
+
+// Restores the heap order of the whole slice bottom up, starting from the last parent.
+func (heap *BinaryHeapOf[T]) heapify() {
+	for index := len(heap.items)/2 - 1; index >= 0; index-- {
+		heap.siftDown(index)
 	}
-	return getInsertNodeHelper(node.right)
 }
 
-func (heap *BinaryHeap) getRemoveNode() *BinaryTreeNode {
-	return getRemoveNodeHelper(heap.root)
+func (heap *BinaryHeapOf[T]) siftUp(index int) {
+	siftUpHelper(index, heap.less, heap.swap)
 }
 
-func getRemoveNodeHelper(node *BinaryTreeNode) *BinaryTreeNode {
-	if isLeaf(node) {
-		return node
-	}
-	if subtreeSize(node.left) <= subtreeSize(node.right) {
-		return getRemoveNodeHelper(node.right)
-	}
-	return getRemoveNodeHelper(node.left)
+// Returns true if the element moved.
+func (heap *BinaryHeapOf[T]) siftDown(index int) bool {
+	return siftDownHelper(index, len(heap.items), heap.less, heap.swap)
 }
 
-func (heap *BinaryHeap) swap(parent *BinaryTreeNode, child *BinaryTreeNode) {
-	var top, bottomLeft, bottomRight, sibling *BinaryTreeNode
-	top = parent.parent
-	bottomLeft = child.left
-	bottomRight = child.right
-	if getRelationship(parent, child) == LeftChild {
-		sibling = parent.right
-	} else {
-		sibling = parent.left
-	}
+// True if the element at index i belongs above the element at index j.
+func (heap *BinaryHeapOf[T]) less(i int, j int) bool {
+	return heap.getPriority(heap.items[i].value, heap.items[j].value) < 0
+}
 
-	child.parent = top
-	if getRelationship(parent, child) == LeftChild {
-		child.left = parent
-	} else {
-		child.right = parent
-	}
-	if top != nil {
-		if getRelationship(top, parent) == LeftChild {
-			top.left = child
-		} else {
-			top.right = child
-		}
-	}
-	if sibling == nil {
-		// If the sibling doesn't exist, then the child must be a left child.
-		child.right = nil
-	} else {
-		if getRelationship(parent, sibling) == LeftChild {
-			child.left = sibling
-		} else {
-			child.right = sibling
-		}
-		sibling.parent = child
+func (heap *BinaryHeapOf[T]) swap(i int, j int) {
+	heap.items[i], heap.items[j] = heap.items[j], heap.items[i]
+	heap.items[i].index = i
+	heap.items[j].index = j
+}
+
+// Lowest priority goes to the top of the heap.
+func (heap *BinaryHeapOf[T]) getPriority(a T, b T) int {
+	if heap.compare == nil {
+		heap.compare = naturalCompare[T]()
 	}
-	parent.left = bottomLeft
-	if bottomLeft != nil {
-		bottomLeft.parent = parent
+	if heap.HeapType == MinHeap {
+		return heap.compare(a, b)
 	}
-	parent.right = bottomRight
-	if bottomRight != nil {
-		bottomRight.parent = parent
+	return heap.compare(b, a)
+}
+
+// Comparator for the built-in ordered types, used by heaps made without one.
+// Panics for other types, which have no natural order.
+func naturalCompare[T any]() func(a T, b T) int {
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		return orderedCompare[int, T]
+	case int8:
+		return orderedCompare[int8, T]
+	case int16:
+		return orderedCompare[int16, T]
+	case int32:
+		return orderedCompare[int32, T]
+	case int64:
+		return orderedCompare[int64, T]
+	case uint:
+		return orderedCompare[uint, T]
+	case uint8:
+		return orderedCompare[uint8, T]
+	case uint16:
+		return orderedCompare[uint16, T]
+	case uint32:
+		return orderedCompare[uint32, T]
+	case uint64:
+		return orderedCompare[uint64, T]
+	case uintptr:
+		return orderedCompare[uintptr, T]
+	case float32:
+		return orderedCompare[float32, T]
+	case float64:
+		return orderedCompare[float64, T]
+	case string:
+		return orderedCompare[string, T]
 	}
+	panic(fmt.Sprintf("Heap of %T has no comparator, use NewBinaryHeapFunc", zero))
+}
 
-	parent.parent = child
-	if isRoot(child) {
-		heap.root = child
+// Compares two elements of type T that are known to hold the ordered type O.
+func orderedCompare[O cmp.Ordered, T any](a T, b T) int {
+	return cmp.Compare(any(a).(O), any(b).(O))
+}
+
+// Moves the element at index up until its parent belongs above it.
+func siftUpHelper(index int, less func(int, int) bool, swap func(int, int)) {
+	for index > 0 {
+		parent := (index - 1) / 2
+		if !less(index, parent) {
+			return
+		}
+		swap(index, parent)
+		index = parent
 	}
 }
 
-// Lowest priority goes to the top of the heap.
-func (heap *BinaryHeap) getPriority(a *BinaryTreeNode, b *BinaryTreeNode) int {
-	if heap.HeapType == MinHeap {
-		return a.value - b.value
+// Moves the element at index down until it belongs above both children, returns true if it moved.
+func siftDownHelper(index int, length int, less func(int, int) bool, swap func(int, int)) bool {
+	start := index
+	for {
+		top := index
+		for _, child := range []int{2*index + 1, 2*index + 2} {
+			if child < length && less(child, top) {
+				top = child
+			}
+		}
+		if top == index {
+			return index != start
+		}
+		swap(index, top)
+		index = top
 	}
-	return b.value - a.value
 }
@@ -1,31 +1,47 @@
 package structures
 
 import (
+	"cmp"
 	"errors"
+	"fmt"
+	"iter"
 	"strconv"
 )
 
-// BinarySearchTree is a binary tree that follows the property left < middle < right.
-type BinarySearchTree struct {
-	root *BinaryTreeNode
+// BinarySearchTreeOf is a binary tree that follows the property left < middle < right.
+// Nodes are ordered by key and can optionally carry a value.
+type BinarySearchTreeOf[K cmp.Ordered, V any] struct {
+	root *BinaryTreeNodeOf[K, V]
 	size int
+	// Incremented on every insertion or deletion, used to detect modification during iteration.
+	version int
 }
 
-// Insert adds a new value to the tree.
-func (t *BinarySearchTree) Insert(elem int) error {
-	toInsert := BinaryTreeNode{value: elem}
+// BinarySearchTree is a binary search tree of ints.
+type BinarySearchTree = BinarySearchTreeOf[int, struct{}]
+
+// Insert adds a new key to the tree with an empty value.
+func (t *BinarySearchTreeOf[K, V]) Insert(elem K) error {
+	var value V
+	return t.InsertValue(elem, value)
+}
+
+// InsertValue adds a new key and its value to the tree.
+func (t *BinarySearchTreeOf[K, V]) InsertValue(elem K, value V) error {
+	toInsert := BinaryTreeNodeOf[K, V]{key: elem, value: value, size: 1}
 	if t.root == nil {
 		t.root = &toInsert
 		t.size = 1
+		t.version++
 		return nil
 	}
 
 	currentNode := t.root
 	for !isLeaf(currentNode) {
-		if elem == currentNode.value {
-			return errors.New("Value already exists: " + strconv.Itoa(elem))
+		if elem == currentNode.key {
+			return errors.New("Value already exists: " + fmt.Sprint(elem))
 		}
-		if elem < currentNode.value {
+		if elem < currentNode.key {
 			if currentNode.left == nil {
 				break
 			}
@@ -37,21 +53,23 @@ func (t *BinarySearchTree) Insert(elem int) error {
 			currentNode = currentNode.right
 		}
 	}
-	if elem == currentNode.value {
-		return errors.New("Value already exists: " + strconv.Itoa(elem))
+	if elem == currentNode.key {
+		return errors.New("Value already exists: " + fmt.Sprint(elem))
 	}
 	toInsert.parent = currentNode
-	if elem < currentNode.value {
+	if elem < currentNode.key {
 		currentNode.left = &toInsert
 	} else {
 		currentNode.right = &toInsert
 	}
+	updateSizesToRoot(currentNode)
 	t.size++
+	t.version++
 	return nil
 }
 
 // InsertAll inserts all elements in a slice sequentially.
-func (t *BinarySearchTree) InsertAll(elems []int) error {
+func (t *BinarySearchTreeOf[K, V]) InsertAll(elems []K) error {
 	var e error
 	for _, elem := range elems {
 		err := t.Insert(elem)
@@ -63,20 +81,22 @@ func (t *BinarySearchTree) InsertAll(elems []int) error {
 }
 
 // Delete removes a node from the tree.
-func (t *BinarySearchTree) Delete(elem int) error {
+func (t *BinarySearchTreeOf[K, V]) Delete(elem K) error {
 	currentNode := t.root
-	for currentNode != nil && elem != currentNode.value {
-		if elem < currentNode.value {
+	for currentNode != nil && elem != currentNode.key {
+		if elem < currentNode.key {
 			currentNode = currentNode.left
 		} else {
 			currentNode = currentNode.right
 		}
 	}
 	if currentNode == nil {
-		return errors.New("Delete failed, value does not exist: " + strconv.Itoa(elem))
+		return errors.New("Delete failed, value does not exist: " + fmt.Sprint(elem))
 	}
 
-	var rootReplacement *BinaryTreeNode // Used only if deleting the root.
+	var rootReplacement *BinaryTreeNodeOf[K, V] // Used only if deleting the root.
+	// The lowest node whose subtree size changes.
+	resizeFrom := currentNode.parent
 	if isLeaf(currentNode) {
 		if !isRoot(currentNode) {
 			if getRelationship(currentNode.parent, currentNode) == LeftChild {
@@ -87,7 +107,7 @@ func (t *BinarySearchTree) Delete(elem int) error {
 		}
 	} else if numberOfChildren(currentNode) == 1 {
 		// Replace with left or right child, whichever exists.
-		var replacement *BinaryTreeNode
+		var replacement *BinaryTreeNodeOf[K, V]
 		if currentNode.left != nil {
 			replacement = currentNode.left
 		} else {
@@ -110,12 +130,20 @@ func (t *BinarySearchTree) Delete(elem int) error {
 		// Element has two children.
 		// Find the minimum in the right subtree, replace the node with it.
 		minInRightSubtree := getMinInSubtree(currentNode.right)
+		if minInRightSubtree.parent == currentNode {
+			resizeFrom = minInRightSubtree
+		} else {
+			resizeFrom = minInRightSubtree.parent
+		}
 		// The replacement node is either a leaf or has a right child.
 		if getRelationship(minInRightSubtree.parent, minInRightSubtree) == LeftChild {
 			minInRightSubtree.parent.left = minInRightSubtree.right
 		} else {
 			minInRightSubtree.parent.right = minInRightSubtree.right
 		}
+		if minInRightSubtree.right != nil {
+			minInRightSubtree.right.parent = minInRightSubtree.parent
+		}
 
 		minInRightSubtree.parent = currentNode.parent
 		minInRightSubtree.left = currentNode.left
@@ -136,43 +164,66 @@ func (t *BinarySearchTree) Delete(elem int) error {
 			minInRightSubtree.parent.right = minInRightSubtree
 		}
 	}
-	if elem == t.root.value {
+	if elem == t.root.key {
 		t.root = rootReplacement
 	}
+	updateSizesToRoot(resizeFrom)
 	t.size--
+	t.version++
 	return nil
 }
 
-// Search returns true if the value exists in the tree.
-func (t *BinarySearchTree) Search(elem int) bool {
-	if t.root == nil {
-		return false
+// Search returns true if the key exists in the tree.
+func (t *BinarySearchTreeOf[K, V]) Search(elem K) bool {
+	return searchHelper(elem, t.root) != nil
+}
+
+// Get returns the value stored with a given key.
+func (t *BinarySearchTreeOf[K, V]) Get(elem K) (V, error) {
+	node := searchHelper(elem, t.root)
+	if node == nil {
+		var zero V
+		return zero, errors.New("Key does not exist: " + fmt.Sprint(elem))
 	}
-	return searchHelper(elem, t.root)
+	return node.value, nil
 }
 
-func searchHelper(elem int, node *BinaryTreeNode) bool {
+// Put stores a value with a given key, overriding the value if the key already exists.
+func (t *BinarySearchTreeOf[K, V]) Put(elem K, value V) {
+	node := searchHelper(elem, t.root)
+	if node != nil {
+		node.value = value
+		return
+	}
+	t.InsertValue(elem, value)
+}
+
+func searchHelper[K cmp.Ordered, V any](elem K, node *BinaryTreeNodeOf[K, V]) *BinaryTreeNodeOf[K, V] {
 	if node == nil {
-		return false
+		return nil
 	}
-	if elem == node.value {
-		return true
+	if elem == node.key {
+		return node
 	}
-	if elem < node.value {
+	if elem < node.key {
 		return searchHelper(elem, node.left)
 	}
 	return searchHelper(elem, node.right)
 }
 
 // Depth returns the depth of a given element, -1 if the element is not found.
-func (t *BinarySearchTree) Depth(elem int) int {
+func (t *BinarySearchTreeOf[K, V]) Depth(elem K) int {
+	return depthHelper(elem, t.root)
+}
+
+func depthHelper[K cmp.Ordered, V any](elem K, root *BinaryTreeNodeOf[K, V]) int {
 	depth := 0
-	currentNode := t.root
+	currentNode := root
 	for currentNode != nil {
-		if elem == currentNode.value {
+		if elem == currentNode.key {
 			return depth
 		}
-		if elem < currentNode.value {
+		if elem < currentNode.key {
 			currentNode = currentNode.left
 		} else {
 			currentNode = currentNode.right
@@ -183,35 +234,227 @@ func (t *BinarySearchTree) Depth(elem int) int {
 }
 
 // Clear removes all nodes in the tree.
-func (t *BinarySearchTree) Clear() {
+func (t *BinarySearchTreeOf[K, V]) Clear() {
 	t.root = nil
 	t.size = 0
+	t.version++
 }
 
 // IsEmpty returns true if the tree contains no nodes.
-func (t *BinarySearchTree) IsEmpty() bool {
+func (t *BinarySearchTreeOf[K, V]) IsEmpty() bool {
 	return t.size == 0
 }
 
 // Size returns the number of nodes in the tree.
-func (t *BinarySearchTree) Size() int {
+func (t *BinarySearchTreeOf[K, V]) Size() int {
 	return t.size
 }
 
-// InOrder returns the tree's in order traversal as a slice.
-func (t *BinarySearchTree) InOrder() []int {
-	temp := make([]int, 0)
+// InOrder returns the keys of the tree's in order traversal as a slice.
+func (t *BinarySearchTreeOf[K, V]) InOrder() []K {
+	temp := make([]K, 0)
 	result := &temp
 	inOrderHelper(t.root, &result)
 	return *result
 }
 
-func inOrderHelper(node *BinaryTreeNode, result **[]int) {
+func inOrderHelper[K, V any](node *BinaryTreeNodeOf[K, V], result **[]K) {
 	if node == nil {
 		return
 	}
 	inOrderHelper(node.left, result)
-	temp := append(**result, node.value)
+	temp := append(**result, node.key)
 	*result = &temp
 	inOrderHelper(node.right, result)
 }
+
+// Select returns the k-th smallest key, starting from 0.
+// Time: O(h) where h is the height of the tree.
+func (t *BinarySearchTreeOf[K, V]) Select(k int) (K, error) {
+	node := selectHelper(t.root, k)
+	if node == nil {
+		var zero K
+		return zero, errors.New("Index out of bounds: " + strconv.Itoa(k))
+	}
+	return node.key, nil
+}
+
+// Rank returns the number of keys less than elem, elem does not need to be in the tree.
+// Time: O(h) where h is the height of the tree.
+func (t *BinarySearchTreeOf[K, V]) Rank(elem K) int {
+	return rankHelper(t.root, elem)
+}
+
+// Min returns the smallest key in the tree.
+func (t *BinarySearchTreeOf[K, V]) Min() (K, error) {
+	if t.root == nil {
+		var zero K
+		return zero, errors.New("Tree is empty")
+	}
+	return getMinInSubtree(t.root).key, nil
+}
+
+// Max returns the largest key in the tree.
+func (t *BinarySearchTreeOf[K, V]) Max() (K, error) {
+	if t.root == nil {
+		var zero K
+		return zero, errors.New("Tree is empty")
+	}
+	return getMaxInSubtree(t.root).key, nil
+}
+
+// Floor returns the largest key less than or equal to elem.
+func (t *BinarySearchTreeOf[K, V]) Floor(elem K) (K, error) {
+	return keyOrError(lowerHelper(t.root, elem, true), "No key less than or equal to: "+fmt.Sprint(elem))
+}
+
+// Ceiling returns the smallest key greater than or equal to elem.
+func (t *BinarySearchTreeOf[K, V]) Ceiling(elem K) (K, error) {
+	return keyOrError(higherHelper(t.root, elem, true), "No key greater than or equal to: "+fmt.Sprint(elem))
+}
+
+// Predecessor returns the largest key less than elem, elem does not need to be in the tree.
+func (t *BinarySearchTreeOf[K, V]) Predecessor(elem K) (K, error) {
+	return keyOrError(lowerHelper(t.root, elem, false), "No key less than: "+fmt.Sprint(elem))
+}
+
+// Successor returns the smallest key greater than elem, elem does not need to be in the tree.
+func (t *BinarySearchTreeOf[K, V]) Successor(elem K) (K, error) {
+	return keyOrError(higherHelper(t.root, elem, false), "No key greater than: "+fmt.Sprint(elem))
+}
+
+// Range returns an iterator over the keys and values with lo <= key <= hi, in order.
+// Only the subtrees that overlap the range are visited.
+func (t *BinarySearchTreeOf[K, V]) Range(lo K, hi K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		rangeSeqHelper(t.root, lo, hi, versionedYield(&t.version, yield))
+	}
+}
+
+func keyOrError[K, V any](node *BinaryTreeNodeOf[K, V], message string) (K, error) {
+	if node == nil {
+		var zero K
+		return zero, errors.New(message)
+	}
+	return node.key, nil
+}
+
+// CheckInvariants returns an error describing the first violated binary search tree property, nil if the tree is valid.
+func (t *BinarySearchTreeOf[K, V]) CheckInvariants() error {
+	return checkBinarySearchTree(t.root, t.size)
+}
+
+// All returns an iterator over the keys and values of the tree in order.
+func (t *BinarySearchTreeOf[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		inOrderSeqHelper(t.root, versionedYield(&t.version, yield), false)
+	}
+}
+
+// Backward returns an iterator over the keys and values of the tree in reverse order.
+func (t *BinarySearchTreeOf[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		inOrderSeqHelper(t.root, versionedYield(&t.version, yield), true)
+	}
+}
+
+// Keys returns an iterator over the keys of the tree in order.
+func (t *BinarySearchTreeOf[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range t.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the tree, ordered by key.
+func (t *BinarySearchTreeOf[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range t.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// PreOrder returns an iterator over the keys and values of the tree's pre order traversal.
+func (t *BinarySearchTreeOf[K, V]) PreOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		preOrderSeqHelper(t.root, versionedYield(&t.version, yield))
+	}
+}
+
+// PostOrder returns an iterator over the keys and values of the tree's post order traversal.
+func (t *BinarySearchTreeOf[K, V]) PostOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		postOrderSeqHelper(t.root, versionedYield(&t.version, yield))
+	}
+}
+
+// LevelOrder returns an iterator over the keys and values of the tree's level order traversal.
+func (t *BinarySearchTreeOf[K, V]) LevelOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		levelOrderSeqHelper(t.root, versionedYield(&t.version, yield))
+	}
+}
+
+// Wraps yield so that the iteration panics if the tree's version changes between two steps.
+func versionedYield[K, V any](version *int, yield func(K, V) bool) func(K, V) bool {
+	expected := *version
+	return func(key K, value V) bool {
+		if !yield(key, value) {
+			return false
+		}
+		checkVersion(expected, *version)
+		return true
+	}
+}
+
+// The traversal helpers return false once the consumer stops the iteration.
+func inOrderSeqHelper[K, V any](node *BinaryTreeNodeOf[K, V], yield func(K, V) bool, reverse bool) bool {
+	if node == nil {
+		return true
+	}
+	first, second := node.left, node.right
+	if reverse {
+		first, second = second, first
+	}
+	return inOrderSeqHelper(first, yield, reverse) && yield(node.key, node.value) && inOrderSeqHelper(second, yield, reverse)
+}
+
+func preOrderSeqHelper[K, V any](node *BinaryTreeNodeOf[K, V], yield func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	return yield(node.key, node.value) && preOrderSeqHelper(node.left, yield) && preOrderSeqHelper(node.right, yield)
+}
+
+func postOrderSeqHelper[K, V any](node *BinaryTreeNodeOf[K, V], yield func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	return postOrderSeqHelper(node.left, yield) && postOrderSeqHelper(node.right, yield) && yield(node.key, node.value)
+}
+
+func levelOrderSeqHelper[K, V any](root *BinaryTreeNodeOf[K, V], yield func(K, V) bool) {
+	if root == nil {
+		return
+	}
+	queue := &ArrayQueueOf[*BinaryTreeNodeOf[K, V]]{}
+	queue.Enqueue(root)
+	for !queue.IsEmpty() {
+		node, _ := queue.Dequeue()
+		if !yield(node.key, node.value) {
+			return
+		}
+		if node.left != nil {
+			queue.Enqueue(node.left)
+		}
+		if node.right != nil {
+			queue.Enqueue(node.right)
+		}
+	}
+}
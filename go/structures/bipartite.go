@@ -0,0 +1,355 @@
+package structures
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Bipartition splits the vertices of a graph into two sides so that every edge joins the two sides, with edges treated as undirected.
+type Bipartition struct {
+	// Left and Right are in insertion order, the first vertex of each connected component is on the left.
+	Left  []string
+	Right []string
+}
+
+// Matching is a set of edges no two of which share a vertex, with edges treated as undirected.
+type Matching struct {
+	// Edges of the matching in insertion order, in the direction they were added to the graph.
+	Edges []*Edge
+	// Weight is the total weight of the edges.
+	Weight int
+	// Mates maps every matched vertex to the vertex it is matched with.
+	Mates map[string]string
+}
+
+// The edges of a graph treated as undirected, with the vertices indexed by insertion order.
+type undirectedView struct {
+	vertices []string
+	index    map[string]int
+	edges    []*Edge
+	// Indices of the edges at each vertex in insertion order, a self loop appears once.
+	incident [][]int
+}
+
+func newUndirectedView(g Graph) *undirectedView {
+	view := &undirectedView{index: make(map[string]int)}
+	for vertex := range g.Vertices() {
+		view.index[vertex] = len(view.vertices)
+		view.vertices = append(view.vertices, vertex)
+	}
+	view.incident = make([][]int, len(view.vertices))
+	for edge := range g.Edges() {
+		i := len(view.edges)
+		view.edges = append(view.edges, edge)
+		from, to := view.index[edge.From()], view.index[edge.To()]
+		view.incident[from] = append(view.incident[from], i)
+		if to != from {
+			view.incident[to] = append(view.incident[to], i)
+		}
+	}
+	return view
+}
+
+// Returns the end of an edge that is not the given vertex.
+func (view *undirectedView) other(i int, vertex int) int {
+	from := view.index[view.edges[i].From()]
+	if from != vertex {
+		return from
+	}
+	return view.index[view.edges[i].To()]
+}
+
+// Colours each component by breadth first search from its first vertex, true for vertices on the left.
+// Returns the indices of an odd cycle instead if two vertices of the same colour are adjacent.
+func (view *undirectedView) colour() ([]bool, []int) {
+	n := len(view.vertices)
+	depth := make([]int, n)
+	parent := make([]int, n)
+	for i := range n {
+		depth[i] = -1
+	}
+	for root := range n {
+		if depth[root] != -1 {
+			continue
+		}
+		depth[root], parent[root] = 0, -1
+		queue := []int{root}
+		for len(queue) > 0 {
+			vertex := queue[0]
+			queue = queue[1:]
+			for _, i := range view.incident[vertex] {
+				neighbour := view.other(i, vertex)
+				if depth[neighbour] == -1 {
+					depth[neighbour], parent[neighbour] = depth[vertex]+1, vertex
+					queue = append(queue, neighbour)
+				} else if depth[neighbour]%2 == depth[vertex]%2 {
+					return nil, oddCycle(parent, depth, vertex, neighbour)
+				}
+			}
+		}
+	}
+	left := make([]bool, n)
+	for i := range n {
+		left[i] = depth[i]%2 == 0
+	}
+	return left, nil
+}
+
+// Joins the tree paths from two adjacent vertices at the same depth parity to their lowest common ancestor.
+// The cycle runs from the ancestor down to a, across to b and back up, so it has odd length.
+func oddCycle(parent []int, depth []int, a int, b int) []int {
+	up, down := []int{a}, []int{b}
+	for a != b {
+		if depth[a] >= depth[b] {
+			a = parent[a]
+			up = append(up, a)
+		} else {
+			b = parent[b]
+			down = append(down, b)
+		}
+	}
+	// Both paths end at the ancestor, which belongs at the start of the cycle only.
+	down = down[:len(down)-1]
+	slices.Reverse(up)
+	return append(up, down...)
+}
+
+// IsBipartite returns a split of the vertices into two sides with every edge between them, edges treated as undirected.
+// If there is none, returns nil and an odd cycle instead.
+// Time: O(V + E).
+func IsBipartite(g Graph) (*Bipartition, []string) {
+	view := newUndirectedView(g)
+	left, cycle := view.colour()
+	if cycle != nil {
+		return nil, view.names(cycle)
+	}
+	result := &Bipartition{Left: make([]string, 0), Right: make([]string, 0)}
+	for i, vertex := range view.vertices {
+		if left[i] {
+			result.Left = append(result.Left, vertex)
+		} else {
+			result.Right = append(result.Right, vertex)
+		}
+	}
+	return result, nil
+}
+
+func (view *undirectedView) names(indices []int) []string {
+	result := make([]string, len(indices))
+	for i, index := range indices {
+		result[i] = view.vertices[index]
+	}
+	return result
+}
+
+// Colours the graph, returning an error naming an odd cycle if it is not bipartite.
+func (view *undirectedView) bipartite() ([]bool, error) {
+	left, cycle := view.colour()
+	if cycle != nil {
+		names := view.names(cycle)
+		return nil, errors.New("Graph is not bipartite, odd cycle: " + strings.Join(append(names, names[0]), " -> "))
+	}
+	return left, nil
+}
+
+// Builds a matching from the edge matching each vertex, -1 for unmatched vertices.
+func (view *undirectedView) matching(mate []int) *Matching {
+	chosen := make([]int, 0)
+	for vertex, i := range mate {
+		// Each edge is seen from both of its ends, keep it once.
+		if i != -1 && view.index[view.edges[i].From()] == vertex {
+			chosen = append(chosen, i)
+		}
+	}
+	slices.Sort(chosen)
+	result := &Matching{Edges: make([]*Edge, 0, len(chosen)), Mates: make(map[string]string)}
+	for _, i := range chosen {
+		edge := view.edges[i]
+		result.Edges = append(result.Edges, edge)
+		result.Weight += edge.Weight()
+		result.Mates[edge.From()] = edge.To()
+		result.Mates[edge.To()] = edge.From()
+	}
+	return result
+}
+
+// HopcroftKarp returns a maximum cardinality matching of a bipartite graph using the Hopcroft-Karp algorithm.
+// Each phase finds a maximal set of vertex disjoint shortest augmenting paths, there are O(sqrt(V)) phases.
+// Time: O(E sqrt(V)).
+func HopcroftKarp(g Graph) (*Matching, error) {
+	view := newUndirectedView(g)
+	left, err := view.bipartite()
+	if err != nil {
+		return nil, err
+	}
+	n := len(view.vertices)
+	mate := make([]int, n)
+	for i := range n {
+		mate[i] = -1
+	}
+	for {
+		// Length of the shortest alternating path from a free left vertex to each left vertex, -1 if none.
+		layer := make([]int, n)
+		queue := make([]int, 0)
+		for vertex := range n {
+			layer[vertex] = -1
+			if left[vertex] && mate[vertex] == -1 {
+				layer[vertex] = 0
+				queue = append(queue, vertex)
+			}
+		}
+		found := false
+		for len(queue) > 0 {
+			vertex := queue[0]
+			queue = queue[1:]
+			for _, i := range view.incident[vertex] {
+				neighbour := view.other(i, vertex)
+				if mate[neighbour] == -1 {
+					found = true
+				} else if next := view.other(mate[neighbour], neighbour); layer[next] == -1 {
+					layer[next] = layer[vertex] + 1
+					queue = append(queue, next)
+				}
+			}
+		}
+		if !found {
+			return view.matching(mate), nil
+		}
+
+		// Position of the next edge to try from each left vertex, so each edge is tried once per phase.
+		next := make([]int, n)
+		var augment func(vertex int) bool
+		augment = func(vertex int) bool {
+			for ; next[vertex] < len(view.incident[vertex]); next[vertex]++ {
+				i := view.incident[vertex][next[vertex]]
+				neighbour := view.other(i, vertex)
+				if mate[neighbour] == -1 || (layer[view.other(mate[neighbour], neighbour)] == layer[vertex]+1 && augment(view.other(mate[neighbour], neighbour))) {
+					mate[vertex], mate[neighbour] = i, i
+					return true
+				}
+			}
+			// No augmenting path passes through this vertex for the rest of the phase.
+			layer[vertex] = -1
+			return false
+		}
+		for vertex := range n {
+			if left[vertex] && mate[vertex] == -1 {
+				augment(vertex)
+			}
+		}
+	}
+}
+
+// Hungarian returns a minimum weight perfect matching of a bipartite graph using the Hungarian algorithm.
+// Uses the shortest augmenting path formulation with vertex potentials, adding one left vertex at a time.
+// Time: O(V^3).
+func Hungarian(g Graph) (*Matching, error) {
+	view := newUndirectedView(g)
+	isLeft, err := view.bipartite()
+	if err != nil {
+		return nil, err
+	}
+	left, right := make([]int, 0), make([]int, 0)
+	for vertex := range view.vertices {
+		if isLeft[vertex] {
+			left = append(left, vertex)
+		} else {
+			right = append(right, vertex)
+		}
+	}
+	if len(left) != len(right) {
+		return nil, errors.New("Graph has no perfect matching, the sides have different sizes")
+	}
+	n := len(left)
+	column := make([]int, len(view.vertices))
+	for j, vertex := range right {
+		column[vertex] = j + 1
+	}
+
+	// Lightest edge between each pair, earliest inserted on ties, with rows and columns indexed from 1.
+	// Missing edges cost more than any perfect matching of real edges, so they are only used if there is no such matching.
+	missing := 1
+	for _, edge := range view.edges {
+		missing += max(edge.Weight(), -edge.Weight())
+	}
+	best := make([][]int, n+1)
+	cost := make([][]int, n+1)
+	for i := range n + 1 {
+		best[i] = make([]int, n+1)
+		cost[i] = make([]int, n+1)
+		for j := range n + 1 {
+			best[i][j] = -1
+			cost[i][j] = missing
+		}
+	}
+	for row, vertex := range left {
+		for _, i := range view.incident[vertex] {
+			j := column[view.other(i, vertex)]
+			if best[row+1][j] == -1 || view.edges[i].Weight() < cost[row+1][j] {
+				best[row+1][j] = i
+				cost[row+1][j] = view.edges[i].Weight()
+			}
+		}
+	}
+
+	// Potentials of rows and columns, the row matched to each column and the previous column on the shortest path to it.
+	// Column 0 is a placeholder matched to the row being added.
+	u := make([]int, n+1)
+	v := make([]int, n+1)
+	match := make([]int, n+1)
+	way := make([]int, n+1)
+	for row := 1; row <= n; row++ {
+		match[0] = row
+		j0 := 0
+		minimum := make([]int, n+1)
+		used := make([]bool, n+1)
+		for j := range n + 1 {
+			minimum[j] = math.MaxInt
+		}
+		for match[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := match[j0], math.MaxInt, 0
+			for j := 1; j <= n; j++ {
+				if used[j] {
+					continue
+				}
+				if reduced := cost[i0][j] - u[i0] - v[j]; reduced < minimum[j] {
+					minimum[j], way[j] = reduced, j0
+				}
+				if minimum[j] < delta {
+					delta, j1 = minimum[j], j
+				}
+			}
+			for j := range n + 1 {
+				if used[j] {
+					u[match[j]] += delta
+					v[j] -= delta
+				} else {
+					minimum[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		// Flip the matched and unmatched edges along the path back to the placeholder column.
+		for j0 != 0 {
+			j1 := way[j0]
+			match[j0] = match[j1]
+			j0 = j1
+		}
+	}
+
+	mate := make([]int, len(view.vertices))
+	for i := range mate {
+		mate[i] = -1
+	}
+	for j := 1; j <= n; j++ {
+		i := best[match[j]][j]
+		if i == -1 {
+			return nil, errors.New("Graph has no perfect matching")
+		}
+		mate[left[match[j]-1]], mate[right[j-1]] = i, i
+	}
+	return view.matching(mate), nil
+}
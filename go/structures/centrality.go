@@ -0,0 +1,198 @@
+package structures
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// Most iterations PageRank makes before giving up on converging.
+const pageRankIterations = 1000
+
+// ClosenessCentrality returns the closeness of each vertex from the weighted distances to the vertices it can reach.
+// Uses the Wasserman and Faust formula (r / sum) * (r / (n - 1)) for a vertex that reaches r other vertices
+// at a total distance sum, so that vertices reaching few others score lower. Vertices reaching none score 0,
+// and vertices reaching others only through zero weight edges, at a total distance of 0, score +Inf.
+// Edge weights must be non-negative.
+func ClosenessCentrality(graph Graph) (map[string]float64, error) {
+	g := graph.directed()
+	if err := checkDijkstra(g); err != nil {
+		return nil, err
+	}
+	c := g.ToCSR()
+	n := len(c.names)
+	result := make(map[string]float64, n)
+	for source, vertex := range c.names {
+		distance, previous := c.dijkstra(source)
+		reached, sum := 0, 0
+		for i, d := range distance {
+			if previous[i] != -1 {
+				reached++
+				sum += d
+			}
+		}
+		// The source reaches itself.
+		reached--
+		switch {
+		case reached == 0:
+			result[vertex] = 0
+		case sum == 0:
+			result[vertex] = math.Inf(1)
+		default:
+			result[vertex] = float64(reached) / float64(sum) * float64(reached) / float64(n-1)
+		}
+	}
+	return result, nil
+}
+
+// BetweennessCentrality returns the betweenness of each vertex using Brandes' algorithm on the weighted distances,
+// the sum over ordered pairs of other vertices of the fraction of shortest paths between them that pass through it.
+// Parallel edges give separate paths and self loops are ignored. Edge weights must be non-negative,
+// and zero weight edges must not form a cycle, as the number of shortest paths around one is infinite.
+// Time: O(V E log V).
+func BetweennessCentrality(graph Graph) (map[string]float64, error) {
+	g := graph.directed()
+	if err := checkDijkstra(g); err != nil {
+		return nil, err
+	}
+	c := g.ToCSR()
+	n := len(c.names)
+	betweenness := make([]float64, n)
+	paths := make([]float64, n)
+	dependency := make([]float64, n)
+	previous := make([][]int, n)
+	state := make([]int, n)
+	for source := range n {
+		distance, parent := c.dijkstra(source)
+		for i := range n {
+			paths[i], dependency[i], previous[i], state[i] = 0, 0, previous[i][:0], 0
+		}
+		// Every edge on a shortest path, found from the distances rather than while they are settled,
+		// since a zero weight edge can lead to a vertex already settled at the same distance.
+		for vertex := range n {
+			if parent[vertex] == -1 {
+				continue
+			}
+			for i := c.offsets[vertex]; i < c.offsets[vertex+1]; i++ {
+				next := c.targets[i]
+				if next != vertex && distance[vertex]+c.weights[i] == distance[next] {
+					previous[next] = append(previous[next], vertex)
+				}
+			}
+		}
+		// Vertices after every vertex before them on a shortest path.
+		order := make([]int, 0, n)
+		var visit func(vertex int) error
+		visit = func(vertex int) error {
+			state[vertex] = 1
+			for _, before := range previous[vertex] {
+				if state[before] == 1 {
+					return errors.New("Betweenness does not support cycles of zero weight edges, found from: " + c.names[source])
+				}
+				if state[before] == 0 {
+					if err := visit(before); err != nil {
+						return err
+					}
+				}
+			}
+			state[vertex] = 2
+			order = append(order, vertex)
+			return nil
+		}
+		for vertex := range n {
+			if parent[vertex] != -1 && state[vertex] == 0 {
+				if err := visit(vertex); err != nil {
+					return nil, err
+				}
+			}
+		}
+		paths[source] = 1
+		for _, vertex := range order {
+			for _, before := range previous[vertex] {
+				paths[vertex] += paths[before]
+			}
+		}
+		// Dependencies accumulate from the furthest vertices back towards the source.
+		for i := len(order) - 1; i >= 0; i-- {
+			vertex := order[i]
+			for _, before := range previous[vertex] {
+				dependency[before] += paths[before] / paths[vertex] * (1 + dependency[vertex])
+			}
+			if vertex != source {
+				betweenness[vertex] += dependency[vertex]
+			}
+		}
+	}
+	result := make(map[string]float64, n)
+	for i, vertex := range c.names {
+		result[vertex] = betweenness[i]
+	}
+	return result, nil
+}
+
+// PageRank returns the PageRank of each vertex by power iteration. A random walk follows an outgoing edge
+// with probability proportional to its weight, or with probability 1 - damping jumps to a vertex chosen uniformly,
+// as it does from a vertex without outgoing weight. Stops when the total change in ranks is below the tolerance.
+func PageRank(graph Graph, damping float64, tolerance float64) (map[string]float64, error) {
+	g := graph.directed()
+	if !(damping >= 0 && damping <= 1) {
+		return nil, errors.New("Damping factor must be between 0 and 1: " + strconv.FormatFloat(damping, 'g', -1, 64))
+	}
+	if !(tolerance > 0) {
+		return nil, errors.New("Tolerance must be positive: " + strconv.FormatFloat(tolerance, 'g', -1, 64))
+	}
+	for edge := range g.Edges() {
+		if edge.weight < 0 {
+			return nil, errors.New("PageRank does not support negative edge weights: " + edge.From() + "->" + edge.To())
+		}
+	}
+	c := g.ToCSR()
+	n := len(c.names)
+	result := make(map[string]float64, n)
+	if n == 0 {
+		return result, nil
+	}
+	outWeight := make([]int, n)
+	for vertex := range n {
+		for i := c.offsets[vertex]; i < c.offsets[vertex+1]; i++ {
+			outWeight[vertex] += c.weights[i]
+		}
+	}
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+	next := make([]float64, n)
+	for range pageRankIterations {
+		// Rank spread evenly, by the jumps and from vertices the walk cannot leave by an edge.
+		spread := 1 - damping
+		for vertex := range n {
+			if outWeight[vertex] == 0 {
+				spread += damping * rank[vertex]
+			}
+		}
+		for i := range next {
+			next[i] = spread / float64(n)
+		}
+		for vertex := range n {
+			if outWeight[vertex] == 0 {
+				continue
+			}
+			for i := c.offsets[vertex]; i < c.offsets[vertex+1]; i++ {
+				next[c.targets[i]] += damping * rank[vertex] * float64(c.weights[i]) / float64(outWeight[vertex])
+			}
+		}
+		change := 0.0
+		for i := range rank {
+			change += math.Abs(next[i] - rank[i])
+		}
+		rank, next = next, rank
+		if change < tolerance {
+			for i, vertex := range c.names {
+				result[vertex] = rank[i]
+			}
+			return result, nil
+		}
+	}
+	return nil, errors.New("PageRank did not converge in " + strconv.Itoa(pageRankIterations) + " iterations")
+}
@@ -0,0 +1,413 @@
+package structures
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+)
+
+// Most passes of Kernighan-Lin and sweeps of label propagation before stopping.
+const (
+	kernighanLinPasses     = 50
+	labelPropagationSweeps = 100
+)
+
+// Partition splits the vertices of a graph into communities.
+type Partition struct {
+	// Community maps each vertex to its community, communities are numbered from 0 in the order of their first vertex.
+	Community map[string]int
+	// Communities lists the vertices of each community in insertion order.
+	Communities [][]string
+	// Modularity of the partition, with edges treated as undirected and weighted.
+	Modularity float64
+}
+
+// The graph treated as undirected, with the weights of parallel and opposite edges added together.
+// A self loop of weight w adds 2w to the loop weight of its vertex, as it adds to the degree at both ends.
+type communityView struct {
+	vertices []string
+	index    map[string]int
+	// Neighbours of each vertex in the order they are first joined, without the vertex itself.
+	neighbours [][]int
+	weights    []map[int]float64
+	loops      []float64
+	// Total weight at each vertex, and at all vertices together.
+	degree []float64
+	total  float64
+}
+
+func newCommunityView(g DirectedWeightedGraph) (*communityView, error) {
+	view := &communityView{index: make(map[string]int)}
+	for vertex := range g.Vertices() {
+		view.index[vertex] = len(view.vertices)
+		view.vertices = append(view.vertices, vertex)
+	}
+	n := len(view.vertices)
+	view.neighbours = make([][]int, n)
+	view.weights = make([]map[int]float64, n)
+	view.loops = make([]float64, n)
+	view.degree = make([]float64, n)
+	for i := range n {
+		view.weights[i] = make(map[int]float64)
+	}
+	for edge := range g.Edges() {
+		if edge.weight < 0 {
+			return nil, errors.New("Community detection does not support negative edge weights: " + edge.From() + "->" + edge.To())
+		}
+		view.join(view.index[edge.From()], view.index[edge.To()], float64(edge.weight))
+	}
+	return view, nil
+}
+
+// Adds weight between two vertices in both directions.
+func (view *communityView) join(a int, b int, weight float64) {
+	view.degree[a] += weight
+	view.degree[b] += weight
+	view.total += 2 * weight
+	if a == b {
+		view.loops[a] += 2 * weight
+		return
+	}
+	if _, ok := view.weights[a][b]; !ok {
+		view.neighbours[a] = append(view.neighbours[a], b)
+		view.neighbours[b] = append(view.neighbours[b], a)
+	}
+	view.weights[a][b] += weight
+	view.weights[b][a] += weight
+}
+
+// Modularity of an assignment of the vertices to communities.
+// The fraction of the weight inside communities, less the fraction expected if edges were placed at random.
+func (view *communityView) modularity(community []int) float64 {
+	if view.total == 0 {
+		return 0
+	}
+	inside := make(map[int]float64)
+	degree := make(map[int]float64)
+	// Communities in the order of their first vertex, so the sum is the same on every run.
+	order := make([]int, 0)
+	for vertex := range view.vertices {
+		c := community[vertex]
+		if _, ok := degree[c]; !ok {
+			order = append(order, c)
+		}
+		inside[c] += view.loops[vertex]
+		degree[c] += view.degree[vertex]
+		for neighbour, weight := range view.weights[vertex] {
+			if community[neighbour] == c {
+				inside[c] += weight
+			}
+		}
+	}
+	result := 0.0
+	for _, c := range order {
+		result += inside[c]/view.total - (degree[c]/view.total)*(degree[c]/view.total)
+	}
+	return result
+}
+
+// Numbers the communities in the order of their first vertex and lists their vertices.
+func (view *communityView) partition(community []int) *Partition {
+	result := &Partition{Community: make(map[string]int, len(view.vertices)), Communities: make([][]string, 0)}
+	renumbered := make(map[int]int)
+	for vertex, name := range view.vertices {
+		c, ok := renumbered[community[vertex]]
+		if !ok {
+			c = len(result.Communities)
+			renumbered[community[vertex]] = c
+			result.Communities = append(result.Communities, make([]string, 0))
+		}
+		result.Community[name] = c
+		result.Communities[c] = append(result.Communities[c], name)
+	}
+	result.Modularity = view.modularity(community)
+	return result
+}
+
+// Modularity returns the modularity of an assignment of every vertex to a community, with edges treated as undirected.
+// Edge weights must be non-negative.
+func Modularity(graph Graph, community map[string]int) (float64, error) {
+	g := graph.directed()
+	view, err := newCommunityView(g)
+	if err != nil {
+		return 0, err
+	}
+	assignment := make([]int, len(view.vertices))
+	for i, vertex := range view.vertices {
+		c, ok := community[vertex]
+		if !ok {
+			return 0, errors.New("Vertex has no community: " + vertex)
+		}
+		assignment[i] = c
+	}
+	return view.modularity(assignment), nil
+}
+
+// LabelPropagation splits the graph into communities by label propagation, with edges treated as undirected.
+// Every vertex starts in a community of its own, then in random order each vertex joins the community
+// with the most weight among its neighbours, ties broken at random, until every vertex is in such a community.
+// The same seed always gives the same communities. Edge weights must be non-negative. Time: O(E) per sweep.
+func LabelPropagation(graph Graph, seed uint64) (*Partition, error) {
+	g := graph.directed()
+	view, err := newCommunityView(g)
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewPCG(seed, 0))
+	n := len(view.vertices)
+	label := make([]int, n)
+	for i := range n {
+		label[i] = i
+	}
+	// Most weighted labels around a vertex, false if its own label is not one of them.
+	bestLabels := func(vertex int) ([]int, bool) {
+		weights := make(map[int]float64)
+		best := make([]int, 0)
+		highest := 0.0
+		for _, neighbour := range view.neighbours[vertex] {
+			l := label[neighbour]
+			weights[l] += view.weights[vertex][neighbour]
+		}
+		// Neighbour order rather than map order keeps the result reproducible.
+		for _, neighbour := range view.neighbours[vertex] {
+			l := label[neighbour]
+			weight := weights[l]
+			if weight > highest {
+				best, highest = best[:0], weight
+			}
+			if weight == highest && weight > 0 && !slices.Contains(best, l) {
+				best = append(best, l)
+			}
+		}
+		return best, len(best) == 0 || slices.Contains(best, label[vertex])
+	}
+	for range labelPropagationSweeps {
+		for _, vertex := range rng.Perm(n) {
+			if best, ok := bestLabels(vertex); !ok {
+				label[vertex] = best[rng.IntN(len(best))]
+			}
+		}
+		settled := true
+		for vertex := range n {
+			if _, ok := bestLabels(vertex); !ok {
+				settled = false
+				break
+			}
+		}
+		if settled {
+			break
+		}
+	}
+	return view.partition(label), nil
+}
+
+// Louvain splits the graph into communities by Louvain modularity optimisation, with edges treated as undirected.
+// Vertices are moved, in random order, to the neighbouring community that most increases modularity
+// until no move helps, then each community is merged into a single vertex and the process repeats
+// on the merged graph until nothing moves. The same seed always gives the same communities.
+// Edge weights must be non-negative.
+func Louvain(graph Graph, seed uint64) (*Partition, error) {
+	g := graph.directed()
+	view, err := newCommunityView(g)
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewPCG(seed, 0))
+	n := len(view.vertices)
+	// Community of each original vertex, and the level graph of merged communities.
+	community := make([]int, n)
+	for i := range n {
+		community[i] = i
+	}
+	level := view
+	for {
+		moved, next := level.moveVertices(rng)
+		if !moved {
+			break
+		}
+		for i := range community {
+			community[i] = next[community[i]]
+		}
+		level = level.merge(next)
+	}
+	return view.partition(community), nil
+}
+
+// Moves vertices between communities while modularity increases.
+// Returns whether any vertex moved and the communities numbered from 0.
+func (view *communityView) moveVertices(rng *rand.Rand) (bool, []int) {
+	n := len(view.vertices)
+	community := make([]int, n)
+	// Total degree of each community.
+	total := make([]float64, n)
+	for i := range n {
+		community[i] = i
+		total[i] = view.degree[i]
+	}
+	moved := false
+	order := rng.Perm(n)
+	for improved := true; improved && view.total > 0; {
+		improved = false
+		for _, vertex := range order {
+			current := community[vertex]
+			total[current] -= view.degree[vertex]
+			// Weight from the vertex to each neighbouring community, in neighbour order.
+			links := make(map[int]float64)
+			candidates := []int{current}
+			for _, neighbour := range view.neighbours[vertex] {
+				c := community[neighbour]
+				if _, ok := links[c]; !ok && c != current {
+					candidates = append(candidates, c)
+				}
+				links[c] += view.weights[vertex][neighbour]
+			}
+			// Gain in modularity from joining a community, up to a constant factor.
+			gain := func(c int) float64 {
+				return links[c] - total[c]*view.degree[vertex]/view.total
+			}
+			best := current
+			for _, c := range candidates[1:] {
+				if gain(c) > gain(best) {
+					best = c
+				}
+			}
+			community[vertex] = best
+			total[best] += view.degree[vertex]
+			if best != current {
+				moved, improved = true, true
+			}
+		}
+	}
+	renumbered := make(map[int]int)
+	for i, c := range community {
+		if _, ok := renumbered[c]; !ok {
+			renumbered[c] = len(renumbered)
+		}
+		community[i] = renumbered[c]
+	}
+	return moved, community
+}
+
+// Merges each community into a single vertex, with the weights inside it as a loop.
+func (view *communityView) merge(community []int) *communityView {
+	size := 0
+	for _, c := range community {
+		size = max(size, c+1)
+	}
+	merged := &communityView{
+		vertices:   make([]string, size),
+		neighbours: make([][]int, size),
+		weights:    make([]map[int]float64, size),
+		loops:      make([]float64, size),
+		degree:     make([]float64, size),
+		total:      view.total,
+	}
+	for c := range size {
+		merged.weights[c] = make(map[int]float64)
+	}
+	for vertex := range view.vertices {
+		c := community[vertex]
+		merged.loops[c] += view.loops[vertex]
+		merged.degree[c] += view.degree[vertex]
+		for _, neighbour := range view.neighbours[vertex] {
+			d, weight := community[neighbour], view.weights[vertex][neighbour]
+			if c == d {
+				merged.loops[c] += weight
+				continue
+			}
+			if _, ok := merged.weights[c][d]; !ok {
+				merged.neighbours[c] = append(merged.neighbours[c], d)
+			}
+			merged.weights[c][d] += weight
+		}
+	}
+	return merged
+}
+
+// KernighanLin splits the graph into two halves, sizes differing by at most one, with little weight between them
+// by Kernighan-Lin bisection from a random split, with edges treated as undirected. Each pass swaps pairs of vertices,
+// most reducing the weight between the halves first, then keeps the best prefix of the swaps. Passes repeat
+// while they reduce the weight between the halves. The same seed always gives the same halves.
+// Edge weights must be non-negative. Time: O(V^2) per pass.
+func KernighanLin(graph Graph, seed uint64) (*Partition, error) {
+	g := graph.directed()
+	view, err := newCommunityView(g)
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewPCG(seed, 0))
+	n := len(view.vertices)
+	side := make([]int, n)
+	for i, vertex := range rng.Perm(n) {
+		if i >= n/2 {
+			side[vertex] = 1
+		}
+	}
+	// Weight to the other half less the weight to the vertex's own half.
+	difference := make([]float64, n)
+	locked := make([]bool, n)
+	for range kernighanLinPasses {
+		for vertex := range n {
+			difference[vertex] = 0
+			locked[vertex] = false
+			for neighbour, weight := range view.weights[vertex] {
+				if side[neighbour] == side[vertex] {
+					difference[vertex] -= weight
+				} else {
+					difference[vertex] += weight
+				}
+			}
+		}
+		swaps := make([][2]int, 0, n/2)
+		gains := make([]float64, 0, n/2)
+		for range n / 2 {
+			a := -1
+			for vertex := range n {
+				if !locked[vertex] && side[vertex] == 0 && (a == -1 || difference[vertex] > difference[a]) {
+					a = vertex
+				}
+			}
+			b, gain := -1, 0.0
+			for vertex := range n {
+				if locked[vertex] || side[vertex] == 0 {
+					continue
+				}
+				if swapGain := difference[a] + difference[vertex] - 2*view.weights[a][vertex]; b == -1 || swapGain > gain {
+					b, gain = vertex, swapGain
+				}
+			}
+			locked[a], locked[b] = true, true
+			swaps = append(swaps, [2]int{a, b})
+			gains = append(gains, gain)
+			// Moving a and b changes the differences of their neighbours as if both were already swapped.
+			for neighbour, weight := range view.weights[a] {
+				if side[neighbour] == 0 {
+					difference[neighbour] += 2 * weight
+				} else {
+					difference[neighbour] -= 2 * weight
+				}
+			}
+			for neighbour, weight := range view.weights[b] {
+				if side[neighbour] == 1 {
+					difference[neighbour] += 2 * weight
+				} else {
+					difference[neighbour] -= 2 * weight
+				}
+			}
+		}
+		best, bestGain, sum := 0, 0.0, 0.0
+		for i, gain := range gains {
+			sum += gain
+			if sum > bestGain {
+				best, bestGain = i+1, sum
+			}
+		}
+		if best == 0 {
+			break
+		}
+		for _, swap := range swaps[:best] {
+			side[swap[0]], side[swap[1]] = 1, 0
+		}
+	}
+	return view.partition(side), nil
+}
@@ -0,0 +1,97 @@
+package structures
+
+import (
+	"maps"
+)
+
+// Generic copy of every vertex and edge of g into the empty graph h, in the same order and with the same IDs and attributes.
+// Parallel edges must be allowed in h. Returns the largest edge ID.
+func copyGraphHelper(g DirectedWeightedGraph, h DirectedWeightedGraph) int {
+	for vertex := range g.Vertices() {
+		h.AddVertex(vertex)
+		h.getVertex(vertex).attributes = maps.Clone(g.getVertex(vertex).attributes)
+	}
+	lastID := 0
+	for edge := range g.Edges() {
+		h.AddEdge(edge.From(), edge.To(), edge.weight)
+		between := h.EdgesBetween(edge.From(), edge.To())
+		copyEdgeFields(between[len(between)-1], edge)
+		lastID = max(lastID, edge.id)
+	}
+	return lastID
+}
+
+// Copies the ID, float weight and attributes of an edge.
+func copyEdgeFields(copied *Edge, edge *Edge) {
+	copied.id = edge.id
+	if edge.floatWeight != nil {
+		weight := *edge.floatWeight
+		copied.floatWeight = &weight
+	}
+	copied.attributes = maps.Clone(edge.attributes)
+}
+
+// Generic conversion to an adjacency list.
+func toListHelper(g DirectedWeightedGraph) *AdjacencyList {
+	list := &AdjacencyList{}
+	list.Clear()
+	list.lastID = copyGraphHelper(g, list)
+	list.policy = g.getParallelEdgePolicy()
+	return list
+}
+
+// Generic conversion to an adjacency matrix.
+func toMatrixHelper(g DirectedWeightedGraph) *AdjacencyMatrix {
+	matrix := &AdjacencyMatrix{}
+	matrix.Clear()
+	matrix.lastID = copyGraphHelper(g, matrix)
+	matrix.policy = g.getParallelEdgePolicy()
+	return matrix
+}
+
+// Generic conversion to a compressed sparse row graph.
+// The outgoing edges of each vertex keep the order they have in g, so algorithms give the same results on both.
+func toCSRHelper(g DirectedWeightedGraph) *CSRGraph {
+	n := g.NumberOfVertices()
+	c := &CSRGraph{
+		names:    make([]string, 0, n),
+		ids:      make(map[string]int, n),
+		vertices: make([]Vertex, 0, n),
+		offsets:  make([]int, 1, n+1),
+		policy:   g.getParallelEdgePolicy(),
+	}
+	m := 0
+	for vertex := range g.Vertices() {
+		c.ids[vertex] = len(c.names)
+		c.names = append(c.names, vertex)
+		c.vertices = append(c.vertices, Vertex{value: vertex, attributes: maps.Clone(g.getVertex(vertex).attributes)})
+		m += len(g.getOutgoingEdges(g.getVertex(vertex)))
+	}
+
+	// Edges are stored together, and must not move once pointers to them are taken.
+	storage := make([]Edge, m)
+	c.outgoing = make([]*Edge, m)
+	c.targets = make([]int, m)
+	c.weights = make([]int, m)
+	copies := make(map[*Edge]*Edge, m)
+	i := 0
+	for _, name := range c.names {
+		for _, edge := range g.getOutgoingEdges(g.getVertex(name)) {
+			to := c.ids[edge.To()]
+			// Edges share the interned names of their ends.
+			storage[i] = Edge{vertices: [2]string{name, c.names[to]}, weight: edge.weight}
+			copyEdgeFields(&storage[i], edge)
+			copies[edge] = &storage[i]
+			c.outgoing[i] = &storage[i]
+			c.targets[i] = to
+			c.weights[i] = edge.weight
+			i++
+		}
+		c.offsets = append(c.offsets, i)
+	}
+	c.edges = make([]*Edge, 0, m)
+	for edge := range g.Edges() {
+		c.edges = append(c.edges, copies[edge])
+	}
+	return c
+}
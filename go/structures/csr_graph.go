@@ -0,0 +1,302 @@
+package structures
+
+import (
+	"errors"
+	"iter"
+)
+
+// ErrReadOnly is returned when trying to modify a read-only graph.
+var ErrReadOnly = errors.New("Graph is read-only")
+
+// CSRGraph represents a read-only directed weighted graph in compressed sparse row form, created by ToCSR.
+// Vertices have integer IDs in insertion order, and the targets and weights of the edges leaving each vertex
+// are stored next to each other in flat arrays, which the traversals and Dijkstra's algorithm read directly.
+// The other algorithms take it like any other graph.
+type CSRGraph struct {
+	// Interned vertex names by ID, and the ID of each name.
+	names    []string
+	ids      map[string]int
+	vertices []Vertex
+	// The edges leaving vertex v are outgoing[offsets[v]:offsets[v+1]], and the IDs of the vertices they lead to
+	// and their weights are targets[offsets[v]:offsets[v+1]] and weights[offsets[v]:offsets[v+1]].
+	offsets  []int
+	outgoing []*Edge
+	targets  []int
+	weights  []int
+	// Edges in insertion order.
+	edges []*Edge
+	// Paths found by the last call to Dijkstra.
+	paths *PathTree
+	// Parallel edge policy of the graph it was created from, or set since, kept for conversions.
+	policy ParallelEdgePolicy
+}
+
+// AddVertex returns ErrReadOnly.
+func (g *CSRGraph) AddVertex(value string) error {
+	return ErrReadOnly
+}
+
+// AddAllVertices returns ErrReadOnly.
+func (g *CSRGraph) AddAllVertices(values []string) error {
+	return ErrReadOnly
+}
+
+// RemoveVertex returns ErrReadOnly.
+func (g *CSRGraph) RemoveVertex(value string) error {
+	return ErrReadOnly
+}
+
+// AddEdge returns ErrReadOnly.
+func (g *CSRGraph) AddEdge(from string, to string, weight int) (int, error) {
+	return -1, ErrReadOnly
+}
+
+// RemoveEdge returns ErrReadOnly.
+func (g *CSRGraph) RemoveEdge(from string, to string) error {
+	return ErrReadOnly
+}
+
+// RemoveEdgeByID returns ErrReadOnly.
+func (g *CSRGraph) RemoveEdgeByID(id int) error {
+	return ErrReadOnly
+}
+
+// EdgesBetween returns the edges from one vertex to another in insertion order.
+func (g *CSRGraph) EdgesBetween(from string, to string) []*Edge {
+	result := make([]*Edge, 0)
+	if vertex := g.getVertex(from); vertex != nil {
+		for _, edge := range g.getOutgoingEdges(vertex) {
+			if edge.vertices[1] == to {
+				result = append(result, edge)
+			}
+		}
+	}
+	return result
+}
+
+// SetParallelEdgePolicy sets the policy given to the graphs converted from this one, no edges can be added to it.
+func (g *CSRGraph) SetParallelEdgePolicy(policy ParallelEdgePolicy) {
+	g.policy = policy
+}
+
+// Clear removes all vertices and edges, leaving an empty graph that is still read-only.
+func (g *CSRGraph) Clear() {
+	*g = CSRGraph{}
+}
+
+// IsEmpty returns true if the graph is empty.
+func (g *CSRGraph) IsEmpty() bool {
+	return len(g.names) == 0
+}
+
+// DFS performs a depth first traversal from the source vertex.
+// Returns an error if the source does not exist.
+func (g *CSRGraph) DFS(source string) ([]string, error) {
+	start, ok := g.ids[source]
+	if !ok {
+		return nil, errors.New("Vertex does not exist: " + source)
+	}
+	visited := make([]bool, len(g.names))
+	visited[start] = true
+	result := []string{source}
+	// Each entry holds a vertex and the position of the next edge to follow from it.
+	stack := [][2]int{{start, g.offsets[start]}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if top[1] == g.offsets[top[0]+1] {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		next := g.targets[top[1]]
+		top[1]++
+		if !visited[next] {
+			visited[next] = true
+			result = append(result, g.names[next])
+			stack = append(stack, [2]int{next, g.offsets[next]})
+		}
+	}
+	return result, nil
+}
+
+// BFS performs a breadth first traversal from the source vertex.
+// Returns an error if the source does not exist.
+func (g *CSRGraph) BFS(source string) ([]string, error) {
+	start, ok := g.ids[source]
+	if !ok {
+		return nil, errors.New("Vertex does not exist: " + source)
+	}
+	visited := make([]bool, len(g.names))
+	visited[start] = true
+	result := []string{source}
+	// The front of the slice represents the front of the queue.
+	queue := []int{start}
+	for len(queue) > 0 {
+		front := queue[0]
+		queue = queue[1:]
+		for _, next := range g.targets[g.offsets[front]:g.offsets[front+1]] {
+			if !visited[next] {
+				visited[next] = true
+				result = append(result, g.names[next])
+				queue = append(queue, next)
+			}
+		}
+	}
+	return result, nil
+}
+
+// Dijkstra finds the shortest path to all vertices in the graph from the source vertex.
+// The paths are kept for GetShortestPath until the next call, prefer ShortestPaths.
+// Returns an error if the source does not exist or an edge has a negative weight.
+func (g *CSRGraph) Dijkstra(source string) error {
+	var err error
+	g.paths, err = ShortestPaths(g, source)
+	return err
+}
+
+// GetShortestPath returns the shortest path between the target and Dijkstra's source.
+func (g *CSRGraph) GetShortestPath(target string) ([]*DijkstraResult, error) {
+	return getShortestPathHelper(g, g.paths, target)
+}
+
+// ToList returns a copy of the graph as an adjacency list, with the same edge IDs and attributes.
+func (g *CSRGraph) ToList() *AdjacencyList {
+	return toListHelper(g)
+}
+
+// ToMatrix returns a copy of the graph as an adjacency matrix, with the same edge IDs and attributes.
+func (g *CSRGraph) ToMatrix() *AdjacencyMatrix {
+	return toMatrixHelper(g)
+}
+
+// ToCSR returns the graph itself, since it cannot change.
+func (g *CSRGraph) ToCSR() *CSRGraph {
+	return g
+}
+
+// Vertices returns an iterator over the vertices of the graph in insertion order.
+func (g *CSRGraph) Vertices() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, name := range g.names {
+			if !yield(name) {
+				return
+			}
+		}
+	}
+}
+
+// Edges returns an iterator over the edges of the graph in insertion order.
+func (g *CSRGraph) Edges() iter.Seq[*Edge] {
+	return edgesSeq(g, g.edges)
+}
+
+// Neighbours returns an iterator over the neighbours of a vertex and the weights of the edges leading to them.
+func (g *CSRGraph) Neighbours(value string) iter.Seq2[string, int] {
+	return neighboursSeq(g, value)
+}
+
+// OutgoingEdges returns an iterator over the edges leaving a vertex, to read their attributes.
+func (g *CSRGraph) OutgoingEdges(value string) iter.Seq[*Edge] {
+	return outgoingEdgesSeq(g, value)
+}
+
+// VertexAttribute returns the value of a named attribute of a vertex, false if it has not been set.
+func (g *CSRGraph) VertexAttribute(value string, name string) (any, bool) {
+	return vertexAttributeHelper(g, value, name)
+}
+
+// SetVertexAttribute returns ErrReadOnly.
+func (g *CSRGraph) SetVertexAttribute(value string, name string, attribute any) error {
+	return ErrReadOnly
+}
+
+// EdgeAttribute returns the value of a named attribute of an edge, false if it has not been set.
+func (g *CSRGraph) EdgeAttribute(from string, to string, name string) (any, bool) {
+	return edgeAttributeHelper(g, from, to, name)
+}
+
+// SetEdgeAttribute returns ErrReadOnly.
+func (g *CSRGraph) SetEdgeAttribute(from string, to string, name string, attribute any) error {
+	return ErrReadOnly
+}
+
+// SetEdgeFloatWeight returns ErrReadOnly.
+func (g *CSRGraph) SetEdgeFloatWeight(from string, to string, weight float64) error {
+	return ErrReadOnly
+}
+
+// EdgeAttributeByID returns the value of a named attribute of the edge with the given ID, false if it has not been set.
+func (g *CSRGraph) EdgeAttributeByID(id int, name string) (any, bool) {
+	return edgeAttributeByIDHelper(g, id, name)
+}
+
+// SetEdgeAttributeByID returns ErrReadOnly.
+func (g *CSRGraph) SetEdgeAttributeByID(id int, name string, attribute any) error {
+	return ErrReadOnly
+}
+
+// SetEdgeFloatWeightByID returns ErrReadOnly.
+func (g *CSRGraph) SetEdgeFloatWeightByID(id int, weight float64) error {
+	return ErrReadOnly
+}
+
+// NumberOfVertices returns the number of vertices in the graph.
+func (g *CSRGraph) NumberOfVertices() int {
+	return len(g.names)
+}
+
+// NumberOfEdges returns the number of edges in the graph.
+func (g *CSRGraph) NumberOfEdges() int {
+	return len(g.edges)
+}
+
+// Dijkstra's algorithm from a vertex ID with the integer edge weights, which must be non-negative.
+func (g *CSRGraph) dijkstra(source int) ([]int, []int) {
+	return dijkstraCore(g, source, func(i int) int { return g.weights[i] })
+}
+
+func (g *CSRGraph) getVertex(value string) *Vertex {
+	id, ok := g.ids[value]
+	if !ok {
+		return nil
+	}
+	return &g.vertices[id]
+}
+
+func (g *CSRGraph) getEdge(from string, to string) *Edge {
+	if vertex := g.getVertex(from); vertex != nil {
+		for _, edge := range g.getOutgoingEdges(vertex) {
+			if edge.vertices[1] == to {
+				return edge
+			}
+		}
+	}
+	return nil
+}
+
+func (g *CSRGraph) getOutgoingEdges(vertex *Vertex) []*Edge {
+	id := g.ids[vertex.value]
+	// Limit the capacity so that appending cannot overwrite the edges of the next vertex.
+	return g.outgoing[g.offsets[id]:g.offsets[id+1]:g.offsets[id+1]]
+}
+
+func (g *CSRGraph) getNeighbours(vertex *Vertex) []*Vertex {
+	id := g.ids[vertex.value]
+	result := make([]*Vertex, 0, g.offsets[id+1]-g.offsets[id])
+	for _, next := range g.targets[g.offsets[id]:g.offsets[id+1]] {
+		result = append(result, &g.vertices[next])
+	}
+	return result
+}
+
+func (g *CSRGraph) getParallelEdgePolicy() ParallelEdgePolicy {
+	return g.policy
+}
+
+// The graph never changes.
+func (g *CSRGraph) getVersion() int {
+	return 0
+}
+
+func (g *CSRGraph) directed() DirectedWeightedGraph {
+	return g
+}
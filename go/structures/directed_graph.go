@@ -0,0 +1,190 @@
+package structures
+
+import "iter"
+
+// DirectedAdjacencyList represents a directed unweighted graph implemented using an adjacency list.
+// Every edge has weight 1, so weighted algorithms count edges.
+type DirectedAdjacencyList struct {
+	graphWrapper[AdjacencyList, *AdjacencyList]
+}
+
+// DirectedAdjacencyMatrix represents a directed unweighted graph implemented using an adjacency matrix.
+// Every edge has weight 1, so weighted algorithms count edges.
+type DirectedAdjacencyMatrix struct {
+	graphWrapper[AdjacencyMatrix, *AdjacencyMatrix]
+}
+
+// AddEdge adds a new edge to the graph and returns its ID.
+func (g *DirectedAdjacencyList) AddEdge(from string, to string) (int, error) {
+	return g.inner().AddEdge(from, to, 1)
+}
+
+// AddEdge adds a new edge to the graph and returns its ID.
+func (g *DirectedAdjacencyMatrix) AddEdge(from string, to string) (int, error) {
+	return g.inner().AddEdge(from, to, 1)
+}
+
+// Generic wrapper around a directed weighted graph of type G, forwarding every operation but AddEdge to it.
+// Graphs that add their own kind of edge embed it, so that the edges of the wrapped graph can only be added their way.
+type graphWrapper[G any, P interface {
+	*G
+	DirectedWeightedGraph
+}] struct {
+	graph G
+}
+
+// The wrapped graph.
+func (g *graphWrapper[G, P]) inner() P {
+	return P(&g.graph)
+}
+
+// AddVertex adds a new vertex to the graph.
+func (g *graphWrapper[G, P]) AddVertex(value string) error {
+	return g.inner().AddVertex(value)
+}
+
+// AddAllVertices adds a list of vertices to the graph.
+func (g *graphWrapper[G, P]) AddAllVertices(values []string) error {
+	return g.inner().AddAllVertices(values)
+}
+
+// RemoveVertex removes a vertex and the edges touching it from the graph.
+func (g *graphWrapper[G, P]) RemoveVertex(value string) error {
+	return g.inner().RemoveVertex(value)
+}
+
+// RemoveEdge removes an edge from the graph, the first one added if there are parallel edges.
+func (g *graphWrapper[G, P]) RemoveEdge(from string, to string) error {
+	return g.inner().RemoveEdge(from, to)
+}
+
+// RemoveEdgeByID removes the edge with the given ID from the graph.
+func (g *graphWrapper[G, P]) RemoveEdgeByID(id int) error {
+	return g.inner().RemoveEdgeByID(id)
+}
+
+// EdgesBetween returns the edges from one vertex to another in insertion order.
+func (g *graphWrapper[G, P]) EdgesBetween(from string, to string) []*Edge {
+	return g.inner().EdgesBetween(from, to)
+}
+
+// SetParallelEdgePolicy sets what AddEdge does when the edge already exists, parallel edges are allowed by default.
+// Edges already in the graph are kept.
+func (g *graphWrapper[G, P]) SetParallelEdgePolicy(policy ParallelEdgePolicy) {
+	g.inner().SetParallelEdgePolicy(policy)
+}
+
+// Clear removes all nodes from the graph.
+func (g *graphWrapper[G, P]) Clear() {
+	g.inner().Clear()
+}
+
+// IsEmpty returns true if the graph is empty.
+func (g *graphWrapper[G, P]) IsEmpty() bool {
+	return g.inner().IsEmpty()
+}
+
+// DFS performs a depth first traversal from the source vertex.
+// Returns an error if the source does not exist.
+func (g *graphWrapper[G, P]) DFS(source string) ([]string, error) {
+	return g.inner().DFS(source)
+}
+
+// BFS performs a breadth first traversal from the source vertex.
+// Returns an error if the source does not exist.
+func (g *graphWrapper[G, P]) BFS(source string) ([]string, error) {
+	return g.inner().BFS(source)
+}
+
+// NumberOfVertices returns the number of vertices in the graph.
+func (g *graphWrapper[G, P]) NumberOfVertices() int {
+	return g.inner().NumberOfVertices()
+}
+
+// NumberOfEdges returns the number of edges in the graph.
+func (g *graphWrapper[G, P]) NumberOfEdges() int {
+	return g.inner().NumberOfEdges()
+}
+
+// Vertices returns an iterator over the vertices of the graph in insertion order.
+func (g *graphWrapper[G, P]) Vertices() iter.Seq[string] {
+	return g.inner().Vertices()
+}
+
+// Edges returns an iterator over the edges of the graph in insertion order.
+func (g *graphWrapper[G, P]) Edges() iter.Seq[*Edge] {
+	return g.inner().Edges()
+}
+
+// Neighbours returns an iterator over the neighbours of a vertex and the weights of the edges leading to them.
+func (g *graphWrapper[G, P]) Neighbours(value string) iter.Seq2[string, int] {
+	return g.inner().Neighbours(value)
+}
+
+// OutgoingEdges returns an iterator over the edges leaving a vertex, to read their attributes.
+func (g *graphWrapper[G, P]) OutgoingEdges(value string) iter.Seq[*Edge] {
+	return g.inner().OutgoingEdges(value)
+}
+
+// VertexAttribute returns the value of a named attribute of a vertex, false if it has not been set.
+func (g *graphWrapper[G, P]) VertexAttribute(value string, name string) (any, bool) {
+	return g.inner().VertexAttribute(value, name)
+}
+
+// SetVertexAttribute sets the value of a named attribute of a vertex.
+func (g *graphWrapper[G, P]) SetVertexAttribute(value string, name string, attribute any) error {
+	return g.inner().SetVertexAttribute(value, name, attribute)
+}
+
+// EdgeAttribute returns the value of a named attribute of an edge, false if it has not been set.
+func (g *graphWrapper[G, P]) EdgeAttribute(from string, to string, name string) (any, bool) {
+	return g.inner().EdgeAttribute(from, to, name)
+}
+
+// SetEdgeAttribute sets the value of a named attribute of an edge.
+func (g *graphWrapper[G, P]) SetEdgeAttribute(from string, to string, name string, attribute any) error {
+	return g.inner().SetEdgeAttribute(from, to, name, attribute)
+}
+
+// SetEdgeFloatWeight sets a float64 weight on an edge, the integer weight is unchanged.
+func (g *graphWrapper[G, P]) SetEdgeFloatWeight(from string, to string, weight float64) error {
+	return g.inner().SetEdgeFloatWeight(from, to, weight)
+}
+
+// EdgeAttributeByID returns the value of a named attribute of the edge with the given ID, false if it has not been set.
+func (g *graphWrapper[G, P]) EdgeAttributeByID(id int, name string) (any, bool) {
+	return g.inner().EdgeAttributeByID(id, name)
+}
+
+// SetEdgeAttributeByID sets the value of a named attribute of the edge with the given ID.
+func (g *graphWrapper[G, P]) SetEdgeAttributeByID(id int, name string, attribute any) error {
+	return g.inner().SetEdgeAttributeByID(id, name, attribute)
+}
+
+// SetEdgeFloatWeightByID sets a float64 weight on the edge with the given ID, the integer weight is unchanged.
+func (g *graphWrapper[G, P]) SetEdgeFloatWeightByID(id int, weight float64) error {
+	return g.inner().SetEdgeFloatWeightByID(id, weight)
+}
+
+// ToList returns a weighted copy of the graph as an adjacency list, with the same edge IDs and attributes.
+func (g *graphWrapper[G, P]) ToList() *AdjacencyList {
+	return g.inner().ToList()
+}
+
+// ToMatrix returns a weighted copy of the graph as an adjacency matrix, with the same edge IDs and attributes.
+func (g *graphWrapper[G, P]) ToMatrix() *AdjacencyMatrix {
+	return g.inner().ToMatrix()
+}
+
+// ToCSR returns a read-only weighted copy of the graph in compressed sparse row form, with the same edge IDs and attributes.
+func (g *graphWrapper[G, P]) ToCSR() *CSRGraph {
+	return g.inner().ToCSR()
+}
+
+func (g *graphWrapper[G, P]) getVertex(value string) *Vertex {
+	return g.inner().getVertex(value)
+}
+
+func (g *graphWrapper[G, P]) directed() DirectedWeightedGraph {
+	return g.inner()
+}
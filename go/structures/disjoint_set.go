@@ -0,0 +1,265 @@
+package structures
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// DisjointSet partitions elements into sets that can be merged, also known as union-find.
+// Uses path compression and union by rank, so operations take nearly constant amortized time.
+type DisjointSet[T comparable] struct {
+	elements []T
+	index    map[T]int
+	sets     unionFind
+}
+
+// RollbackDisjointSet is a disjoint set whose unions can be undone in reverse order, used for offline dynamic connectivity.
+// Paths are not compressed so that unions can be undone, operations take O(log n) time.
+type RollbackDisjointSet[T comparable] struct {
+	DisjointSet[T]
+}
+
+// Add adds a new element in a set of its own.
+func (d *DisjointSet[T]) Add(elem T) error {
+	if d.index == nil {
+		d.index = make(map[T]int)
+	}
+	if _, ok := d.index[elem]; ok {
+		return errors.New("Element already exists: " + fmt.Sprint(elem))
+	}
+	d.index[elem] = d.sets.add()
+	d.elements = append(d.elements, elem)
+	return nil
+}
+
+// AddAll adds all elements in a slice, each in a set of its own.
+func (d *DisjointSet[T]) AddAll(elems []T) error {
+	var err error
+	for _, elem := range elems {
+		temp := d.Add(elem)
+		if temp != nil {
+			err = temp
+		}
+	}
+	return err
+}
+
+// Find returns the representative element of the set containing an element.
+// Two elements are in the same set if and only if they have the same representative.
+func (d *DisjointSet[T]) Find(elem T) (T, error) {
+	x, err := d.getIndex(elem)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return d.elements[d.sets.find(x)], nil
+}
+
+// Union merges the sets containing two elements, returns true if they were in different sets.
+func (d *DisjointSet[T]) Union(a T, b T) (bool, error) {
+	x, err := d.getIndex(a)
+	if err != nil {
+		return false, err
+	}
+	y, err := d.getIndex(b)
+	if err != nil {
+		return false, err
+	}
+	return d.sets.union(x, y), nil
+}
+
+// Connected returns true if two elements are in the same set, false if either does not exist.
+func (d *DisjointSet[T]) Connected(a T, b T) bool {
+	x, err := d.getIndex(a)
+	if err != nil {
+		return false
+	}
+	y, err := d.getIndex(b)
+	return err == nil && d.sets.find(x) == d.sets.find(y)
+}
+
+// Contains returns true if the element has been added.
+func (d *DisjointSet[T]) Contains(elem T) bool {
+	_, ok := d.index[elem]
+	return ok
+}
+
+// SetSize returns the number of elements in the set containing an element.
+func (d *DisjointSet[T]) SetSize(elem T) (int, error) {
+	x, err := d.getIndex(elem)
+	if err != nil {
+		return 0, err
+	}
+	return d.sets.size[d.sets.find(x)], nil
+}
+
+// Members returns the elements in the set containing an element, in insertion order.
+// Time: O(k log k) where k is the size of the set.
+func (d *DisjointSet[T]) Members(elem T) ([]T, error) {
+	x, err := d.getIndex(elem)
+	if err != nil {
+		return nil, err
+	}
+	indices := d.sets.members(x)
+	slices.Sort(indices)
+	result := make([]T, len(indices))
+	for i, index := range indices {
+		result[i] = d.elements[index]
+	}
+	return result, nil
+}
+
+// SetCount returns the number of sets.
+func (d *DisjointSet[T]) SetCount() int {
+	return d.sets.count
+}
+
+// Size returns the number of elements.
+func (d *DisjointSet[T]) Size() int {
+	return len(d.elements)
+}
+
+// Clear removes all elements.
+func (d *DisjointSet[T]) Clear() {
+	d.elements = nil
+	d.index = nil
+	d.sets = unionFind{rollback: d.sets.rollback}
+}
+
+func (d *DisjointSet[T]) getIndex(elem T) (int, error) {
+	x, ok := d.index[elem]
+	if !ok {
+		return 0, errors.New("Element does not exist: " + fmt.Sprint(elem))
+	}
+	return x, nil
+}
+
+// Add adds a new element in a set of its own. Additions are not undone by Rollback.
+func (d *RollbackDisjointSet[T]) Add(elem T) error {
+	d.sets.rollback = true
+	return d.DisjointSet.Add(elem)
+}
+
+// AddAll adds all elements in a slice, each in a set of its own. Additions are not undone by Rollback.
+func (d *RollbackDisjointSet[T]) AddAll(elems []T) error {
+	d.sets.rollback = true
+	return d.DisjointSet.AddAll(elems)
+}
+
+// Checkpoint returns the number of successful unions so far, to be passed to Rollback.
+func (d *RollbackDisjointSet[T]) Checkpoint() int {
+	return len(d.sets.history)
+}
+
+// Rollback undoes every successful union since a checkpoint, most recent first.
+// Time: O(k) where k is the number of unions undone.
+func (d *RollbackDisjointSet[T]) Rollback(checkpoint int) error {
+	if checkpoint < 0 || checkpoint > len(d.sets.history) {
+		return errors.New("Invalid checkpoint: " + fmt.Sprint(checkpoint))
+	}
+	for len(d.sets.history) > checkpoint {
+		d.sets.undo()
+	}
+	return nil
+}
+
+// Disjoint sets of the integers 0 to n - 1.
+type unionFind struct {
+	parent []int
+	rank   []int
+	// Size of the set, only kept up to date for roots.
+	size []int
+	// Each set is a circular linked list through next, so its members can be listed.
+	next  []int
+	count int
+	// When rollback is set, paths are not compressed and every successful union is recorded in history.
+	rollback bool
+	history  []unionRecord
+}
+
+// The root that was attached below another root by a union.
+type unionRecord struct {
+	child         int
+	root          int
+	rankIncreased bool
+}
+
+func newUnionFind(n int) *unionFind {
+	uf := &unionFind{}
+	for range n {
+		uf.add()
+	}
+	return uf
+}
+
+// Adds a new element in a set of its own and returns it.
+func (uf *unionFind) add() int {
+	x := len(uf.parent)
+	uf.parent = append(uf.parent, x)
+	uf.rank = append(uf.rank, 0)
+	uf.size = append(uf.size, 1)
+	uf.next = append(uf.next, x)
+	uf.count++
+	return x
+}
+
+// Returns the root of the set containing x, compressing the path to it unless unions can be rolled back.
+func (uf *unionFind) find(x int) int {
+	for uf.parent[x] != x {
+		if !uf.rollback {
+			uf.parent[x] = uf.parent[uf.parent[x]]
+		}
+		x = uf.parent[x]
+	}
+	return x
+}
+
+// Merges the sets containing x and y, returns false if they were already the same set.
+func (uf *unionFind) union(x int, y int) bool {
+	x, y = uf.find(x), uf.find(y)
+	if x == y {
+		return false
+	}
+	// Attach the shallower tree below the deeper one.
+	if uf.rank[x] < uf.rank[y] {
+		x, y = y, x
+	}
+	uf.parent[y] = x
+	uf.size[x] += uf.size[y]
+	// Swapping the successors of two nodes in different circular lists joins the lists.
+	uf.next[x], uf.next[y] = uf.next[y], uf.next[x]
+	rankIncreased := uf.rank[x] == uf.rank[y]
+	if rankIncreased {
+		uf.rank[x]++
+	}
+	uf.count--
+	if uf.rollback {
+		uf.history = append(uf.history, unionRecord{child: y, root: x, rankIncreased: rankIncreased})
+	}
+	return true
+}
+
+// Undoes the most recent recorded union.
+func (uf *unionFind) undo() {
+	record := uf.history[len(uf.history)-1]
+	uf.history = uf.history[:len(uf.history)-1]
+	x, y := record.root, record.child
+	uf.parent[y] = y
+	uf.size[x] -= uf.size[y]
+	// Swapping the same successors again splits the lists.
+	uf.next[x], uf.next[y] = uf.next[y], uf.next[x]
+	if record.rankIncreased {
+		uf.rank[x]--
+	}
+	uf.count++
+}
+
+// Returns the elements in the set containing x.
+func (uf *unionFind) members(x int) []int {
+	result := []int{x}
+	for current := uf.next[x]; current != x; current = uf.next[current] {
+		result = append(result, current)
+	}
+	return result
+}
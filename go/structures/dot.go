@@ -0,0 +1,321 @@
+package structures
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Kinds of DOT tokens.
+const (
+	dotIdentifier = iota
+	dotString
+	dotArrow
+	dotPunctuation
+	dotEnd
+)
+
+type dotToken struct {
+	kind int
+	text string
+	// Position of the first character of the token.
+	line   int
+	column int
+}
+
+// Parser for the subset of DOT written by encodeDOTHelper: a digraph of vertex and edge statements.
+// Default attribute statements and graph attributes are skipped, subgraphs and ports are not supported.
+type dotParser struct {
+	tokens []dotToken
+	next   int
+}
+
+// EncodeDOT writes the graph in Graphviz DOT format, with edge weights as weight attributes.
+// The vertices and edges of a path from GetShortestPath are drawn in red, pass nil to highlight nothing.
+func EncodeDOT(g Graph, w io.Writer, path []*DijkstraResult) error {
+	onPath := make(map[string]bool)
+	for _, step := range path {
+		onPath[step.Value] = true
+	}
+	highlighted := pathEdges(g, path)
+	var b strings.Builder
+	b.WriteString("digraph {\n")
+	for vertex := range g.Vertices() {
+		b.WriteString("\t" + quoteDOT(vertex))
+		if onPath[vertex] {
+			b.WriteString(" [color=red]")
+		}
+		b.WriteString(";\n")
+	}
+	for edge := range g.Edges() {
+		weight := strconv.Itoa(edge.Weight())
+		b.WriteString("\t" + quoteDOT(edge.From()) + " -> " + quoteDOT(edge.To()) + " [weight=" + weight + ", label=" + weight)
+		if highlighted[edge.id] {
+			b.WriteString(", color=red, penwidth=2")
+		}
+		b.WriteString("];\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Quotes a DOT identifier, escaping quotes and backslashes.
+func quoteDOT(value string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// DecodeDOT replaces the graph with one read from a Graphviz digraph, edges without a weight attribute have weight 1.
+// The graph is unchanged if the input cannot be decoded.
+// Returns a ParseError with the position of any problem in the input.
+// Vertices are added when first mentioned.
+func DecodeDOT(g WeightedGraph, r io.Reader) error {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	tokens, err := scanDOT(string(input))
+	if err != nil {
+		return err
+	}
+	decoded, err := newDecoded(g)
+	if err != nil {
+		return err
+	}
+	p := &dotParser{tokens: tokens}
+	if p.keyword("strict") {
+		p.next++
+	}
+	if !p.keyword("digraph") {
+		if p.keyword("graph") {
+			return p.fail("Undirected graphs are not supported")
+		}
+		return p.fail("Expected digraph")
+	}
+	p.next++
+	if p.peek().kind == dotIdentifier || p.peek().kind == dotString {
+		p.next++
+	}
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.punctuation("}") {
+		if err := p.statement(decoded); err != nil {
+			return err
+		}
+		if p.punctuation(";") {
+			p.next++
+		}
+	}
+	p.next++
+	if p.peek().kind != dotEnd {
+		return p.fail("Expected end of input")
+	}
+	replaceDecoded(g, decoded)
+	return nil
+}
+
+// Splits DOT input into tokens, skipping whitespace and comments.
+func scanDOT(input string) ([]dotToken, error) {
+	runes := []rune(input)
+	tokens := make([]dotToken, 0)
+	line, column := 1, 1
+	// Moves past n characters, keeping track of the position.
+	advance := func(i int, n int) int {
+		for end := i + n; i < end && i < len(runes); i++ {
+			if runes[i] == '\n' {
+				line, column = line+1, 1
+			} else {
+				column++
+			}
+		}
+		return i
+	}
+	fail := func(message string) error {
+		return &ParseError{Format: "DOT", Line: line, Column: column, Message: message}
+	}
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		rest := string(runes[i:min(i+2, len(runes))])
+		switch {
+		case unicode.IsSpace(c):
+			i = advance(i, 1)
+		case rest == "//" || c == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i = advance(i, 1)
+			}
+		case rest == "/*":
+			end := strings.Index(string(runes[i+2:]), "*/")
+			if end == -1 {
+				return nil, fail("Unterminated comment")
+			}
+			i = advance(i, len([]rune(string(runes[i+2:])[:end]))+4)
+		case rest == "->" || rest == "--":
+			tokens = append(tokens, dotToken{kind: dotArrow, text: rest, line: line, column: column})
+			i = advance(i, 2)
+		case strings.ContainsRune("{}[];,=:", c):
+			tokens = append(tokens, dotToken{kind: dotPunctuation, text: string(c), line: line, column: column})
+			i = advance(i, 1)
+		case c == '"':
+			token := dotToken{kind: dotString, line: line, column: column}
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) && (runes[j+1] == '"' || runes[j+1] == '\\') {
+					j++
+				}
+				b.WriteRune(runes[j])
+			}
+			if j == len(runes) {
+				return nil, fail("Unterminated string")
+			}
+			token.text = b.String()
+			tokens = append(tokens, token)
+			i = advance(i, j+1-i)
+		case c == '_' || c == '.' || c == '-' || unicode.IsLetter(c) || unicode.IsDigit(c):
+			token := dotToken{kind: dotIdentifier, line: line, column: column}
+			j := i + 1
+			for j < len(runes) && (runes[j] == '_' || runes[j] == '.' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			token.text = string(runes[i:j])
+			tokens = append(tokens, token)
+			i = advance(i, j-i)
+		default:
+			return nil, fail("Unexpected character " + strconv.QuoteRune(c))
+		}
+	}
+	return append(tokens, dotToken{kind: dotEnd, line: line, column: column}), nil
+}
+
+// Parses one statement and adds its vertices and edges to the graph.
+func (p *dotParser) statement(g WeightedGraph) error {
+	if p.keyword("subgraph") || p.punctuation("{") {
+		return p.fail("Subgraphs are not supported")
+	}
+	if p.keyword("graph") || p.keyword("node") || p.keyword("edge") {
+		p.next++
+		_, err := p.attributes()
+		return err
+	}
+	first := p.peek()
+	if first.kind != dotIdentifier && first.kind != dotString {
+		return p.fail("Expected vertex")
+	}
+	p.next++
+	if p.punctuation("=") {
+		p.next++
+		return p.identifier(nil)
+	}
+	if p.punctuation(":") {
+		return p.fail("Ports are not supported")
+	}
+	vertices := []string{first.text}
+	for p.peek().kind == dotArrow {
+		if p.peek().text != "->" {
+			return p.fail("Expected ->")
+		}
+		p.next++
+		var vertex string
+		if err := p.identifier(&vertex); err != nil {
+			return err
+		}
+		vertices = append(vertices, vertex)
+	}
+	attributes, err := p.attributes()
+	if err != nil {
+		return err
+	}
+	for _, vertex := range vertices {
+		addDecodedVertex(g, vertex)
+	}
+	if len(vertices) == 1 {
+		return nil
+	}
+	weight := 1
+	if token, ok := attributes["weight"]; ok {
+		weight, err = strconv.Atoi(token.text)
+		if err != nil {
+			return &ParseError{Format: "DOT", Line: token.line, Column: token.column, Message: "Weight is not an integer: " + token.text}
+		}
+	}
+	for i := 1; i < len(vertices); i++ {
+		if _, err := g.AddEdge(vertices[i-1], vertices[i], weight); err != nil {
+			return &ParseError{Format: "DOT", Line: first.line, Column: first.column, Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+// Parses any number of attribute lists, returning the value token of each attribute.
+func (p *dotParser) attributes() (map[string]dotToken, error) {
+	result := make(map[string]dotToken)
+	for p.punctuation("[") {
+		p.next++
+		for !p.punctuation("]") {
+			var name string
+			if err := p.identifier(&name); err != nil {
+				return nil, err
+			}
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			result[name] = p.peek()
+			if err := p.identifier(nil); err != nil {
+				return nil, err
+			}
+			if p.punctuation(",") || p.punctuation(";") {
+				p.next++
+			}
+		}
+		p.next++
+	}
+	return result, nil
+}
+
+// Parses an identifier or string, storing its text if value is not nil.
+func (p *dotParser) identifier(value *string) error {
+	token := p.peek()
+	if token.kind != dotIdentifier && token.kind != dotString {
+		return p.fail("Expected identifier")
+	}
+	if value != nil {
+		*value = token.text
+	}
+	p.next++
+	return nil
+}
+
+func (p *dotParser) expect(text string) error {
+	if !p.punctuation(text) {
+		return p.fail("Expected " + text)
+	}
+	p.next++
+	return nil
+}
+
+func (p *dotParser) peek() dotToken {
+	return p.tokens[p.next]
+}
+
+// True if the next token is the given punctuation.
+func (p *dotParser) punctuation(text string) bool {
+	return p.peek().kind == dotPunctuation && p.peek().text == text
+}
+
+// True if the next token is the given keyword, which is case insensitive.
+func (p *dotParser) keyword(text string) bool {
+	return p.peek().kind == dotIdentifier && strings.EqualFold(p.peek().text, text)
+}
+
+// Error at the next token.
+func (p *dotParser) fail(message string) error {
+	token := p.peek()
+	if token.kind == dotEnd {
+		message += ", got end of input"
+	} else {
+		message += ", got " + strconv.Quote(token.text)
+	}
+	return &ParseError{Format: "DOT", Line: token.line, Column: token.column, Message: message}
+}
@@ -0,0 +1,92 @@
+package structures
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// EncodeEdgeList writes the graph as a CSV edge list of source, target and weight.
+// Vertices without edges get a row of their own with the target and weight left empty.
+func EncodeEdgeList(g Graph, w io.Writer) error {
+	if err := checkVertexNames(g, "CSV"); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"source", "target", "weight"})
+	connected := make(map[string]bool)
+	for edge := range g.Edges() {
+		connected[edge.From()] = true
+		connected[edge.To()] = true
+	}
+	for vertex := range g.Vertices() {
+		if !connected[vertex] {
+			writer.Write([]string{vertex, "", ""})
+		}
+	}
+	for edge := range g.Edges() {
+		writer.Write([]string{edge.From(), edge.To(), strconv.Itoa(edge.Weight())})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// DecodeEdgeList replaces the graph with one read from a CSV edge list, rows without a weight have weight 1.
+// The graph is unchanged if the input cannot be decoded.
+// Returns a ParseError with the position of any problem in the input.
+// A row with only a source adds a vertex, and a first row of source,target or source,target,weight is taken as a header.
+func DecodeEdgeList(g WeightedGraph, r io.Reader) error {
+	decoded, err := newDecoded(g)
+	if err != nil {
+		return err
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	fail := func(field int, message string) error {
+		line, column := reader.FieldPos(field)
+		return &ParseError{Format: "CSV", Line: line, Column: column, Message: message}
+	}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			replaceDecoded(g, decoded)
+			return nil
+		}
+		if err != nil {
+			var parseError *csv.ParseError
+			if errors.As(err, &parseError) {
+				return &ParseError{Format: "CSV", Line: parseError.Line, Column: parseError.Column, Message: parseError.Err.Error()}
+			}
+			return err
+		}
+		if first && len(record) >= 2 && record[0] == "source" && record[1] == "target" {
+			continue
+		}
+		if len(record) > 3 {
+			return fail(3, "Expected at most 3 fields, got "+strconv.Itoa(len(record)))
+		}
+		if record[0] == "" {
+			return fail(0, "Source is empty")
+		}
+		addDecodedVertex(decoded, record[0])
+		if len(record) == 1 || (record[1] == "" && (len(record) == 2 || record[2] == "")) {
+			continue
+		}
+		if record[1] == "" {
+			return fail(1, "Target is empty")
+		}
+		weight := 1
+		if len(record) == 3 && record[2] != "" {
+			weight, err = strconv.Atoi(record[2])
+			if err != nil {
+				return fail(2, "Weight is not an integer: "+record[2])
+			}
+		}
+		addDecodedVertex(decoded, record[1])
+		if _, err := decoded.AddEdge(record[0], record[1], weight); err != nil {
+			return fail(1, err.Error())
+		}
+	}
+}
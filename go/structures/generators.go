@@ -0,0 +1,414 @@
+package structures
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+)
+
+// WeightDistribution returns a random edge weight using the random source of a generator.
+type WeightDistribution func(rng *rand.Rand) int
+
+// GeneratorOptions configures the random graph generators.
+type GeneratorOptions struct {
+	// Seed of the random source, the same seed always generates the same graph.
+	Seed uint64
+	// Weight gives the weight of each edge, every edge has weight 1 if it is nil.
+	Weight WeightDistribution
+	// Directed adds each edge in one direction only, and is not allowed for undirected graphs.
+	// Otherwise a directed graph gets each edge in both directions with the same weight, which models an undirected graph,
+	// and an undirected graph gets each edge once.
+	Directed bool
+}
+
+// ConstantWeights gives every edge the same weight.
+func ConstantWeights(weight int) WeightDistribution {
+	return func(rng *rand.Rand) int {
+		return weight
+	}
+}
+
+// UniformWeights gives edges weights between low and high inclusive, all equally likely.
+// Returns an error if high is below low.
+func UniformWeights(low int, high int) (WeightDistribution, error) {
+	if high < low {
+		return nil, errors.New("Highest weight must not be below the lowest weight: " + strconv.Itoa(high) + " < " + strconv.Itoa(low))
+	}
+	// The number of weights less one, which fits in a uint64 even when high - low overflows an int.
+	span := uint64(high) - uint64(low)
+	return func(rng *rand.Rand) int {
+		if span == math.MaxUint64 {
+			return int(rng.Uint64())
+		}
+		return low + int(rng.Uint64N(span+1))
+	}, nil
+}
+
+// NormalWeights gives edges weights from a normal distribution, rounded to the nearest integer.
+func NormalWeights(mean float64, deviation float64) WeightDistribution {
+	return func(rng *rand.Rand) int {
+		return int(math.Round(mean + deviation*rng.NormFloat64()))
+	}
+}
+
+// ExponentialWeights gives edges weights from an exponential distribution, rounded to the nearest integer.
+func ExponentialWeights(mean float64) WeightDistribution {
+	return func(rng *rand.Rand) int {
+		return int(math.Round(mean * rng.ExpFloat64()))
+	}
+}
+
+// ErdosRenyi replaces the graph with an Erdős–Rényi G(n, p) random graph on vertices "0" to "n-1",
+// where each pair of distinct vertices, ordered if directed, is joined with probability p.
+// Time: O(V + E).
+func ErdosRenyi(g WeightedGraph, n int, p float64, options GeneratorOptions) error {
+	if err := checkGenerator(n, 0, p); err != nil {
+		return err
+	}
+	gen, err := newGenerator(g, n, options)
+	if err != nil {
+		return err
+	}
+	randomPairs(gen.rng, n, p, options.Directed, func(from int, to int) {
+		gen.edge(from, to)
+	})
+	return nil
+}
+
+// BarabasiAlbert replaces the graph with a Barabási–Albert preferential attachment graph on vertices "0" to "n-1".
+// Each vertex after the first m joins m earlier vertices, chosen with probability proportional to their degree.
+// If directed, edges go from the new vertex to the earlier ones.
+func BarabasiAlbert(g WeightedGraph, n int, m int, options GeneratorOptions) error {
+	if err := checkGenerator(n, m, 0); err != nil {
+		return err
+	}
+	if m < 1 || m >= n {
+		return errors.New("Number of edges per vertex must be between 1 and n - 1: " + strconv.Itoa(m))
+	}
+	gen, err := newGenerator(g, n, options)
+	if err != nil {
+		return err
+	}
+	targets := make([]int, m)
+	for i := range m {
+		targets[i] = i
+	}
+	// Each vertex appears once for every edge it has, so choosing uniformly from it follows the degrees.
+	repeated := make([]int, 0, 2*m*n)
+	for source := m; source < n; source++ {
+		for _, target := range targets {
+			gen.edge(source, target)
+			repeated = append(repeated, target, source)
+		}
+		chosen := make(map[int]bool)
+		targets = targets[:0]
+		for len(targets) < m {
+			target := repeated[gen.rng.IntN(len(repeated))]
+			if !chosen[target] {
+				chosen[target] = true
+				targets = append(targets, target)
+			}
+		}
+	}
+	return nil
+}
+
+// WattsStrogatz replaces the graph with a Watts–Strogatz small world graph on vertices "0" to "n-1".
+// Vertices start in a ring, each joined to its k nearest neighbours, k even, then the far end of each edge
+// is moved to a random vertex with probability beta. If directed, edges go from the vertex that kept its end.
+func WattsStrogatz(g WeightedGraph, n int, k int, beta float64, options GeneratorOptions) error {
+	if err := checkGenerator(n, k, beta); err != nil {
+		return err
+	}
+	if k%2 != 0 || k >= n {
+		return errors.New("Number of neighbours must be even and less than n: " + strconv.Itoa(k))
+	}
+	gen, err := newGenerator(g, n, options)
+	if err != nil {
+		return err
+	}
+	joined := make(map[[2]int]bool)
+	pair := func(a int, b int) [2]int {
+		return [2]int{min(a, b), max(a, b)}
+	}
+	edges := make([][2]int, 0, n*k/2)
+	for distance := 1; distance <= k/2; distance++ {
+		for vertex := range n {
+			edge := [2]int{vertex, (vertex + distance) % n}
+			joined[pair(edge[0], edge[1])] = true
+			edges = append(edges, edge)
+		}
+	}
+	degree := make([]int, n)
+	for vertex := range n {
+		degree[vertex] = k
+	}
+	for i, edge := range edges {
+		// A vertex joined to every other vertex has nowhere to move the edge to.
+		if gen.rng.Float64() >= beta || degree[edge[0]] == n-1 {
+			continue
+		}
+		target := gen.rng.IntN(n)
+		for target == edge[0] || joined[pair(edge[0], target)] {
+			target = gen.rng.IntN(n)
+		}
+		delete(joined, pair(edge[0], edge[1]))
+		joined[pair(edge[0], target)] = true
+		degree[edge[1]]--
+		degree[target]++
+		edges[i][1] = target
+	}
+	for _, edge := range edges {
+		gen.edge(edge[0], edge[1])
+	}
+	return nil
+}
+
+// Grid replaces the graph with a width by height lattice, with vertices named "x,y" and joined to the vertices beside them.
+// If directed, edges go right and down.
+func Grid(g WeightedGraph, width int, height int, options GeneratorOptions) error {
+	if width < 0 || height < 0 {
+		return errors.New("Grid size must not be negative: " + strconv.Itoa(width) + "x" + strconv.Itoa(height))
+	}
+	gen, err := newGenerator(g, 0, options)
+	if err != nil {
+		return err
+	}
+	for y := range height {
+		for x := range width {
+			gen.names = append(gen.names, fmt.Sprintf("%d,%d", x, y))
+		}
+	}
+	g.AddAllVertices(gen.names)
+	for y := range height {
+		for x := range width {
+			if x+1 < width {
+				gen.edge(y*width+x, y*width+x+1)
+			}
+			if y+1 < height {
+				gen.edge(y*width+x, (y+1)*width+x)
+			}
+		}
+	}
+	return nil
+}
+
+// Complete replaces the graph with a complete graph on vertices "0" to "n-1".
+// If directed, every ordered pair of distinct vertices is joined, each edge with its own weight.
+func Complete(g WeightedGraph, n int, options GeneratorOptions) error {
+	if err := checkGenerator(n, 0, 0); err != nil {
+		return err
+	}
+	gen, err := newGenerator(g, n, options)
+	if err != nil {
+		return err
+	}
+	for from := range n {
+		for to := range n {
+			if from < to || (options.Directed && from != to) {
+				gen.edge(from, to)
+			}
+		}
+	}
+	return nil
+}
+
+// Star replaces the graph with a star on vertices "0" to "n-1", with "0" joined to every other vertex.
+// If directed, edges go from the centre.
+func Star(g WeightedGraph, n int, options GeneratorOptions) error {
+	if err := checkGenerator(n, 0, 0); err != nil {
+		return err
+	}
+	gen, err := newGenerator(g, n, options)
+	if err != nil {
+		return err
+	}
+	for leaf := 1; leaf < n; leaf++ {
+		gen.edge(0, leaf)
+	}
+	return nil
+}
+
+// RandomDAG replaces the graph with a random directed acyclic graph on vertices "0" to "n-1".
+// The vertices are put in a random order and each pair is joined with probability p, from the earlier to the later vertex.
+// Edges are always added in one direction. Time: O(V + E).
+func RandomDAG(g WeightedGraph, n int, p float64, options GeneratorOptions) error {
+	if err := checkGenerator(n, 0, p); err != nil {
+		return err
+	}
+	options.Directed = true
+	gen, err := newGenerator(g, n, options)
+	if err != nil {
+		return err
+	}
+	order := gen.rng.Perm(n)
+	randomPairs(gen.rng, n, p, false, func(later int, earlier int) {
+		gen.edge(order[earlier], order[later])
+	})
+	return nil
+}
+
+// RandomTree replaces the graph with a tree on vertices "0" to "n-1", chosen uniformly from all labelled trees
+// using a random Prüfer sequence. If directed, edges go away from "0".
+func RandomTree(g WeightedGraph, n int, options GeneratorOptions) error {
+	if err := checkGenerator(n, 0, 0); err != nil {
+		return err
+	}
+	gen, err := newGenerator(g, n, options)
+	if err != nil {
+		return err
+	}
+	if n < 2 {
+		return nil
+	}
+	// Decode the sequence, each step joins the smallest remaining leaf to the next vertex in the sequence.
+	sequence := make([]int, n-2)
+	degree := make([]int, n)
+	for i := range sequence {
+		sequence[i] = gen.rng.IntN(n)
+		degree[sequence[i]]++
+	}
+	for vertex := range n {
+		degree[vertex]++
+	}
+	adjacency := make([][]int, n)
+	join := func(a int, b int) {
+		adjacency[a] = append(adjacency[a], b)
+		adjacency[b] = append(adjacency[b], a)
+	}
+	next := 0
+	for degree[next] != 1 {
+		next++
+	}
+	leaf := next
+	for _, vertex := range sequence {
+		join(leaf, vertex)
+		degree[vertex]--
+		if degree[vertex] == 1 && vertex < next {
+			leaf = vertex
+		} else {
+			next++
+			for degree[next] != 1 {
+				next++
+			}
+			leaf = next
+		}
+	}
+	join(leaf, n-1)
+
+	// Breadth first from the root, so that edges point away from it.
+	visited := make([]bool, n)
+	visited[0] = true
+	queue := []int{0}
+	for len(queue) > 0 {
+		front := queue[0]
+		queue = queue[1:]
+		for _, child := range adjacency[front] {
+			if !visited[child] {
+				visited[child] = true
+				gen.edge(front, child)
+				queue = append(queue, child)
+			}
+		}
+	}
+	return nil
+}
+
+// Random source and vertex names shared by the generators.
+type generator struct {
+	g       WeightedGraph
+	rng     *rand.Rand
+	options GeneratorOptions
+	names   []string
+	// False for undirected graphs, whose edges already join both ways.
+	directed bool
+}
+
+// Clears the graph and adds vertices "0" to "n-1".
+// Returns an error if the options ask for directed edges in an undirected graph.
+func newGenerator(g WeightedGraph, n int, options GeneratorOptions) (*generator, error) {
+	_, directed := g.(DirectedWeightedGraph)
+	if options.Directed && !directed {
+		return nil, errors.New("Directed edges cannot be generated in an undirected graph")
+	}
+	gen := &generator{g: g, rng: rand.New(rand.NewPCG(options.Seed, 0)), options: options, names: make([]string, n), directed: directed}
+	for i := range n {
+		gen.names[i] = strconv.Itoa(i)
+	}
+	g.Clear()
+	g.AddAllVertices(gen.names)
+	return gen, nil
+}
+
+// Adds an edge with a random weight, in both directions if the options are undirected but the graph is directed.
+func (gen *generator) edge(from int, to int) {
+	weight := 1
+	if gen.options.Weight != nil {
+		weight = gen.options.Weight(gen.rng)
+	}
+	gen.g.AddEdge(gen.names[from], gen.names[to], weight)
+	if !gen.options.Directed && gen.directed && from != to {
+		gen.g.AddEdge(gen.names[to], gen.names[from], weight)
+	}
+}
+
+// Checks the parameters common to the generators.
+func checkGenerator(n int, k int, p float64) error {
+	if n < 0 {
+		return errors.New("Number of vertices must not be negative: " + strconv.Itoa(n))
+	}
+	if k < 0 {
+		return errors.New("Number of edges per vertex must not be negative: " + strconv.Itoa(k))
+	}
+	if !(p >= 0 && p <= 1) {
+		return errors.New("Probability must be between 0 and 1: " + strconv.FormatFloat(p, 'g', -1, 64))
+	}
+	return nil
+}
+
+// Calls yield for each pair of distinct vertices independently with probability p, ordered pairs if directed
+// and pairs with the first vertex greater otherwise. Skips over the pairs that are not chosen,
+// with geometrically distributed gaps, so the time is proportional to the number chosen.
+func randomPairs(rng *rand.Rand, n int, p float64, directed bool, yield func(v int, w int)) {
+	if p == 0 || n < 2 {
+		return
+	}
+	logSkip := math.Log(1 - p)
+	skip := func() int {
+		if p == 1 {
+			return 0
+		}
+		return int(math.Log(1-rng.Float64()) / logSkip)
+	}
+	if directed {
+		// Row v holds the n - 1 vertices other than v, position j is vertex j before the diagonal and j + 1 after it,
+		// so every ordered pair has the same chance.
+		v, j := 0, -1
+		for v < n {
+			j += 1 + skip()
+			v += j / (n - 1)
+			j %= n - 1
+			if v < n {
+				w := j
+				if w >= v {
+					w++
+				}
+				yield(v, w)
+			}
+		}
+		return
+	}
+	v, w := 1, -1
+	for v < n {
+		w += 1 + skip()
+		for w >= v && v < n {
+			w -= v
+			v++
+		}
+		if v < n {
+			yield(v, w)
+		}
+	}
+}
@@ -1,48 +1,177 @@
 package structures
 
-// Vertex represents a string vertex in a directed weighted graph.
+import (
+	"errors"
+	"iter"
+)
+
+// Vertex represents a string vertex in a graph.
 type Vertex struct {
 	// Value is unique amongst vertices in the same graph.
-	value string
-	// Distance is the shortest distance from the source vertex.
-	distance int
-	// Used for Dijkstra's algorithm.
-	previous *Vertex
+	value      string
+	attributes map[string]any
 }
 
-// Edge represents a connection between two vertices in a directed weighted graph.
+// Edge represents a connection between two vertices in a graph.
 type Edge struct {
+	// Given by AddEdge, unique amongst edges ever added to the same graph.
+	id       int
 	vertices [2]string
 	weight   int
-	// Status is only used for the adjacency matrix implementation, it will be 1 if the edge exists and 0 if not.
-	status int
+	// Set by SetEdgeFloatWeight, nil if the edge only has an integer weight.
+	floatWeight *float64
+	attributes  map[string]any
+}
+
+// ID returns the ID given to the edge when it was added to its graph.
+func (e *Edge) ID() int {
+	return e.id
+}
+
+// From returns the vertex the edge starts at.
+func (e *Edge) From() string {
+	return e.vertices[0]
+}
+
+// To returns the vertex the edge ends at.
+func (e *Edge) To() string {
+	return e.vertices[1]
+}
+
+// Weight returns the weight of the edge.
+func (e *Edge) Weight() int {
+	return e.weight
+}
+
+// FloatWeight returns the float64 weight of the edge, which is the integer weight unless set with SetEdgeFloatWeight.
+func (e *Edge) FloatWeight() float64 {
+	if e.floatWeight != nil {
+		return *e.floatWeight
+	}
+	return float64(e.weight)
+}
+
+// Attribute returns the value of a named attribute of the edge, false if it has not been set.
+func (e *Edge) Attribute(name string) (any, bool) {
+	value, ok := e.attributes[name]
+	return value, ok
 }
 
-// DijkstraResult represents a step in the shortest path traversal.
-type DijkstraResult struct {
+// WeightBy returns the value of a named numeric attribute of the edge as a float64, or FloatWeight if the name is empty.
+func (e *Edge) WeightBy(attribute string) (float64, error) {
+	if attribute == "" {
+		return e.FloatWeight(), nil
+	}
+	value, ok := e.attributes[attribute]
+	if !ok {
+		return 0, errors.New("Edge has no attribute " + attribute + ": " + e.From() + "->" + e.To())
+	}
+	switch number := value.(type) {
+	case int:
+		return float64(number), nil
+	case int64:
+		return float64(number), nil
+	case float32:
+		return float64(number), nil
+	case float64:
+		return number, nil
+	}
+	return 0, errors.New("Edge attribute " + attribute + " is not a number: " + e.From() + "->" + e.To())
+}
+
+// Weight is the type of edge weights and path lengths.
+type Weight interface {
+	~int | ~float64
+}
+
+// DijkstraResultOf represents a step in the shortest path traversal.
+type DijkstraResultOf[W Weight] struct {
 	Value    string
-	Distance int
+	Distance W
 }
 
+// DijkstraResult is a step in a shortest path with integer weights.
+type DijkstraResult = DijkstraResultOf[int]
+
 // DirectedWeightedGraph represents a directed weighted graph that can hold string nodes.
 type DirectedWeightedGraph interface {
-	AddVertex(value string) error                             // Adds a new vertex to the graph.
-	AddAllVertices(values []string) error                     // Adds a list of vertices to the graph.
-	RemoveVertex(value string) error                          // Removes a vertex from the graph.
-	AddEdge(from string, to string, weight int) error         // Adds a new edge to the graph.
-	RemoveEdge(from string, to string) error                  // Removes an edge from the graph.
-	Clear()                                                   // Clears the graph.
-	IsEmpty() bool                                            // True if the graph is empty.
-	DFS(source string) []string                               // Depth first traversal.
-	BFS(source string) []string                               // Breadth first traversal.
-	Dijkstra(source string)                                   // Dijkstra's algorithm.
+	Graph
+	// Adds a new edge to the graph and returns its ID.
+	AddEdge(from string, to string, weight int) (int, error)
+	Dijkstra(source string) error                             // Dijkstra's algorithm, stores the result in the graph.
 	GetShortestPath(target string) ([]*DijkstraResult, error) // To be used after a call to Dijkstra().
-	NumberOfVertices() int                                    // Number of vertices in the graph.
-	NumberOfEdges() int                                       // Number of edges in the graph.
-	getVertex(value string) *Vertex                           // Get a vertex given its value.
+	getEdge(from string, to string) *Edge                     // Get the first edge from one vertex to another.
 	getOutgoingEdges(vertex *Vertex) []*Edge                  // Get the outgoing edges of a given vertex.
 	getNeighbours(vertex *Vertex) []*Vertex                   // Get the neighbouring vertices of a given vertex.
-	resetPath()                                               // Resets Dijkstra's path.
+	getParallelEdgePolicy() ParallelEdgePolicy                // Get what AddEdge does when the edge already exists.
+	getVersion() int                                          // Incremented on every modification of the graph.
+}
+
+// Graph holds the operations shared by every kind of graph. The algorithms are package functions over it,
+// which see an undirected graph as a directed one with each edge in both directions,
+// and an unweighted graph as one with weight 1 on every edge.
+type Graph interface {
+	AddVertex(value string) error                   // Adds a new vertex to the graph.
+	AddAllVertices(values []string) error           // Adds a list of vertices to the graph.
+	RemoveVertex(value string) error                // Removes a vertex from the graph.
+	RemoveEdge(from string, to string) error        // Removes an edge from the graph.
+	RemoveEdgeByID(id int) error                    // Removes an edge from the graph given its ID.
+	EdgesBetween(from string, to string) []*Edge    // Edges between two vertices.
+	Clear()                                         // Clears the graph.
+	IsEmpty() bool                                  // True if the graph is empty.
+	DFS(source string) ([]string, error)            // Depth first traversal.
+	BFS(source string) ([]string, error)            // Breadth first traversal.
+	NumberOfVertices() int                          // Number of vertices in the graph.
+	NumberOfEdges() int                             // Number of edges in the graph.
+	Vertices() iter.Seq[string]                     // Iterates over the vertices.
+	Edges() iter.Seq[*Edge]                         // Iterates over the edges.
+	Neighbours(value string) iter.Seq2[string, int] // Iterates over the neighbours of a vertex and the edge weights.
+	OutgoingEdges(value string) iter.Seq[*Edge]     // Iterates over the edges leaving a vertex.
+	// Attributes of vertices and edges, values of any type stored under a name.
+	VertexAttribute(value string, name string) (any, bool)
+	SetVertexAttribute(value string, name string, attribute any) error
+	EdgeAttribute(from string, to string, name string) (any, bool)
+	SetEdgeAttribute(from string, to string, name string, attribute any) error
+	// Sets a float64 weight on an edge, read by FloatWeight and ShortestPathsBy.
+	SetEdgeFloatWeight(from string, to string, weight float64) error
+	// The same for the edge with a given ID, to reach any of several parallel edges.
+	EdgeAttributeByID(id int, name string) (any, bool)
+	SetEdgeAttributeByID(id int, name string, attribute any) error
+	SetEdgeFloatWeightByID(id int, weight float64) error
+	// Sets what AddEdge does when the edge already exists.
+	SetParallelEdgePolicy(policy ParallelEdgePolicy)
+	ToList() *AdjacencyList         // Copies the graph into a directed adjacency list.
+	ToMatrix() *AdjacencyMatrix     // Copies the graph into a directed adjacency matrix.
+	ToCSR() *CSRGraph               // Copies the graph into a read-only compressed sparse row graph.
+	getVertex(value string) *Vertex // Get a vertex given its value.
+	// The graph itself if it is directed and weighted, otherwise the directed weighted graph holding its edges.
+	directed() DirectedWeightedGraph
+}
+
+// WeightedGraph holds the operations shared by directed and undirected weighted graphs.
+type WeightedGraph interface {
+	Graph
+	AddEdge(from string, to string, weight int) (int, error) // Adds a new edge to the graph and returns its ID.
+}
+
+// DirectedGraph represents a directed unweighted graph that can hold string nodes.
+type DirectedGraph interface {
+	Graph
+	AddEdge(from string, to string) (int, error) // Adds a new edge to the graph and returns its ID.
+}
+
+// UndirectedWeightedGraph represents an undirected weighted graph that can hold string nodes.
+// Each edge is counted and iterated once, in the direction it was added, and can be removed from either end.
+type UndirectedWeightedGraph interface {
+	Graph
+	AddEdge(a string, b string, weight int) (int, error) // Adds a new edge to the graph and returns its ID.
+}
+
+// UndirectedGraph represents an undirected unweighted graph that can hold string nodes.
+// Each edge is counted and iterated once, in the direction it was added, and can be removed from either end.
+type UndirectedGraph interface {
+	Graph
+	AddEdge(a string, b string) (int, error) // Adds a new edge to the graph and returns its ID.
 }
 
 // Removes the given vertex from the vertices array.
@@ -55,10 +184,10 @@ func removeFromVertexArray(arr []*Vertex, value string) []*Vertex {
 	return arr
 }
 
-// Removes an edge from the edges array given from and to.
-func removeFromEdgesArray(arr []*Edge, from string, to string) []*Edge {
-	for index, edge := range arr {
-		if edge.vertices[0] == from && edge.vertices[1] == to {
+// Removes the given edge from the edges array.
+func removeEdgeFromArray(arr []*Edge, edge *Edge) []*Edge {
+	for index, elem := range arr {
+		if elem == edge {
 			return append(arr[:index], arr[index+1:]...)
 		}
 	}
@@ -98,3 +227,63 @@ func dfsHelper(vertex *Vertex, result **[]string, g DirectedWeightedGraph) {
 		}
 	}
 }
+
+// Generic vertex iterator.
+func verticesSeq(g DirectedWeightedGraph, vertices []*Vertex) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		version := g.getVersion()
+		for _, vertex := range vertices {
+			if !yield(vertex.value) {
+				return
+			}
+			checkVersion(version, g.getVersion())
+		}
+	}
+}
+
+// Generic edge iterator.
+func edgesSeq(g DirectedWeightedGraph, edges []*Edge) iter.Seq[*Edge] {
+	return func(yield func(*Edge) bool) {
+		version := g.getVersion()
+		for _, edge := range edges {
+			if !yield(edge) {
+				return
+			}
+			checkVersion(version, g.getVersion())
+		}
+	}
+}
+
+// Generic neighbour iterator, yields nothing if the vertex does not exist.
+func neighboursSeq(g DirectedWeightedGraph, value string) iter.Seq2[string, int] {
+	return func(yield func(string, int) bool) {
+		vertex := g.getVertex(value)
+		if vertex == nil {
+			return
+		}
+		version := g.getVersion()
+		for _, edge := range g.getOutgoingEdges(vertex) {
+			if !yield(edge.vertices[1], edge.weight) {
+				return
+			}
+			checkVersion(version, g.getVersion())
+		}
+	}
+}
+
+// Generic outgoing edge iterator, yields nothing if the vertex does not exist.
+func outgoingEdgesSeq(g DirectedWeightedGraph, value string) iter.Seq[*Edge] {
+	return func(yield func(*Edge) bool) {
+		vertex := g.getVertex(value)
+		if vertex == nil {
+			return
+		}
+		version := g.getVersion()
+		for _, edge := range g.getOutgoingEdges(vertex) {
+			if !yield(edge) {
+				return
+			}
+			checkVersion(version, g.getVersion())
+		}
+	}
+}
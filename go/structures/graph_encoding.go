@@ -0,0 +1,105 @@
+package structures
+
+import (
+	"errors"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ParseError is returned when decoding a graph fails, with the position of the problem in the input.
+type ParseError struct {
+	// Format is the name of the format being decoded.
+	Format string
+	// Line and Column start at 1, the column counts characters.
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return e.Format + " line " + strconv.Itoa(e.Line) + ", column " + strconv.Itoa(e.Column) + ": " + e.Message
+}
+
+// Converts a byte offset into the input into a line and column.
+func inputPosition(input []byte, offset int) (int, int) {
+	line, start := 1, 0
+	for i := 0; i < offset && i < len(input); i++ {
+		if input[i] == '\n' {
+			line++
+			start = i + 1
+		}
+	}
+	return line, utf8.RuneCount(input[start:min(offset, len(input))]) + 1
+}
+
+// Returns a new empty graph of the same type and parallel edge policy as g to decode into, so that g is unchanged
+// if decoding fails. ErrReadOnly if g cannot be changed.
+func newDecoded(g WeightedGraph) (WeightedGraph, error) {
+	var decoded WeightedGraph
+	switch g.(type) {
+	case *AdjacencyList:
+		decoded = &AdjacencyList{}
+	case *AdjacencyMatrix:
+		decoded = &AdjacencyMatrix{}
+	case *UndirectedWeightedAdjacencyList:
+		decoded = &UndirectedWeightedAdjacencyList{}
+	case *UndirectedWeightedAdjacencyMatrix:
+		decoded = &UndirectedWeightedAdjacencyMatrix{}
+	default:
+		return nil, ErrReadOnly
+	}
+	decoded.Clear()
+	decoded.SetParallelEdgePolicy(g.directed().getParallelEdgePolicy())
+	return decoded, nil
+}
+
+// Replaces the contents of the graph with a graph decoded successfully.
+// Its edges were added under the same parallel edge policy, so adding them again cannot fail.
+func replaceDecoded(g WeightedGraph, decoded WeightedGraph) {
+	g.Clear()
+	for vertex := range decoded.Vertices() {
+		g.AddVertex(vertex)
+	}
+	for edge := range decoded.Edges() {
+		g.AddEdge(edge.From(), edge.To(), edge.Weight())
+	}
+}
+
+// Returns an error if a vertex has an empty name, which the format cannot hold.
+func checkVertexNames(g Graph, format string) error {
+	for vertex := range g.Vertices() {
+		if vertex == "" {
+			return errors.New(format + " cannot hold a vertex with an empty name")
+		}
+	}
+	return nil
+}
+
+// Adds a vertex while decoding unless it has already been added.
+func addDecodedVertex(g WeightedGraph, value string) {
+	if g.getVertex(value) == nil {
+		g.AddVertex(value)
+	}
+}
+
+// Finds the IDs of the edges along a path returned by GetShortestPath, one for each step.
+// Of parallel edges, the first whose weight matches the step is taken.
+func pathEdges(g Graph, path []*DijkstraResult) map[int]bool {
+	result := make(map[int]bool)
+	for i := 1; i < len(path); i++ {
+		step := path[i].Distance - path[i-1].Distance
+		var found *Edge
+		for edge := range g.OutgoingEdges(path[i-1].Value) {
+			if edge.To() != path[i].Value {
+				continue
+			}
+			if found == nil || (edge.Weight() == step && found.Weight() != step) {
+				found = edge
+			}
+		}
+		if found != nil {
+			result[found.id] = true
+		}
+	}
+	return result
+}
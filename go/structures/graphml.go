@@ -0,0 +1,159 @@
+package structures
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// EncodeGraphML writes the graph in GraphML format, with edge weights as an int data key named weight.
+func EncodeGraphML(g Graph, w io.Writer) error {
+	if err := checkVertexNames(g, "GraphML"); err != nil {
+		return err
+	}
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString("<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+	b.WriteString("  <key id=\"weight\" for=\"edge\" attr.name=\"weight\" attr.type=\"int\"/>\n")
+	b.WriteString("  <graph edgedefault=\"directed\">\n")
+	for vertex := range g.Vertices() {
+		b.WriteString("    <node id=\"" + escapeXML(vertex) + "\"/>\n")
+	}
+	for edge := range g.Edges() {
+		b.WriteString("    <edge source=\"" + escapeXML(edge.From()) + "\" target=\"" + escapeXML(edge.To()) + "\">")
+		b.WriteString("<data key=\"weight\">" + strconv.Itoa(edge.Weight()) + "</data></edge>\n")
+	}
+	b.WriteString("  </graph>\n</graphml>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func escapeXML(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+// DecodeGraphML replaces the graph with one read from GraphML, edges without a weight have weight 1.
+// The graph is unchanged if the input cannot be decoded.
+// Returns a ParseError with the position of any problem in the input.
+// The input must hold a single directed graph, hyperedges and ports are not supported.
+func DecodeGraphML(g WeightedGraph, r io.Reader) error {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	decoded, err := newDecoded(g)
+	if err != nil {
+		return err
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(input))
+	// Offset of the start of the current element.
+	offset := 0
+	fail := func(message string) error {
+		line, column := inputPosition(input, offset)
+		return &ParseError{Format: "GraphML", Line: line, Column: column, Message: message}
+	}
+	weightKey := "weight"
+	depth := 0
+	// The edge being read, where it starts, and the data key of the current data element.
+	var edge *[2]string
+	edgeOffset := 0
+	weight := 1
+	dataKey := ""
+	graphs := 0
+	for {
+		offset = int(decoder.InputOffset())
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var syntaxError *xml.SyntaxError
+			if errors.As(err, &syntaxError) {
+				line, column := decoder.InputPos()
+				return &ParseError{Format: "GraphML", Line: line, Column: column, Message: syntaxError.Msg}
+			}
+			return fail(err.Error())
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			depth++
+			attributes := make(map[string]string)
+			for _, attribute := range element.Attr {
+				attributes[attribute.Name.Local] = attribute.Value
+			}
+			switch element.Name.Local {
+			case "graphml":
+				if depth != 1 {
+					return fail("Unexpected graphml element")
+				}
+			case "key":
+				if attributes["attr.name"] == "weight" && attributes["for"] != "node" {
+					weightKey = attributes["id"]
+				}
+			case "graph":
+				graphs++
+				if graphs > 1 {
+					return fail("Only one graph is supported")
+				}
+				if attributes["edgedefault"] == "undirected" {
+					return fail("Undirected graphs are not supported")
+				}
+			case "node":
+				if attributes["id"] == "" {
+					return fail("Node has no id")
+				}
+				addDecodedVertex(decoded, attributes["id"])
+			case "edge":
+				source, target := attributes["source"], attributes["target"]
+				if source == "" || target == "" {
+					return fail("Edge needs a source and a target")
+				}
+				if attributes["directed"] == "false" {
+					return fail("Undirected edges are not supported")
+				}
+				edge = &[2]string{source, target}
+				edgeOffset = offset
+				weight = 1
+			case "data":
+				dataKey = attributes["key"]
+			case "hyperedge", "port":
+				return fail("Element is not supported: " + element.Name.Local)
+			}
+		case xml.CharData:
+			if edge != nil && dataKey == weightKey && dataKey != "" {
+				text := strings.TrimSpace(string(element))
+				weight, err = strconv.Atoi(text)
+				if err != nil {
+					return fail("Weight is not an integer: " + text)
+				}
+			}
+		case xml.EndElement:
+			depth--
+			switch element.Name.Local {
+			case "data":
+				dataKey = ""
+			case "edge":
+				offset = edgeOffset
+				for _, vertex := range edge {
+					if decoded.getVertex(vertex) == nil {
+						return fail("Edge refers to an unknown node: " + vertex)
+					}
+				}
+				if _, err := decoded.AddEdge(edge[0], edge[1], weight); err != nil {
+					return fail(err.Error())
+				}
+				edge = nil
+			}
+		}
+	}
+	if graphs == 0 {
+		return fail("No graph element")
+	}
+	replaceDecoded(g, decoded)
+	return nil
+}
@@ -2,136 +2,202 @@ package structures
 
 import (
 	"errors"
-	"hash/fnv"
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"reflect"
 )
 
-// MapNode represents a node stored in the map.
+// MapNodeOf represents a node stored in the map.
 // The key represents the unhashed key.
-type MapNode struct {
-	key   string
-	value int
+// Nodes are also linked in insertion order so that traversals are deterministic.
+type MapNodeOf[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *MapNodeOf[K, V]
+	next  *MapNodeOf[K, V]
 }
 
-// Hashmap maps strings to ints.
-type Hashmap struct {
-	items map[uint32][]*MapNode
+// HashmapOf maps comparable keys to values of any type.
+type HashmapOf[K comparable, V any] struct {
+	items map[uint64][]*MapNodeOf[K, V]
+	seed  maphash.Seed
+	head  *MapNodeOf[K, V]
+	tail  *MapNodeOf[K, V]
 	size  int
+	// Incremented when an entry is added or removed, used to detect modification during iteration.
+	version int
 }
 
-func hash(str string) uint32 {
-	h := fnv.New32a()
-	h.Write([]byte(str))
-	return h.Sum32()
+// MapNode represents a node stored in a map of strings to ints.
+type MapNode = MapNodeOf[string, int]
+
+// Hashmap maps strings to ints.
+type Hashmap = HashmapOf[string, int]
+
+func (m *HashmapOf[K, V]) hash(key K) uint64 {
+	return maphash.Comparable(m.seed, key)
 }
 
-func find(arr []*MapNode, key string) (*MapNode, error) {
+func find[K comparable, V any](arr []*MapNodeOf[K, V], key K) (*MapNodeOf[K, V], error) {
 	for _, elem := range arr {
 		if elem.key == key {
 			return elem, nil
 		}
 	}
-	return nil, errors.New("Key not found: " + key)
+	return nil, errors.New("Key not found: " + fmt.Sprint(key))
 }
 
-func remove(arr []*MapNode, key string) ([]*MapNode, error) {
+func remove[K comparable, V any](arr []*MapNodeOf[K, V], key K) ([]*MapNodeOf[K, V], error) {
 	for index, elem := range arr {
 		if elem.key == key {
 			return append(arr[:index], arr[index+1:]...), nil
 		}
 	}
-	return nil, errors.New("Key does not exist in array: " + key)
+	return nil, errors.New("Key does not exist in array: " + fmt.Sprint(key))
 }
 
 // Put adds a new entry to the map.
-func (m *Hashmap) Put(key string, value int) {
+func (m *HashmapOf[K, V]) Put(key K, value V) {
 	if m.items == nil {
-		m.items = make(map[uint32][]*MapNode)
-	}
-	hashCode := hash(key)
-	arr, exists := m.items[hashCode]
-	if !exists {
-		m.items[hashCode] = make([]*MapNode, 0)
+		m.items = make(map[uint64][]*MapNodeOf[K, V])
+		m.seed = maphash.MakeSeed()
 	}
-	item, err := find(arr, key)
+	hashCode := m.hash(key)
+	item, err := find(m.items[hashCode], key)
 	// Value already exists in map, so override it.
 	if err == nil {
 		item.value = value
+		return
+	}
+	node := &MapNodeOf[K, V]{key: key, value: value, prev: m.tail}
+	m.items[hashCode] = append(m.items[hashCode], node)
+	if m.tail == nil {
+		m.head = node
 	} else {
-		m.items[hashCode] = append(m.items[hashCode], &MapNode{key: key, value: value})
+		m.tail.next = node
 	}
+	m.tail = node
 	m.size++
+	m.version++
 }
 
 // Get finds a value in the map by key.
-func (m *Hashmap) Get(key string) (int, error) {
-	hashCode := hash(key)
-	arr, exists := m.items[hashCode]
-	if !exists {
-		return 0, errors.New("Key does not exist: " + key)
+func (m *HashmapOf[K, V]) Get(key K) (V, error) {
+	var zero V
+	if m.items == nil {
+		return zero, errors.New("Key does not exist: " + fmt.Sprint(key))
 	}
-	item, err := find(arr, key)
+	item, err := find(m.items[m.hash(key)], key)
 	if err != nil {
-		return 0, errors.New("Key does not exist: " + key)
+		return zero, errors.New("Key does not exist: " + fmt.Sprint(key))
 	}
 	return item.value, nil
 }
 
 // Remove deletes and returns the value mapped to key.
-func (m *Hashmap) Remove(key string) (int, error) {
-	hashCode := hash(key)
-	value, err := m.Get(key)
+func (m *HashmapOf[K, V]) Remove(key K) (V, error) {
+	var zero V
+	if m.items == nil {
+		return zero, errors.New("Key does not exist: " + fmt.Sprint(key))
+	}
+	hashCode := m.hash(key)
+	item, err := find(m.items[hashCode], key)
 	if err != nil {
-		return 0, err
+		return zero, errors.New("Key does not exist: " + fmt.Sprint(key))
 	}
 	newArr, err := remove(m.items[hashCode], key)
 	if err != nil {
-		return 0, err
+		return zero, err
+	}
+	if len(newArr) == 0 {
+		delete(m.items, hashCode)
+	} else {
+		m.items[hashCode] = newArr
+	}
+	if item.prev == nil {
+		m.head = item.next
+	} else {
+		item.prev.next = item.next
+	}
+	if item.next == nil {
+		m.tail = item.prev
+	} else {
+		item.next.prev = item.prev
 	}
-	m.items[hashCode] = newArr
 	m.size--
-	return value, nil
+	m.version++
+	return item.value, nil
 }
 
 // ContainsKey returns true if the key has an entry in the map.
-func (m *Hashmap) ContainsKey(key string) bool {
+func (m *HashmapOf[K, V]) ContainsKey(key K) bool {
 	_, err := m.Get(key)
 	return err == nil
 }
 
 // ContainsValue returns true if the value is present in the map.
-func (m *Hashmap) ContainsValue(value int) bool {
-	values := m.Values()
-	for _, elem := range values {
-		if elem == value {
+// Values are compared with reflect.DeepEqual since V is not required to be comparable.
+func (m *HashmapOf[K, V]) ContainsValue(value V) bool {
+	for node := m.head; node != nil; node = node.next {
+		if reflect.DeepEqual(node.value, value) {
 			return true
 		}
 	}
 	return false
 }
 
-// Values returns the set of all values in the map as a slice.
-func (m *Hashmap) Values() []int {
-	result := make([]int, 0)
-	for _, arr := range m.items {
-		for _, node := range arr {
-			result = append(result, node.value)
+// All returns an iterator over the entries of the map in insertion order.
+// Overriding the value of an existing key during iteration is allowed, adding or removing entries is not.
+func (m *HashmapOf[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		version := m.version
+		for node := m.head; node != nil; node = node.next {
+			if !yield(node.key, node.value) {
+				return
+			}
+			checkVersion(version, m.version)
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the map in insertion order.
+func (m *HashmapOf[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the map in insertion order.
+func (m *HashmapOf[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range m.All() {
+			if !yield(value) {
+				return
+			}
 		}
 	}
-	return result
 }
 
 // Clear removes all entries in the map.
-func (m *Hashmap) Clear() {
+func (m *HashmapOf[K, V]) Clear() {
 	m.items = nil
+	m.head = nil
+	m.tail = nil
 	m.size = 0
+	m.version++
 }
 
 // IsEmpty returns true if the map has no entries.
-func (m *Hashmap) IsEmpty() bool {
-	return len(m.items) == 0
+func (m *HashmapOf[K, V]) IsEmpty() bool {
+	return m.size == 0
 }
 
 // Size returns the number of entries in the map.
-func (m *Hashmap) Size() int {
+func (m *HashmapOf[K, V]) Size() int {
 	return m.size
 }
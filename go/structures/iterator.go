@@ -0,0 +1,13 @@
+package structures
+
+import "errors"
+
+// ErrModifiedDuringIteration is the panic value raised when a container is modified while it is being iterated.
+var ErrModifiedDuringIteration = errors.New("Container was modified during iteration")
+
+// Panics if a container's version changed since the iteration started.
+func checkVersion(expected int, actual int) {
+	if expected != actual {
+		panic(ErrModifiedDuringIteration)
+	}
+}
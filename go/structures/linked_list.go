@@ -1,46 +1,54 @@
 package structures
 
-import "errors"
-import "strconv"
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+)
 
-// LinkedListNode represents a node in the linked list.
-type LinkedListNode struct {
-	Value int
-	Prev  *LinkedListNode
-	Next  *LinkedListNode
+// LinkedListNodeOf represents a node in the linked list.
+type LinkedListNodeOf[T comparable] struct {
+	Value T
+	Prev  *LinkedListNodeOf[T]
+	Next  *LinkedListNodeOf[T]
 }
 
-// LinkedList is doubly linked and holds ints.
-type LinkedList struct {
-	head *LinkedListNode
-	tail *LinkedListNode
+// LinkedListOf is doubly linked and holds elements of any comparable type.
+type LinkedListOf[T comparable] struct {
+	head *LinkedListNodeOf[T]
+	tail *LinkedListNodeOf[T]
 	size int
+	// Incremented on every modification, used to detect modification during iteration.
+	version int
 }
 
 // Append adds an element to the end.
-func (l *LinkedList) Append(elem int) {
-	newNode := LinkedListNode{Value: elem, Prev: l.tail}
+func (l *LinkedListOf[T]) Append(elem T) {
+	newNode := LinkedListNodeOf[T]{Value: elem, Prev: l.tail}
 	if l.size == 0 {
 		l.head = &newNode
 		l.tail = &newNode
 		l.size = 1
+		l.version++
 		return
 	}
 
 	l.tail.Next = &newNode
 	l.tail = &newNode
 	l.size++
+	l.version++
 }
 
 // AppendAll adds all elements in a given slice to the end.
-func (l *LinkedList) AppendAll(elems []int) {
+func (l *LinkedListOf[T]) AppendAll(elems []T) {
 	for _, elem := range elems {
 		l.Append(elem)
 	}
 }
 
 // Add inserts an element into a given position.
-func (l *LinkedList) Add(elem int, index int) error {
+func (l *LinkedListOf[T]) Add(elem T, index int) error {
 	if index > l.size || index < 0 {
 		return errors.New("Index out of bounds: " + strconv.Itoa(index))
 	}
@@ -48,11 +56,13 @@ func (l *LinkedList) Add(elem int, index int) error {
 		l.Append(elem)
 		return nil
 	}
-	newNode := LinkedListNode{Value: elem}
+	newNode := LinkedListNodeOf[T]{Value: elem}
 	if index == 0 {
 		newNode.Next = l.head
+		l.head.Prev = &newNode
 		l.head = &newNode
 		l.size++
+		l.version++
 		return nil
 	}
 	if index == l.size {
@@ -60,6 +70,7 @@ func (l *LinkedList) Add(elem int, index int) error {
 		l.tail.Next = &newNode
 		l.tail = &newNode
 		l.size++
+		l.version++
 		return nil
 	}
 
@@ -70,16 +81,19 @@ func (l *LinkedList) Add(elem int, index int) error {
 		position++
 	}
 	newNode.Next = currentNode.Next
+	newNode.Next.Prev = &newNode
 	currentNode.Next = &newNode
 	newNode.Prev = currentNode
 	l.size++
+	l.version++
 	return nil
 }
 
 // Get returns the element at a given index.
-func (l *LinkedList) Get(index int) (int, error) {
+func (l *LinkedListOf[T]) Get(index int) (T, error) {
 	if index >= l.size || index < 0 {
-		return 0, errors.New("Index out of bounds: " + strconv.Itoa(index))
+		var zero T
+		return zero, errors.New("Index out of bounds: " + strconv.Itoa(index))
 	}
 	position := 0
 	currentNode := l.head
@@ -91,7 +105,7 @@ func (l *LinkedList) Get(index int) (int, error) {
 }
 
 // Remove deletes the first occurenct of an element by value.
-func (l *LinkedList) Remove(value int) error {
+func (l *LinkedListOf[T]) Remove(value T) error {
 	currentNode := l.head
 	for currentNode != nil && currentNode.Value != value {
 		currentNode = currentNode.Next
@@ -101,6 +115,8 @@ func (l *LinkedList) Remove(value int) error {
 			l.head = l.head.Next
 			if l.head != nil {
 				l.head.Prev = nil
+			} else {
+				l.tail = nil
 			}
 		} else if currentNode == l.tail {
 			l.tail = l.tail.Prev
@@ -112,15 +128,17 @@ func (l *LinkedList) Remove(value int) error {
 			currentNode.Next.Prev = currentNode.Prev
 		}
 		l.size--
+		l.version++
 		return nil
 	}
-	return errors.New("Value not found: " + strconv.Itoa(value))
+	return errors.New("Value not found: " + fmt.Sprint(value))
 }
 
 // RemoveAt deletes and returns an element by index.
-func (l *LinkedList) RemoveAt(index int) (int, error) {
+func (l *LinkedListOf[T]) RemoveAt(index int) (T, error) {
 	if index >= l.size || index < 0 {
-		return 0, errors.New("Index out of bounds: " + strconv.Itoa(index))
+		var zero T
+		return zero, errors.New("Index out of bounds: " + strconv.Itoa(index))
 	}
 	if l.size == 1 {
 		value := l.head.Value
@@ -132,6 +150,7 @@ func (l *LinkedList) RemoveAt(index int) (int, error) {
 		l.head = l.head.Next
 		l.head.Prev = nil
 		l.size--
+		l.version++
 		return value, nil
 	}
 	if index == l.size-1 {
@@ -139,6 +158,7 @@ func (l *LinkedList) RemoveAt(index int) (int, error) {
 		l.tail = l.tail.Prev
 		l.tail.Next = nil
 		l.size--
+		l.version++
 		return value, nil
 	}
 
@@ -152,12 +172,13 @@ func (l *LinkedList) RemoveAt(index int) (int, error) {
 	currentNode.Prev.Next = currentNode.Next
 	currentNode.Next.Prev = currentNode.Prev
 	l.size--
+	l.version++
 	return value, nil
 }
 
 // RemoveAll deletes all elements that satisfy a given predicate.
-func (l *LinkedList) RemoveAll(predicate func(int) bool) {
-	l.ForEach(func(item int, _ int) {
+func (l *LinkedListOf[T]) RemoveAll(predicate func(T) bool) {
+	l.ForEach(func(item T, _ int) {
 		if predicate(item) {
 			l.Remove(item)
 		}
@@ -165,7 +186,7 @@ func (l *LinkedList) RemoveAll(predicate func(int) bool) {
 }
 
 // ForEach applies a function to each element in the list.
-func (l *LinkedList) ForEach(fn func(int, int)) {
+func (l *LinkedListOf[T]) ForEach(fn func(T, int)) {
 	currentNode := l.head
 	index := 0
 	for currentNode != nil {
@@ -176,7 +197,7 @@ func (l *LinkedList) ForEach(fn func(int, int)) {
 }
 
 // Find returns the first element to satisfy the predicate.
-func (l *LinkedList) Find(predicate func(int, int) bool) (int, error) {
+func (l *LinkedListOf[T]) Find(predicate func(T, int) bool) (T, error) {
 	currentNode := l.head
 	index := 0
 	for currentNode != nil {
@@ -185,11 +206,12 @@ func (l *LinkedList) Find(predicate func(int, int) bool) (int, error) {
 		}
 		currentNode = currentNode.Next
 	}
-	return 0, errors.New("No elements found")
+	var zero T
+	return zero, errors.New("No elements found")
 }
 
 // IndexOf returns the index of an element, -1 if not found.
-func (l *LinkedList) IndexOf(elem int) int {
+func (l *LinkedListOf[T]) IndexOf(elem T) int {
 	currentNode := l.head
 	index := 0
 	for currentNode != nil {
@@ -203,13 +225,13 @@ func (l *LinkedList) IndexOf(elem int) int {
 }
 
 // Contains returns true if the element is present in the list.
-func (l *LinkedList) Contains(elem int) bool {
+func (l *LinkedListOf[T]) Contains(elem T) bool {
 	return l.IndexOf(elem) > -1
 }
 
 // ToArray returns a slice with the contents of the list.
-func (l *LinkedList) ToArray() []int {
-	result := make([]int, 0)
+func (l *LinkedListOf[T]) ToArray() []T {
+	result := make([]T, 0)
 	currentNode := l.head
 	for currentNode != nil {
 		result = append(result, currentNode.Value)
@@ -218,29 +240,78 @@ func (l *LinkedList) ToArray() []int {
 	return result
 }
 
+// All returns an iterator over the indices and elements of the list, from head to tail.
+func (l *LinkedListOf[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		version := l.version
+		index := 0
+		for currentNode := l.head; currentNode != nil; currentNode = currentNode.Next {
+			if !yield(index, currentNode.Value) {
+				return
+			}
+			checkVersion(version, l.version)
+			index++
+		}
+	}
+}
+
+// Values returns an iterator over the elements of the list, from head to tail.
+func (l *LinkedListOf[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, value := range l.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the indices and elements of the list, from tail to head.
+func (l *LinkedListOf[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		version := l.version
+		index := l.size - 1
+		for currentNode := l.tail; currentNode != nil; currentNode = currentNode.Prev {
+			if !yield(index, currentNode.Value) {
+				return
+			}
+			checkVersion(version, l.version)
+			index--
+		}
+	}
+}
+
 // Size returns the number of elements.
-func (l *LinkedList) Size() int {
+func (l *LinkedListOf[T]) Size() int {
 	return l.size
 }
 
 // IsEmpty returns true if the list is empty.
-func (l *LinkedList) IsEmpty() bool {
+func (l *LinkedListOf[T]) IsEmpty() bool {
 	return l.size == 0
 }
 
 // Clear removes all elements.
-func (l *LinkedList) Clear() {
+func (l *LinkedListOf[T]) Clear() {
 	l.head = nil
 	l.tail = nil
 	l.size = 0
+	l.version++
 }
 
 // Head returns the head of the linked list.
-func (l *LinkedList) Head() *LinkedListNode {
+func (l *LinkedListOf[T]) Head() *LinkedListNodeOf[T] {
 	return l.head
 }
 
 // SetHead sets the head of a linked list. Used for testing purposes.
-func (l *LinkedList) SetHead(head *LinkedListNode) {
+func (l *LinkedListOf[T]) SetHead(head *LinkedListNodeOf[T]) {
 	l.head = head
+	l.version++
 }
+
+// LinkedListNode represents a node in a linked list of ints.
+type LinkedListNode = LinkedListNodeOf[int]
+
+// LinkedList is doubly linked and holds ints.
+type LinkedList = LinkedListOf[int]
@@ -0,0 +1,296 @@
+package structures
+
+import (
+	"errors"
+	"math"
+)
+
+// FlowResult is a maximum flow through a graph whose edge weights are capacities, and the minimum cut it saturates.
+type FlowResult struct {
+	// Value is the total flow leaving the source.
+	Value int
+	// Cost is the total cost of the flow, only set by MinCostMaxFlow.
+	Cost int
+	// Flows maps every edge of the graph to the flow through it.
+	Flows map[*Edge]int
+	// SourceSide and SinkSide partition the vertices into a minimum cut, each in insertion order.
+	SourceSide []string
+	SinkSide   []string
+	// CutEdges are the edges from the source side to the sink side, their capacities add up to the flow value.
+	CutEdges []*Edge
+}
+
+// Residual network of a graph, arc 2k is edge k of the graph and arc 2k + 1 is its reverse.
+type flowNetwork struct {
+	vertices  []string
+	index     map[string]int
+	edges     []*Edge
+	arcs      []flowArc
+	adjacency [][]int
+	source    int
+	sink      int
+}
+
+type flowArc struct {
+	to       int
+	capacity int
+	flow     int
+	cost     int
+}
+
+func (a *flowArc) residual() int {
+	return a.capacity - a.flow
+}
+
+func newFlowNetwork(g DirectedWeightedGraph, source string, sink string, cost func(edge *Edge) int) (*flowNetwork, error) {
+	for _, vertex := range []string{source, sink} {
+		if g.getVertex(vertex) == nil {
+			return nil, errors.New("Vertex does not exist: " + vertex)
+		}
+	}
+	if source == sink {
+		return nil, errors.New("Source and sink must be different: " + source)
+	}
+	network := &flowNetwork{index: make(map[string]int)}
+	for vertex := range g.Vertices() {
+		network.index[vertex] = len(network.vertices)
+		network.vertices = append(network.vertices, vertex)
+	}
+	network.adjacency = make([][]int, len(network.vertices))
+	for edge := range g.Edges() {
+		if edge.Weight() < 0 {
+			return nil, errors.New("Capacities must be non-negative: " + edge.From() + "->" + edge.To())
+		}
+		from, to := network.index[edge.From()], network.index[edge.To()]
+		edgeCost := 0
+		if cost != nil {
+			edgeCost = cost(edge)
+		}
+		network.adjacency[from] = append(network.adjacency[from], len(network.arcs))
+		network.arcs = append(network.arcs, flowArc{to: to, capacity: edge.Weight(), cost: edgeCost})
+		network.adjacency[to] = append(network.adjacency[to], len(network.arcs))
+		network.arcs = append(network.arcs, flowArc{to: from, cost: -edgeCost})
+		network.edges = append(network.edges, edge)
+	}
+	network.source, network.sink = network.index[source], network.index[sink]
+	return network, nil
+}
+
+// Pushes flow along an arc, the reverse arc gains the same amount of residual capacity.
+func (n *flowNetwork) push(arc int, amount int) {
+	n.arcs[arc].flow += amount
+	n.arcs[arc^1].flow -= amount
+}
+
+// Returns the arcs of a shortest augmenting path found by breadth first search, nil if the sink is unreachable.
+func (n *flowNetwork) augmentingPath() []int {
+	// Arc used to reach each vertex, -1 if not reached.
+	parent := make([]int, len(n.vertices))
+	for i := range parent {
+		parent[i] = -1
+	}
+	queue := []int{n.source}
+	for len(queue) > 0 && parent[n.sink] == -1 {
+		vertex := queue[0]
+		queue = queue[1:]
+		for _, arc := range n.adjacency[vertex] {
+			to := n.arcs[arc].to
+			if n.arcs[arc].residual() > 0 && to != n.source && parent[to] == -1 {
+				parent[to] = arc
+				queue = append(queue, to)
+			}
+		}
+	}
+	return n.pathTo(parent)
+}
+
+// Follows the arcs used to reach each vertex back from the sink, nil if the sink was not reached.
+func (n *flowNetwork) pathTo(parent []int) []int {
+	if parent[n.sink] == -1 {
+		return nil
+	}
+	path := make([]int, 0)
+	for vertex := n.sink; vertex != n.source; vertex = n.arcs[parent[vertex]^1].to {
+		path = append(path, parent[vertex])
+	}
+	return path
+}
+
+// Returns the smallest residual capacity along a path.
+func (n *flowNetwork) bottleneck(path []int) int {
+	amount := math.MaxInt
+	for _, arc := range path {
+		amount = min(amount, n.arcs[arc].residual())
+	}
+	return amount
+}
+
+// Builds the result once no augmenting path remains.
+// The vertices still reachable from the source through residual arcs form the source side of a minimum cut.
+func (n *flowNetwork) result() *FlowResult {
+	reachable := make([]bool, len(n.vertices))
+	reachable[n.source] = true
+	stack := []int{n.source}
+	for len(stack) > 0 {
+		vertex := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, arc := range n.adjacency[vertex] {
+			to := n.arcs[arc].to
+			if n.arcs[arc].residual() > 0 && !reachable[to] {
+				reachable[to] = true
+				stack = append(stack, to)
+			}
+		}
+	}
+
+	result := &FlowResult{Flows: make(map[*Edge]int), SourceSide: make([]string, 0), SinkSide: make([]string, 0), CutEdges: make([]*Edge, 0)}
+	for i, vertex := range n.vertices {
+		if reachable[i] {
+			result.SourceSide = append(result.SourceSide, vertex)
+		} else {
+			result.SinkSide = append(result.SinkSide, vertex)
+		}
+	}
+	for k, edge := range n.edges {
+		arc := n.arcs[2*k]
+		result.Flows[edge] = arc.flow
+		result.Cost += arc.flow * arc.cost
+		if arc.to == n.source {
+			result.Value -= arc.flow
+		} else if n.arcs[2*k+1].to == n.source {
+			result.Value += arc.flow
+		}
+		if reachable[n.arcs[2*k+1].to] && !reachable[arc.to] {
+			result.CutEdges = append(result.CutEdges, edge)
+		}
+	}
+	return result
+}
+
+// EdmondsKarp returns a maximum flow from source to sink using the Edmonds-Karp algorithm, with edge weights as capacities.
+// Time: O(VE^2).
+func EdmondsKarp(graph Graph, source string, sink string) (*FlowResult, error) {
+	g := graph.directed()
+	network, err := newFlowNetwork(g, source, sink, nil)
+	if err != nil {
+		return nil, err
+	}
+	for path := network.augmentingPath(); path != nil; path = network.augmentingPath() {
+		amount := network.bottleneck(path)
+		for _, arc := range path {
+			network.push(arc, amount)
+		}
+	}
+	return network.result(), nil
+}
+
+// Dinic returns a maximum flow from source to sink using Dinic's algorithm, with edge weights as capacities.
+// Time: O(V^2 E).
+func Dinic(graph Graph, source string, sink string) (*FlowResult, error) {
+	g := graph.directed()
+	network, err := newFlowNetwork(g, source, sink, nil)
+	if err != nil {
+		return nil, err
+	}
+	n := len(network.vertices)
+	for {
+		// Number of residual arcs on a shortest path from the source to each vertex, -1 if unreachable.
+		level := make([]int, n)
+		for i := range level {
+			level[i] = -1
+		}
+		level[network.source] = 0
+		queue := []int{network.source}
+		for len(queue) > 0 {
+			vertex := queue[0]
+			queue = queue[1:]
+			for _, arc := range network.adjacency[vertex] {
+				to := network.arcs[arc].to
+				if network.arcs[arc].residual() > 0 && level[to] == -1 {
+					level[to] = level[vertex] + 1
+					queue = append(queue, to)
+				}
+			}
+		}
+		if level[network.sink] == -1 {
+			return network.result(), nil
+		}
+
+		// Position of the next arc to try from each vertex, arcs that cannot reach the sink are skipped for the rest of the phase.
+		next := make([]int, n)
+		var augment func(vertex int, limit int) int
+		augment = func(vertex int, limit int) int {
+			if vertex == network.sink {
+				return limit
+			}
+			for ; next[vertex] < len(network.adjacency[vertex]); next[vertex]++ {
+				arc := network.adjacency[vertex][next[vertex]]
+				to := network.arcs[arc].to
+				if network.arcs[arc].residual() <= 0 || level[to] != level[vertex]+1 {
+					continue
+				}
+				if pushed := augment(to, min(limit, network.arcs[arc].residual())); pushed > 0 {
+					network.push(arc, pushed)
+					return pushed
+				}
+			}
+			return 0
+		}
+		for augment(network.source, math.MaxInt) > 0 {
+		}
+	}
+}
+
+// MinCostMaxFlow returns a maximum flow from source to sink of the lowest total cost, with edge weights as capacities.
+// The cost of sending one unit of flow through an edge is given by the cost function.
+// Cheapest paths are found with Bellman-Ford, so costs may be negative as long as no cycle has a negative total cost.
+// Time: O(FVE) where F is the flow value.
+func MinCostMaxFlow(graph Graph, source string, sink string, cost func(edge *Edge) int) (*FlowResult, error) {
+	g := graph.directed()
+	network, err := newFlowNetwork(g, source, sink, cost)
+	if err != nil {
+		return nil, err
+	}
+	n := len(network.vertices)
+	for {
+		distance := make([]int, n)
+		parent := make([]int, n)
+		for i := range n {
+			distance[i] = math.MaxInt
+			parent[i] = -1
+		}
+		distance[network.source] = 0
+		for pass := 0; ; pass++ {
+			relaxed := false
+			for vertex := range n {
+				if distance[vertex] == math.MaxInt {
+					continue
+				}
+				for _, arc := range network.adjacency[vertex] {
+					to := network.arcs[arc].to
+					newDistance := distance[vertex] + network.arcs[arc].cost
+					if network.arcs[arc].residual() > 0 && newDistance < distance[to] {
+						distance[to] = newDistance
+						parent[to] = arc
+						relaxed = true
+					}
+				}
+			}
+			if !relaxed {
+				break
+			}
+			// Shortest paths have at most n - 1 arcs, so a relaxation on the n-th pass means a cycle of negative cost.
+			if pass == n-1 {
+				return nil, errors.New("Residual network contains a cycle of negative cost")
+			}
+		}
+		path := network.pathTo(parent)
+		if path == nil {
+			return network.result(), nil
+		}
+		amount := network.bottleneck(path)
+		for _, arc := range path {
+			network.push(arc, amount)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package structures
+
+import (
+	"errors"
+	"slices"
+)
+
+// DegreeDistribution counts the vertices of a graph with each in-degree and out-degree.
+type DegreeDistribution struct {
+	// In maps each in-degree to the number of vertices with it, degrees no vertex has are left out.
+	In map[int]int
+	// Out maps each out-degree to the number of vertices with it, degrees no vertex has are left out.
+	Out map[int]int
+}
+
+// InDegree returns the number of edges ending at a vertex, a self loop counts once. Time: O(E).
+func InDegree(graph Graph, value string) (int, error) {
+	g := graph.directed()
+	if g.getVertex(value) == nil {
+		return 0, errors.New("Vertex does not exist: " + value)
+	}
+	degree := 0
+	for edge := range g.Edges() {
+		if edge.To() == value {
+			degree++
+		}
+	}
+	return degree, nil
+}
+
+// OutDegree returns the number of edges leaving a vertex, a self loop counts once.
+func OutDegree(graph Graph, value string) (int, error) {
+	g := graph.directed()
+	vertex := g.getVertex(value)
+	if vertex == nil {
+		return 0, errors.New("Vertex does not exist: " + value)
+	}
+	return len(g.getOutgoingEdges(vertex)), nil
+}
+
+// Degrees returns the distribution of degrees, the number of vertices with each in-degree and each out-degree.
+func Degrees(graph Graph) *DegreeDistribution {
+	g := graph.directed()
+	in := make(map[string]int)
+	for edge := range g.Edges() {
+		in[edge.To()]++
+	}
+	result := &DegreeDistribution{In: make(map[int]int), Out: make(map[int]int)}
+	for vertex := range g.Vertices() {
+		result.In[in[vertex]]++
+		result.Out[len(g.getOutgoingEdges(g.getVertex(vertex)))]++
+	}
+	return result
+}
+
+// Density returns the fraction of ordered pairs of distinct vertices joined by at least one edge.
+// Self loops are ignored and parallel edges count once, so the density is between 0 and 1.
+func Density(graph Graph) float64 {
+	g := graph.directed()
+	n := g.NumberOfVertices()
+	if n < 2 {
+		return 0
+	}
+	joined := make(map[[2]string]bool)
+	for edge := range g.Edges() {
+		if edge.From() != edge.To() {
+			joined[edge.vertices] = true
+		}
+	}
+	return float64(len(joined)) / float64(n*(n-1))
+}
+
+// Eccentricity returns the greatest shortest path distance from a vertex to any other vertex.
+// Returns an error if some vertex cannot be reached or an edge weight is negative.
+func Eccentricity(graph Graph, value string) (int, error) {
+	g := graph.directed()
+	if err := checkDijkstra(g, value); err != nil {
+		return 0, err
+	}
+	c := g.ToCSR()
+	eccentricity, ok := c.eccentricity(c.ids[value])
+	if !ok {
+		return 0, errors.New("Not every vertex can be reached from: " + value)
+	}
+	return eccentricity, nil
+}
+
+// Greatest distance from a vertex, false if it cannot reach every vertex.
+func (g *CSRGraph) eccentricity(source int) (int, bool) {
+	distance, previous := g.dijkstra(source)
+	eccentricity := 0
+	for vertex, d := range distance {
+		if previous[vertex] == -1 {
+			return 0, false
+		}
+		eccentricity = max(eccentricity, d)
+	}
+	return eccentricity, true
+}
+
+// Eccentricities of every vertex, an error if the graph is empty or not strongly connected. Time: O(V (V + E) log V).
+func eccentricitiesHelper(g DirectedWeightedGraph) ([]int, error) {
+	if err := checkDijkstra(g); err != nil {
+		return nil, err
+	}
+	if g.IsEmpty() {
+		return nil, errors.New("Graph is empty")
+	}
+	c := g.ToCSR()
+	result := make([]int, len(c.names))
+	for i := range c.names {
+		eccentricity, ok := c.eccentricity(i)
+		if !ok {
+			return nil, errors.New("Graph is not strongly connected, not every vertex can be reached from: " + c.names[i])
+		}
+		result[i] = eccentricity
+	}
+	return result, nil
+}
+
+// Diameter returns the greatest eccentricity of any vertex.
+// Returns an error if the graph is empty or not strongly connected, or an edge weight is negative.
+func Diameter(graph Graph) (int, error) {
+	g := graph.directed()
+	eccentricities, err := eccentricitiesHelper(g)
+	if err != nil {
+		return 0, err
+	}
+	return slices.Max(eccentricities), nil
+}
+
+// Radius returns the smallest eccentricity of any vertex.
+// Returns an error if the graph is empty or not strongly connected, or an edge weight is negative.
+func Radius(graph Graph) (int, error) {
+	g := graph.directed()
+	eccentricities, err := eccentricitiesHelper(g)
+	if err != nil {
+		return 0, err
+	}
+	return slices.Min(eccentricities), nil
+}
+
+// AverageClustering returns the average clustering coefficient, with edges treated as undirected
+// and self loops and parallel edges ignored. The coefficient of a vertex is the fraction of pairs of its neighbours
+// that are neighbours of each other, 0 for vertices with fewer than two neighbours.
+func AverageClustering(graph Graph) float64 {
+	g := graph.directed()
+	view := newUndirectedView(g)
+	n := len(view.vertices)
+	if n == 0 {
+		return 0
+	}
+	neighbours := make([]map[int]bool, n)
+	for vertex := range n {
+		neighbours[vertex] = make(map[int]bool)
+		for _, i := range view.incident[vertex] {
+			if other := view.other(i, vertex); other != vertex {
+				neighbours[vertex][other] = true
+			}
+		}
+	}
+	total := 0.0
+	for vertex := range n {
+		k := len(neighbours[vertex])
+		if k < 2 {
+			continue
+		}
+		links := 0
+		for a := range neighbours[vertex] {
+			for b := range neighbours[vertex] {
+				if a < b && neighbours[a][b] {
+					links++
+				}
+			}
+		}
+		total += float64(2*links) / float64(k*(k-1))
+	}
+	return total / float64(n)
+}
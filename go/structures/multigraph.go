@@ -0,0 +1,42 @@
+package structures
+
+import (
+	"errors"
+)
+
+// ParallelEdgePolicy decides what AddEdge does when an edge already joins the same vertices in the same direction.
+type ParallelEdgePolicy int
+
+const (
+	// AllowParallelEdges adds the new edge alongside the existing ones.
+	AllowParallelEdges ParallelEdgePolicy = iota
+	// RejectParallelEdges returns an error and keeps the existing edge.
+	RejectParallelEdges
+	// ReplaceParallelEdges removes the existing edges before adding the new one.
+	ReplaceParallelEdges
+)
+
+// Generic parallel edge check, returns the existing edges that the new edge replaces.
+// The name of the edge is only used in the error.
+func parallelEdgesHelper(existing []*Edge, policy ParallelEdgePolicy, name string) ([]*Edge, error) {
+	if len(existing) == 0 {
+		return nil, nil
+	}
+	switch policy {
+	case RejectParallelEdges:
+		return nil, errors.New("Edge already exists in graph: " + name)
+	case ReplaceParallelEdges:
+		return existing, nil
+	}
+	return nil, nil
+}
+
+// Generic edge lookup by ID, nil if no edge has the ID.
+func getEdgeByIDHelper(edges []*Edge, id int) *Edge {
+	for _, edge := range edges {
+		if edge.id == id {
+			return edge
+		}
+	}
+	return nil
+}
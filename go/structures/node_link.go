@@ -0,0 +1,193 @@
+package structures
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// JSON node-link format, as read and written by NetworkX.
+type nodeLinkGraph struct {
+	Directed   bool           `json:"directed"`
+	Multigraph bool           `json:"multigraph"`
+	Nodes      []nodeLinkNode `json:"nodes"`
+	Links      []nodeLinkLink `json:"links"`
+}
+
+type nodeLinkNode struct {
+	ID *string `json:"id"`
+}
+
+type nodeLinkLink struct {
+	Source *string `json:"source"`
+	Target *string `json:"target"`
+	Weight *int    `json:"weight,omitempty"`
+}
+
+// EncodeJSON writes the graph in the JSON node-link format used by NetworkX.
+func EncodeJSON(g Graph, w io.Writer) error {
+	graph := nodeLinkGraph{Directed: true, Multigraph: true, Nodes: make([]nodeLinkNode, 0), Links: make([]nodeLinkLink, 0)}
+	for vertex := range g.Vertices() {
+		graph.Nodes = append(graph.Nodes, nodeLinkNode{ID: &vertex})
+	}
+	for edge := range g.Edges() {
+		graph.Links = append(graph.Links, nodeLinkLink{Source: &edge.vertices[0], Target: &edge.vertices[1], Weight: &edge.weight})
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(graph)
+}
+
+// DecodeJSON replaces the graph with one read from the JSON node-link format, links without a weight have weight 1.
+// The graph is unchanged if the input cannot be decoded.
+// Returns a ParseError with the position of any problem in the input.
+// Links may also be given as edges.
+func DecodeJSON(g WeightedGraph, r io.Reader) error {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	decoded, err := newDecoded(g)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	// Offset of the start of the current value.
+	offset := 0
+	fail := func(message string) error {
+		line, column := inputPosition(input, offset)
+		return &ParseError{Format: "JSON", Line: line, Column: column, Message: message}
+	}
+	// Errors from the decoder, placed where the decoder found them.
+	failDecoding := func(err error) error {
+		var syntaxError *json.SyntaxError
+		var typeError *json.UnmarshalTypeError
+		switch {
+		case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || err.Error() == "unexpected end of JSON input":
+			offset = len(input)
+			return fail("Unexpected end of input")
+		case errors.As(err, &syntaxError):
+			offset = max(int(syntaxError.Offset)-1, 0)
+		case errors.As(err, &typeError):
+			return fail("Field " + typeError.Field + " should be " + typeError.Type.String() + ", got " + typeError.Value)
+		}
+		return fail(err.Error())
+	}
+	// Moves the offset past whitespace and separators to the start of the next value.
+	nextValue := func() {
+		offset = int(decoder.InputOffset())
+		for offset < len(input) && bytes.IndexByte([]byte(" \t\r\n,:"), input[offset]) != -1 {
+			offset++
+		}
+	}
+	expect := func(delimiter json.Delim) error {
+		nextValue()
+		token, err := decoder.Token()
+		if err != nil {
+			return failDecoding(err)
+		}
+		if token != delimiter {
+			return fail("Expected " + delimiter.String())
+		}
+		return nil
+	}
+
+	if err := expect('{'); err != nil {
+		return err
+	}
+	type positionedLink struct {
+		link   nodeLinkLink
+		offset int
+	}
+	links := make([]positionedLink, 0)
+	for decoder.More() {
+		nextValue()
+		token, err := decoder.Token()
+		if err != nil {
+			return failDecoding(err)
+		}
+		switch token {
+		case "directed":
+			nextValue()
+			var directed bool
+			if err := decoder.Decode(&directed); err != nil {
+				return failDecoding(err)
+			}
+			if !directed {
+				return fail("Undirected graphs are not supported")
+			}
+		case "nodes":
+			if err := expect('['); err != nil {
+				return err
+			}
+			for decoder.More() {
+				nextValue()
+				var node nodeLinkNode
+				if err := decoder.Decode(&node); err != nil {
+					return failDecoding(err)
+				}
+				if node.ID == nil {
+					return fail("Node has no id")
+				}
+				addDecodedVertex(decoded, *node.ID)
+			}
+			if err := expect(']'); err != nil {
+				return err
+			}
+		case "links", "edges":
+			if err := expect('['); err != nil {
+				return err
+			}
+			for decoder.More() {
+				nextValue()
+				var link nodeLinkLink
+				if err := decoder.Decode(&link); err != nil {
+					return failDecoding(err)
+				}
+				if link.Source == nil || link.Target == nil {
+					return fail("Link needs a source and a target")
+				}
+				links = append(links, positionedLink{link: link, offset: offset})
+			}
+			if err := expect(']'); err != nil {
+				return err
+			}
+		default:
+			nextValue()
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return failDecoding(err)
+			}
+		}
+	}
+	if err := expect('}'); err != nil {
+		return err
+	}
+	offset = int(decoder.InputOffset())
+	if len(bytes.TrimSpace(input[offset:])) > 0 {
+		offset = len(input) - len(bytes.TrimLeft(input[offset:], " \t\r\n"))
+		return fail("Expected end of input")
+	}
+
+	// Links are added once every node is known, since they may come first.
+	for _, positioned := range links {
+		link := positioned.link
+		offset = positioned.offset
+		for _, vertex := range []string{*link.Source, *link.Target} {
+			if decoded.getVertex(vertex) == nil {
+				return fail("Link refers to an unknown node: " + vertex)
+			}
+		}
+		weight := 1
+		if link.Weight != nil {
+			weight = *link.Weight
+		}
+		if _, err := decoded.AddEdge(*link.Source, *link.Target, weight); err != nil {
+			return fail(err.Error())
+		}
+	}
+	replaceDecoded(g, decoded)
+	return nil
+}
@@ -0,0 +1,169 @@
+package structures
+
+import (
+	"errors"
+	"slices"
+)
+
+// PathTreeOf holds the shortest paths from a source vertex to every reachable vertex.
+// It is a snapshot of the graph at the time it was computed and is not affected by later changes to the graph.
+type PathTreeOf[W Weight] struct {
+	source   string
+	distance map[string]W
+	previous map[string]string
+	// Version of the graph the paths were computed from.
+	version int
+}
+
+// PathTree holds shortest paths with integer weights.
+type PathTree = PathTreeOf[int]
+
+// Source returns the vertex the paths start at.
+func (p *PathTreeOf[W]) Source() string {
+	return p.source
+}
+
+// Reachable returns true if there is a path from the source to the target.
+func (p *PathTreeOf[W]) Reachable(target string) bool {
+	_, ok := p.distance[target]
+	return ok
+}
+
+// DistanceTo returns the length of the shortest path from the source to the target.
+func (p *PathTreeOf[W]) DistanceTo(target string) (W, error) {
+	distance, ok := p.distance[target]
+	if !ok {
+		return 0, errors.New("Vertex cannot be reached: " + target)
+	}
+	return distance, nil
+}
+
+// PathTo returns the vertices on the shortest path from the source to the target, with their distances from the source.
+func (p *PathTreeOf[W]) PathTo(target string) ([]*DijkstraResultOf[W], error) {
+	if !p.Reachable(target) {
+		return nil, errors.New("Vertex cannot be reached: " + target)
+	}
+	result := []*DijkstraResultOf[W]{{Value: target, Distance: p.distance[target]}}
+	for current := target; current != p.source; {
+		current = p.previous[current]
+		result = append(result, &DijkstraResultOf[W]{Value: current, Distance: p.distance[current]})
+	}
+	slices.Reverse(result)
+	return result, nil
+}
+
+// ShortestPaths finds the shortest paths from the source vertex to all reachable vertices using Dijkstra's algorithm.
+// Returns an error if any edge weight is negative, use BellmanFord instead.
+func ShortestPaths(graph Graph, source string) (*PathTree, error) {
+	g := graph.directed()
+	if err := checkDijkstra(g, source); err != nil {
+		return nil, err
+	}
+	c := g.ToCSR()
+	distance, previous := c.dijkstra(c.ids[source])
+	return newPathTree(c, c.ids[source], distance, previous, g.getVersion()), nil
+}
+
+// ShortestPathsBy finds the shortest paths from the source vertex using Dijkstra's algorithm,
+// with the weights read from a named numeric edge attribute, or the float weights if the name is empty.
+// Returns an error if any edge lacks the attribute or has a negative weight.
+func ShortestPathsBy(graph Graph, source string, attribute string) (*PathTreeOf[float64], error) {
+	g := graph.directed()
+	if g.getVertex(source) == nil {
+		return nil, errors.New("Vertex does not exist: " + source)
+	}
+	c := g.ToCSR()
+	weights := make([]float64, len(c.outgoing))
+	for i, edge := range c.outgoing {
+		weight, err := edge.WeightBy(attribute)
+		if err != nil {
+			return nil, err
+		}
+		// NaN is not equal to itself and cannot be compared with other distances.
+		if weight < 0 || weight != weight {
+			return nil, errors.New("Dijkstra does not support negative edge weights: " + edge.From() + "->" + edge.To())
+		}
+		weights[i] = weight
+	}
+	distance, previous := dijkstraCore(c, c.ids[source], func(i int) float64 { return weights[i] })
+	return newPathTree(c, c.ids[source], distance, previous, g.getVersion()), nil
+}
+
+// Returns an error if any of the vertices does not exist or any edge weight is negative.
+func checkDijkstra(g DirectedWeightedGraph, vertices ...string) error {
+	for _, vertex := range vertices {
+		if g.getVertex(vertex) == nil {
+			return errors.New("Vertex does not exist: " + vertex)
+		}
+	}
+	for edge := range g.Edges() {
+		if edge.weight < 0 {
+			return errors.New("Dijkstra does not support negative edge weights, use BellmanFord: " + edge.From() + "->" + edge.To())
+		}
+	}
+	return nil
+}
+
+// Runs Dijkstra's algorithm from a vertex ID over the flat arrays of a CSR graph, with the weight of the edge
+// at each index given by a function, which must return non-negative weights. Returns the distance to each vertex
+// and the vertex before it on its shortest path, which is the source itself for the source
+// and -1 for the vertices that cannot be reached.
+func dijkstraCore[W Weight](c *CSRGraph, source int, weightOf func(i int) W) ([]W, []int) {
+	n := len(c.names)
+	distance := make([]W, n)
+	previous := make([]int, n)
+	for i := range n {
+		previous[i] = -1
+	}
+	previous[source] = source
+	pq := NewPriorityQueue(func(vertex int) W { return distance[vertex] })
+	pq.Push(source)
+	for !pq.IsEmpty() {
+		vertex, _ := pq.Pop()
+		for i := c.offsets[vertex]; i < c.offsets[vertex+1]; i++ {
+			neighbour, newDistance := c.targets[i], distance[vertex]+weightOf(i)
+			if previous[neighbour] != -1 && distance[neighbour] <= newDistance {
+				continue
+			}
+			distance[neighbour], previous[neighbour] = newDistance, vertex
+			// Each vertex is queued at most once, an improved distance moves it forward in place.
+			if pq.Contains(neighbour) {
+				pq.DecreaseKey(neighbour)
+			} else {
+				pq.Push(neighbour)
+			}
+		}
+	}
+	return distance, previous
+}
+
+// Shortest paths from a vertex ID of a CSR graph, from the distances and previous vertices found by dijkstraCore.
+func newPathTree[W Weight](c *CSRGraph, source int, distance []W, previous []int, version int) *PathTreeOf[W] {
+	paths := &PathTreeOf[W]{
+		source:   c.names[source],
+		distance: make(map[string]W),
+		previous: make(map[string]string),
+		version:  version,
+	}
+	for vertex, name := range c.names {
+		if previous[vertex] == -1 {
+			continue
+		}
+		paths.distance[name] = distance[vertex]
+		if vertex != source {
+			paths.previous[name] = c.names[previous[vertex]]
+		}
+	}
+	return paths
+}
+
+// Generic GetShortestPath helper, uses the paths computed by the last call to Dijkstra.
+func getShortestPathHelper(g DirectedWeightedGraph, paths *PathTree, target string) ([]*DijkstraResult, error) {
+	if paths == nil {
+		return nil, errors.New("Dijkstra has not been run")
+	}
+	if paths.version != g.getVersion() {
+		return nil, errors.New("Graph was modified since Dijkstra was run")
+	}
+	return paths.PathTo(target)
+}
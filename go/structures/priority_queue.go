@@ -1,82 +1,113 @@
 package structures
 
-import "errors"
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
 
-// PriorityQueueNode represents a node in the priority queue.
-type PriorityQueueNode struct {
-	value *Vertex
-	next  *PriorityQueueNode
+// PriorityQueueOf holds distinct elements and dequeues the element with the lowest priority first.
+// Priorities are computed by the priority function when an element is pushed and cached until ChangePriority
+// or DecreaseKey is called, so the state the function reads may change while the element is queued.
+// Elements with equal priorities are dequeued in no particular order.
+type PriorityQueueOf[T comparable, P cmp.Ordered] struct {
+	priority func(elem T) P
+	heap     *BinaryHeapOf[*priorityQueueItem[T, P]]
+	handles  map[T]*HeapHandleOf[*priorityQueueItem[T, P]]
 }
 
-// PriorityQueue holds graph vertices and is meant to use with Dijkstra's algorithm.
-type PriorityQueue struct {
-	head *PriorityQueueNode
-	size int
-}
+// PriorityQueue is a priority queue with integer priorities.
+type PriorityQueue[T comparable] = PriorityQueueOf[T, int]
 
-// Enqueue adds an element to the queue.
-func (pq *PriorityQueue) Enqueue(value *Vertex) {
-	node := &PriorityQueueNode{value: value}
+// An element and its cached priority.
+type priorityQueueItem[T comparable, P cmp.Ordered] struct {
+	value    T
+	priority P
+}
 
-	if pq.head == nil {
-		pq.head = node
-		pq.size++
-		return
+// NewPriorityQueue returns an empty queue ordered by a priority function.
+func NewPriorityQueue[T comparable, P cmp.Ordered](priority func(elem T) P) *PriorityQueueOf[T, P] {
+	return &PriorityQueueOf[T, P]{
+		priority: priority,
+		heap: NewBinaryHeapFunc(MinHeap, func(a *priorityQueueItem[T, P], b *priorityQueueItem[T, P]) int {
+			return cmp.Compare(a.priority, b.priority)
+		}),
+		handles: make(map[T]*HeapHandleOf[*priorityQueueItem[T, P]]),
 	}
-	if pq.size == 1 {
-		if predicate(value, pq.head.value) <= 0 {
-			node.next = pq.head
-			pq.head = node
-		} else {
-			pq.head.next = node
-		}
-		pq.size++
-		return
-	}
-	if predicate(value, pq.head.value) <= 0 {
-		node.next = pq.head
-		pq.head = node
-		pq.size++
-		return
+}
+
+// Push adds an element to the queue.
+// Time: O(log n).
+func (pq *PriorityQueueOf[T, P]) Push(elem T) error {
+	if pq.Contains(elem) {
+		return errors.New("Element already exists in queue: " + fmt.Sprint(elem))
 	}
+	pq.handles[elem] = pq.heap.Insert(&priorityQueueItem[T, P]{value: elem, priority: pq.priority(elem)})
+	return nil
+}
 
-	previousNode := pq.head
-	currentNode := pq.head.next
-	for currentNode != nil {
-		if predicate(currentNode.value, value) <= 0 {
-			break
-		}
-		previousNode = currentNode
-		currentNode = currentNode.next
+// Pop removes and returns the element with the lowest priority.
+// Time: O(log n).
+func (pq *PriorityQueueOf[T, P]) Pop() (T, error) {
+	item, err := pq.heap.RemoveTop()
+	if err != nil {
+		var zero T
+		return zero, errors.New("Queue is empty")
 	}
-	after := previousNode.next
-	previousNode.next = node
-	node.next = after
-	pq.size++
+	delete(pq.handles, item.value)
+	return item.value, nil
 }
 
-// Dequeue removes and returns the front of the queue.
-func (pq *PriorityQueue) Dequeue() (*Vertex, error) {
-	if pq.head == nil {
-		return nil, errors.New("Queue is empty")
+// Peek returns the element with the lowest priority without removing it.
+func (pq *PriorityQueueOf[T, P]) Peek() (T, error) {
+	item, err := pq.heap.Top()
+	if err != nil {
+		var zero T
+		return zero, errors.New("Queue is empty")
 	}
-	value := pq.head.value
-	pq.head = pq.head.next
-	pq.size--
-	return value, nil
+	return item.value, nil
 }
 
-// IsEmpty returns true if the queue is empty.
-func (pq *PriorityQueue) IsEmpty() bool {
-	return pq.size == 0
+// ChangePriority recomputes the priority of a queued element, to be called after the state the priority function reads changes.
+// Time: O(log n).
+func (pq *PriorityQueueOf[T, P]) ChangePriority(elem T) error {
+	handle, ok := pq.handles[elem]
+	if !ok {
+		return errors.New("Element does not exist in queue: " + fmt.Sprint(elem))
+	}
+	item := handle.Value()
+	item.priority = pq.priority(elem)
+	return pq.heap.Update(handle, item)
 }
 
-func predicate(a *Vertex, b *Vertex) int {
-	if a.distance < b.distance {
-		return 1
+// DecreaseKey recomputes the priority of a queued element after it has decreased, moving the element forward in place.
+// Returns an error if the element is not queued or its priority increased, use ChangePriority for that instead.
+// Time: O(log n).
+func (pq *PriorityQueueOf[T, P]) DecreaseKey(elem T) error {
+	handle, ok := pq.handles[elem]
+	if !ok {
+		return errors.New("Element does not exist in queue: " + fmt.Sprint(elem))
 	}
-	if a.distance == b.distance {
-		return 0
+	priority := pq.priority(elem)
+	if priority > handle.Value().priority {
+		return errors.New("Priority of the element increased, use ChangePriority: " + fmt.Sprint(elem))
 	}
-	return -1
+	return pq.heap.DecreaseKey(handle, &priorityQueueItem[T, P]{value: elem, priority: priority})
+}
+
+// Contains returns true if the element is in the queue.
+// Time: O(1).
+func (pq *PriorityQueueOf[T, P]) Contains(elem T) bool {
+	_, ok := pq.handles[elem]
+	return ok
+}
+
+// Len returns the number of elements in the queue.
+func (pq *PriorityQueueOf[T, P]) Len() int {
+	return pq.heap.Size()
+}
+
+// IsEmpty returns true if the queue is empty.
+func (pq *PriorityQueueOf[T, P]) IsEmpty() bool {
+	return pq.heap.IsEmpty()
 }
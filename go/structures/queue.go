@@ -2,39 +2,40 @@ package structures
 
 import "errors"
 
-// Queue of ints.
-type Queue interface {
-	Peek() (int, error)
-	Enqueue(elem int)
-	Dequeue() (int, error)
+// QueueOf holds elements of any type.
+type QueueOf[T any] interface {
+	Peek() (T, error)
+	Enqueue(elem T)
+	Dequeue() (T, error)
 	Size() int
 	Clear()
 	IsEmpty() bool
 }
 
-// QueueNode represents a node in the linked queue.
-type QueueNode struct {
-	value int
-	next  *QueueNode
+// QueueNodeOf represents a node in the linked queue.
+type QueueNodeOf[T any] struct {
+	value T
+	next  *QueueNodeOf[T]
 }
 
-// LinkedQueue implements Queue using a linked list.
-type LinkedQueue struct {
-	head *QueueNode
+// LinkedQueueOf implements QueueOf using a linked list.
+type LinkedQueueOf[T any] struct {
+	head *QueueNodeOf[T]
 	size int
 }
 
 // Peek returns the element at the front of the queue.
-func (q *LinkedQueue) Peek() (int, error) {
+func (q *LinkedQueueOf[T]) Peek() (T, error) {
 	if q.IsEmpty() {
-		return 0, errors.New("Queue is empty")
+		var zero T
+		return zero, errors.New("Queue is empty")
 	}
 	return q.head.value, nil
 }
 
 // Enqueue adds a new element to the back of the queue.
-func (q *LinkedQueue) Enqueue(elem int) {
-	newNode := QueueNode{value: elem}
+func (q *LinkedQueueOf[T]) Enqueue(elem T) {
+	newNode := QueueNodeOf[T]{value: elem}
 	if q.IsEmpty() {
 		q.head = &newNode
 	} else {
@@ -48,10 +49,10 @@ func (q *LinkedQueue) Enqueue(elem int) {
 }
 
 // Dequeue removes and returns the element at the front of the queue.
-func (q *LinkedQueue) Dequeue() (int, error) {
+func (q *LinkedQueueOf[T]) Dequeue() (T, error) {
 	value, err := q.Peek()
 	if err != nil {
-		return 0, err
+		return value, err
 	}
 	q.head = q.head.next
 	q.size--
@@ -59,60 +60,73 @@ func (q *LinkedQueue) Dequeue() (int, error) {
 }
 
 // Size returns the number of elements in the queue.
-func (q *LinkedQueue) Size() int {
+func (q *LinkedQueueOf[T]) Size() int {
 	return q.size
 }
 
 // Clear removes all elements in the queue.
-func (q *LinkedQueue) Clear() {
+func (q *LinkedQueueOf[T]) Clear() {
 	q.head = nil
 	q.size = 0
 }
 
 // IsEmpty returns true when the queue is empty.
-func (q *LinkedQueue) IsEmpty() bool {
+func (q *LinkedQueueOf[T]) IsEmpty() bool {
 	return q.size == 0
 }
 
-// ArrayQueue implements Queue using an array.
-type ArrayQueue struct {
-	elems []int
+// ArrayQueueOf implements QueueOf using an array.
+type ArrayQueueOf[T any] struct {
+	elems []T
 }
 
 // Peek returns the element at the front of the queue.
-func (q *ArrayQueue) Peek() (int, error) {
+func (q *ArrayQueueOf[T]) Peek() (T, error) {
 	if q.IsEmpty() {
-		return 0, errors.New("Queue is empty")
+		var zero T
+		return zero, errors.New("Queue is empty")
 	}
 	return q.elems[0], nil
 }
 
 // Enqueue adds a new element to the back of the queue. The front of the slice represents the front of the queue.
-func (q *ArrayQueue) Enqueue(elem int) {
+func (q *ArrayQueueOf[T]) Enqueue(elem T) {
 	q.elems = append(q.elems, elem)
 }
 
 // Dequeue removes and returns the element at the front of the queue.
-func (q *ArrayQueue) Dequeue() (int, error) {
+func (q *ArrayQueueOf[T]) Dequeue() (T, error) {
 	value, err := q.Peek()
 	if err != nil {
-		return 0, err
+		return value, err
 	}
 	q.elems = q.elems[1:]
 	return value, nil
 }
 
 // Clear removes all elements in the queue.
-func (q *ArrayQueue) Clear() {
+func (q *ArrayQueueOf[T]) Clear() {
 	q.elems = nil
 }
 
 // Size returns the number of elements in the queue.
-func (q *ArrayQueue) Size() int {
+func (q *ArrayQueueOf[T]) Size() int {
 	return len(q.elems)
 }
 
 // IsEmpty returns true when the queue is empty.
-func (q *ArrayQueue) IsEmpty() bool {
+func (q *ArrayQueueOf[T]) IsEmpty() bool {
 	return q.Size() == 0
 }
+
+// Queue of ints.
+type Queue = QueueOf[int]
+
+// QueueNode represents a node in a linked queue of ints.
+type QueueNode = QueueNodeOf[int]
+
+// LinkedQueue implements Queue using a linked list.
+type LinkedQueue = LinkedQueueOf[int]
+
+// ArrayQueue implements Queue using an array.
+type ArrayQueue = ArrayQueueOf[int]
@@ -0,0 +1,377 @@
+package structures
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// RedBlackTreeOf is a self-balancing binary search tree.
+// Every node is red or black, the root is black, red nodes have black children and every path from a node
+// to a nil child contains the same number of black nodes, so all operations take O(log n) time.
+type RedBlackTreeOf[K cmp.Ordered, V any] struct {
+	root *BinaryTreeNodeOf[K, V]
+	size int
+	// Incremented on every insertion or deletion, used to detect modification during iteration.
+	version int
+}
+
+// RedBlackTree is a red-black tree of ints.
+type RedBlackTree = RedBlackTreeOf[int, struct{}]
+
+// Insert adds a new key to the tree with an empty value.
+func (t *RedBlackTreeOf[K, V]) Insert(elem K) error {
+	var value V
+	return t.InsertValue(elem, value)
+}
+
+// InsertValue adds a new key and its value to the tree.
+func (t *RedBlackTreeOf[K, V]) InsertValue(elem K, value V) error {
+	var parent *BinaryTreeNodeOf[K, V]
+	currentNode := t.root
+	for currentNode != nil {
+		if elem == currentNode.key {
+			return errors.New("Value already exists: " + fmt.Sprint(elem))
+		}
+		parent = currentNode
+		if elem < currentNode.key {
+			currentNode = currentNode.left
+		} else {
+			currentNode = currentNode.right
+		}
+	}
+	toInsert := &BinaryTreeNodeOf[K, V]{key: elem, value: value, parent: parent, size: 1, red: true}
+	if parent == nil {
+		t.root = toInsert
+	} else if elem < parent.key {
+		parent.left = toInsert
+	} else {
+		parent.right = toInsert
+	}
+	updateSizesToRoot(parent)
+	t.insertFixup(toInsert)
+	t.size++
+	t.version++
+	return nil
+}
+
+// InsertAll inserts all elements in a slice sequentially.
+func (t *RedBlackTreeOf[K, V]) InsertAll(elems []K) error {
+	var e error
+	for _, elem := range elems {
+		err := t.Insert(elem)
+		if err != nil {
+			e = err
+		}
+	}
+	return e
+}
+
+// Delete removes a node from the tree.
+func (t *RedBlackTreeOf[K, V]) Delete(elem K) error {
+	toDelete := searchHelper(elem, t.root)
+	if toDelete == nil {
+		return errors.New("Delete failed, value does not exist: " + fmt.Sprint(elem))
+	}
+
+	// The node that moves into the removed position, and its parent since it may be nil.
+	var replacement, replacementParent *BinaryTreeNodeOf[K, V]
+	removedRed := toDelete.red
+	if toDelete.left == nil {
+		replacement, replacementParent = toDelete.right, toDelete.parent
+		t.transplant(toDelete, toDelete.right)
+	} else if toDelete.right == nil {
+		replacement, replacementParent = toDelete.left, toDelete.parent
+		t.transplant(toDelete, toDelete.left)
+	} else {
+		// Element has two children.
+		// Move the minimum in the right subtree into its position and take over its colour.
+		minInRightSubtree := getMinInSubtree(toDelete.right)
+		removedRed = minInRightSubtree.red
+		replacement = minInRightSubtree.right
+		if minInRightSubtree.parent == toDelete {
+			replacementParent = minInRightSubtree
+		} else {
+			replacementParent = minInRightSubtree.parent
+			t.transplant(minInRightSubtree, minInRightSubtree.right)
+			minInRightSubtree.right = toDelete.right
+			minInRightSubtree.right.parent = minInRightSubtree
+		}
+		t.transplant(toDelete, minInRightSubtree)
+		minInRightSubtree.left = toDelete.left
+		minInRightSubtree.left.parent = minInRightSubtree
+		minInRightSubtree.red = toDelete.red
+	}
+	updateSizesToRoot(replacementParent)
+	if !removedRed {
+		t.deleteFixup(replacement, replacementParent)
+	}
+	t.size--
+	t.version++
+	return nil
+}
+
+// Search returns true if the key exists in the tree.
+func (t *RedBlackTreeOf[K, V]) Search(elem K) bool {
+	return searchHelper(elem, t.root) != nil
+}
+
+// Get returns the value stored with a given key.
+func (t *RedBlackTreeOf[K, V]) Get(elem K) (V, error) {
+	node := searchHelper(elem, t.root)
+	if node == nil {
+		var zero V
+		return zero, errors.New("Key does not exist: " + fmt.Sprint(elem))
+	}
+	return node.value, nil
+}
+
+// Put stores a value with a given key, overriding the value if the key already exists.
+func (t *RedBlackTreeOf[K, V]) Put(elem K, value V) {
+	node := searchHelper(elem, t.root)
+	if node != nil {
+		node.value = value
+		return
+	}
+	t.InsertValue(elem, value)
+}
+
+// Depth returns the depth of a given element, -1 if the element is not found.
+func (t *RedBlackTreeOf[K, V]) Depth(elem K) int {
+	return depthHelper(elem, t.root)
+}
+
+// Clear removes all nodes in the tree.
+func (t *RedBlackTreeOf[K, V]) Clear() {
+	t.root = nil
+	t.size = 0
+	t.version++
+}
+
+// IsEmpty returns true if the tree contains no nodes.
+func (t *RedBlackTreeOf[K, V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Size returns the number of nodes in the tree.
+func (t *RedBlackTreeOf[K, V]) Size() int {
+	return t.size
+}
+
+// InOrder returns the keys of the tree's in order traversal as a slice.
+func (t *RedBlackTreeOf[K, V]) InOrder() []K {
+	temp := make([]K, 0)
+	result := &temp
+	inOrderHelper(t.root, &result)
+	return *result
+}
+
+// All returns an iterator over the keys and values of the tree in order.
+func (t *RedBlackTreeOf[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		inOrderSeqHelper(t.root, versionedYield(&t.version, yield), false)
+	}
+}
+
+// Backward returns an iterator over the keys and values of the tree in reverse order.
+func (t *RedBlackTreeOf[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		inOrderSeqHelper(t.root, versionedYield(&t.version, yield), true)
+	}
+}
+
+// Keys returns an iterator over the keys of the tree in order.
+func (t *RedBlackTreeOf[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range t.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values of the tree, ordered by key.
+func (t *RedBlackTreeOf[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, value := range t.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// PreOrder returns an iterator over the keys and values of the tree's pre order traversal.
+func (t *RedBlackTreeOf[K, V]) PreOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		preOrderSeqHelper(t.root, versionedYield(&t.version, yield))
+	}
+}
+
+// PostOrder returns an iterator over the keys and values of the tree's post order traversal.
+func (t *RedBlackTreeOf[K, V]) PostOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		postOrderSeqHelper(t.root, versionedYield(&t.version, yield))
+	}
+}
+
+// LevelOrder returns an iterator over the keys and values of the tree's level order traversal.
+func (t *RedBlackTreeOf[K, V]) LevelOrder() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		levelOrderSeqHelper(t.root, versionedYield(&t.version, yield))
+	}
+}
+
+// CheckInvariants returns an error describing the first violated red-black tree property, nil if the tree is valid.
+func (t *RedBlackTreeOf[K, V]) CheckInvariants() error {
+	err := checkBinarySearchTree(t.root, t.size)
+	if err != nil {
+		return err
+	}
+	if isRed(t.root) {
+		return errors.New("Root is red: " + fmt.Sprint(t.root.key))
+	}
+	_, err = checkRedBlackHelper(t.root)
+	return err
+}
+
+// Restores the red-black properties after inserting a red node.
+func (t *RedBlackTreeOf[K, V]) insertFixup(node *BinaryTreeNodeOf[K, V]) {
+	for isRed(node.parent) {
+		// The parent is red so it cannot be the root, the grandparent exists.
+		parent := node.parent
+		grandparent := parent.parent
+		if parent == grandparent.left {
+			uncle := grandparent.right
+			if isRed(uncle) {
+				parent.red, uncle.red, grandparent.red = false, false, true
+				node = grandparent
+				continue
+			}
+			if node == parent.right {
+				node = parent
+				t.rotateLeft(node)
+				parent = node.parent
+			}
+			parent.red, grandparent.red = false, true
+			t.rotateRight(grandparent)
+		} else {
+			uncle := grandparent.left
+			if isRed(uncle) {
+				parent.red, uncle.red, grandparent.red = false, false, true
+				node = grandparent
+				continue
+			}
+			if node == parent.left {
+				node = parent
+				t.rotateRight(node)
+				parent = node.parent
+			}
+			parent.red, grandparent.red = false, true
+			t.rotateLeft(grandparent)
+		}
+	}
+	t.root.red = false
+}
+
+// Restores the red-black properties after removing a black node.
+// The node carrying the extra black may be nil, so its parent is passed separately.
+func (t *RedBlackTreeOf[K, V]) deleteFixup(node *BinaryTreeNodeOf[K, V], parent *BinaryTreeNodeOf[K, V]) {
+	for node != t.root && !isRed(node) {
+		if node == parent.left {
+			sibling := parent.right
+			if isRed(sibling) {
+				sibling.red, parent.red = false, true
+				t.rotateLeft(parent)
+				sibling = parent.right
+			}
+			if !isRed(sibling.left) && !isRed(sibling.right) {
+				sibling.red = true
+				node, parent = parent, parent.parent
+				continue
+			}
+			if !isRed(sibling.right) {
+				sibling.left.red, sibling.red = false, true
+				t.rotateRight(sibling)
+				sibling = parent.right
+			}
+			sibling.red, parent.red, sibling.right.red = parent.red, false, false
+			t.rotateLeft(parent)
+		} else {
+			sibling := parent.left
+			if isRed(sibling) {
+				sibling.red, parent.red = false, true
+				t.rotateRight(parent)
+				sibling = parent.left
+			}
+			if !isRed(sibling.left) && !isRed(sibling.right) {
+				sibling.red = true
+				node, parent = parent, parent.parent
+				continue
+			}
+			if !isRed(sibling.left) {
+				sibling.right.red, sibling.red = false, true
+				t.rotateLeft(sibling)
+				sibling = parent.left
+			}
+			sibling.red, parent.red, sibling.left.red = parent.red, false, false
+			t.rotateRight(parent)
+		}
+		node = t.root
+	}
+	if node != nil {
+		node.red = false
+	}
+}
+
+// Replaces the subtree rooted at a node with another subtree.
+func (t *RedBlackTreeOf[K, V]) transplant(node *BinaryTreeNodeOf[K, V], replacement *BinaryTreeNodeOf[K, V]) {
+	if isRoot(node) {
+		t.root = replacement
+	}
+	replaceChild(node.parent, node, replacement)
+}
+
+func (t *RedBlackTreeOf[K, V]) rotateLeft(node *BinaryTreeNodeOf[K, V]) {
+	if pivot := rotateLeft(node); isRoot(pivot) {
+		t.root = pivot
+	}
+}
+
+func (t *RedBlackTreeOf[K, V]) rotateRight(node *BinaryTreeNodeOf[K, V]) {
+	if pivot := rotateRight(node); isRoot(pivot) {
+		t.root = pivot
+	}
+}
+
+// Nil children count as black.
+func isRed[K, V any](node *BinaryTreeNodeOf[K, V]) bool {
+	return node != nil && node.red
+}
+
+// Returns the number of black nodes on every path from a node to a nil child.
+func checkRedBlackHelper[K, V any](node *BinaryTreeNodeOf[K, V]) (int, error) {
+	if node == nil {
+		return 1, nil
+	}
+	if node.red && (isRed(node.left) || isRed(node.right)) {
+		return 0, errors.New("Red node has a red child: " + fmt.Sprint(node.key))
+	}
+	left, err := checkRedBlackHelper(node.left)
+	if err != nil {
+		return 0, err
+	}
+	right, err := checkRedBlackHelper(node.right)
+	if err != nil {
+		return 0, err
+	}
+	if left != right {
+		return 0, errors.New("Black heights differ below node: " + fmt.Sprint(node.key))
+	}
+	if node.red {
+		return left, nil
+	}
+	return left + 1, nil
+}
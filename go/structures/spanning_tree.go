@@ -0,0 +1,160 @@
+package structures
+
+import (
+	"cmp"
+	"slices"
+)
+
+// SpanningForest is a minimum spanning tree of each connected component of a graph, with edges treated as undirected.
+type SpanningForest struct {
+	// Edges of the forest in insertion order, in the direction they were added to the graph.
+	Edges []*Edge
+	// Weight is the total weight of the edges.
+	Weight int
+	// Trees is the number of trees in the forest, which is the number of connected components.
+	Trees int
+}
+
+// The edges of a graph in insertion order with the vertices indexed by insertion order, edges are compared by weight then index.
+type spanningGraph struct {
+	vertices []string
+	index    map[string]int
+	edges    []*Edge
+}
+
+func newSpanningGraph(g Graph) *spanningGraph {
+	sg := &spanningGraph{index: make(map[string]int)}
+	for vertex := range g.Vertices() {
+		sg.index[vertex] = len(sg.vertices)
+		sg.vertices = append(sg.vertices, vertex)
+	}
+	for edge := range g.Edges() {
+		// Self loops never join two trees.
+		if edge.From() != edge.To() {
+			sg.edges = append(sg.edges, edge)
+		}
+	}
+	return sg
+}
+
+// Breaks ties between equal weights by insertion order, so all algorithms choose the same edges.
+func (sg *spanningGraph) compare(i int, j int) int {
+	return cmp.Or(cmp.Compare(sg.edges[i].Weight(), sg.edges[j].Weight()), cmp.Compare(i, j))
+}
+
+func (sg *spanningGraph) endpoints(i int) (int, int) {
+	return sg.index[sg.edges[i].From()], sg.index[sg.edges[i].To()]
+}
+
+func (sg *spanningGraph) forest(chosen []int) *SpanningForest {
+	slices.Sort(chosen)
+	forest := &SpanningForest{Edges: make([]*Edge, 0, len(chosen)), Trees: len(sg.vertices) - len(chosen)}
+	for _, i := range chosen {
+		forest.Edges = append(forest.Edges, sg.edges[i])
+		forest.Weight += sg.edges[i].Weight()
+	}
+	return forest
+}
+
+// Prim returns a minimum spanning forest of the graph using Prim's algorithm, with edges treated as undirected.
+// Time: O(E log E).
+func Prim(g Graph) *SpanningForest {
+	sg := newSpanningGraph(g)
+	incident := make([][]int, len(sg.vertices))
+	for i := range sg.edges {
+		from, to := sg.endpoints(i)
+		incident[from] = append(incident[from], i)
+		incident[to] = append(incident[to], i)
+	}
+	visited := make([]bool, len(sg.vertices))
+	chosen := make([]int, 0)
+	heap := NewBinaryHeapFunc(MinHeap, sg.compare)
+	visit := func(vertex int) {
+		visited[vertex] = true
+		for _, i := range incident[vertex] {
+			from, to := sg.endpoints(i)
+			if !visited[from] || !visited[to] {
+				heap.Insert(i)
+			}
+		}
+	}
+	for root := range sg.vertices {
+		if visited[root] {
+			continue
+		}
+		visit(root)
+		for !heap.IsEmpty() {
+			i, _ := heap.RemoveTop()
+			from, to := sg.endpoints(i)
+			// Both ends may have been added to the tree since the edge was queued.
+			if visited[from] && visited[to] {
+				continue
+			}
+			chosen = append(chosen, i)
+			if visited[from] {
+				visit(to)
+			} else {
+				visit(from)
+			}
+		}
+	}
+	return sg.forest(chosen)
+}
+
+// Kruskal returns a minimum spanning forest of the graph using Kruskal's algorithm, with edges treated as undirected.
+// Time: O(E log E).
+func Kruskal(g Graph) *SpanningForest {
+	sg := newSpanningGraph(g)
+	order := make([]int, len(sg.edges))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, sg.compare)
+	trees := newUnionFind(len(sg.vertices))
+	chosen := make([]int, 0)
+	for _, i := range order {
+		if trees.union(sg.endpoints(i)) {
+			chosen = append(chosen, i)
+		}
+	}
+	return sg.forest(chosen)
+}
+
+// Boruvka returns a minimum spanning forest of the graph using Borůvka's algorithm, with edges treated as undirected.
+// Each round at least halves the number of trees that can still grow.
+// Time: O(E log V).
+func Boruvka(g Graph) *SpanningForest {
+	sg := newSpanningGraph(g)
+	trees := newUnionFind(len(sg.vertices))
+	chosen := make([]int, 0)
+	for {
+		// Lightest edge leaving each tree, indexed by the tree's root, -1 if there is none.
+		lightest := make([]int, len(sg.vertices))
+		for i := range lightest {
+			lightest[i] = -1
+		}
+		for i := range sg.edges {
+			from, to := sg.endpoints(i)
+			fromTree, toTree := trees.find(from), trees.find(to)
+			if fromTree == toTree {
+				continue
+			}
+			for _, tree := range []int{fromTree, toTree} {
+				if lightest[tree] == -1 || sg.compare(i, lightest[tree]) < 0 {
+					lightest[tree] = i
+				}
+			}
+		}
+		merged := false
+		for _, i := range lightest {
+			// Two trees may choose the same edge, only the first union succeeds.
+			if i != -1 && trees.union(sg.endpoints(i)) {
+				chosen = append(chosen, i)
+				merged = true
+			}
+		}
+		if !merged {
+			return sg.forest(chosen)
+		}
+	}
+}
@@ -2,47 +2,48 @@ package structures
 
 import "errors"
 
-// Stack of ints.
-type Stack interface {
-	Peek() (int, error)
-	Push(elem int)
-	Pop() (int, error)
+// StackOf holds elements of any type.
+type StackOf[T any] interface {
+	Peek() (T, error)
+	Push(elem T)
+	Pop() (T, error)
 	Size() int
 	Clear()
 	IsEmpty() bool
 }
 
-// StackNode represents a node in the linked stack.
-type StackNode struct {
-	value int
-	next  *StackNode
+// StackNodeOf represents a node in the linked stack.
+type StackNodeOf[T any] struct {
+	value T
+	next  *StackNodeOf[T]
 }
 
-// LinkedStack implements Stack using a linked list.
-type LinkedStack struct {
-	top  *StackNode
+// LinkedStackOf implements StackOf using a linked list.
+type LinkedStackOf[T any] struct {
+	top  *StackNodeOf[T]
 	size int
 }
 
 // Peek returns the top the element of the stack.
-func (s *LinkedStack) Peek() (int, error) {
+func (s *LinkedStackOf[T]) Peek() (T, error) {
 	if s.IsEmpty() {
-		return 0, errors.New("Stack is empty")
+		var zero T
+		return zero, errors.New("Stack is empty")
 	}
 	return s.top.value, nil
 }
 
 // Push adds an element onto the stack.
-func (s *LinkedStack) Push(elem int) {
-	s.top = &StackNode{value: elem, next: s.top}
+func (s *LinkedStackOf[T]) Push(elem T) {
+	s.top = &StackNodeOf[T]{value: elem, next: s.top}
 	s.size++
 }
 
 // Pop removes and returns the top element of the stack.
-func (s *LinkedStack) Pop() (int, error) {
+func (s *LinkedStackOf[T]) Pop() (T, error) {
 	value, err := s.Peek()
 	if err != nil {
-		return 0, err
+		return value, err
 	}
 	s.top = s.top.next
 	s.size--
@@ -50,60 +51,73 @@ func (s *LinkedStack) Pop() (int, error) {
 }
 
 // Size returns the number of elements in the stack.
-func (s *LinkedStack) Size() int {
+func (s *LinkedStackOf[T]) Size() int {
 	return s.size
 }
 
 // Clear removes all elements in the stack.
-func (s *LinkedStack) Clear() {
+func (s *LinkedStackOf[T]) Clear() {
 	s.top = nil
 	s.size = 0
 }
 
 // IsEmpty returns true when the stack is empty.
-func (s *LinkedStack) IsEmpty() bool {
+func (s *LinkedStackOf[T]) IsEmpty() bool {
 	return s.Size() == 0
 }
 
-// ArrayStack implements Stack using an array.
-type ArrayStack struct {
-	elems []int
+// ArrayStackOf implements StackOf using an array.
+type ArrayStackOf[T any] struct {
+	elems []T
 }
 
 // Peek returns the top the element of the stack.
-func (s *ArrayStack) Peek() (int, error) {
+func (s *ArrayStackOf[T]) Peek() (T, error) {
 	if s.IsEmpty() {
-		return 0, errors.New("Stack is empty")
+		var zero T
+		return zero, errors.New("Stack is empty")
 	}
 	return s.elems[len(s.elems)-1], nil
 }
 
 // Push adds an element onto the stack. The end of the slice represents the top.
-func (s *ArrayStack) Push(elem int) {
+func (s *ArrayStackOf[T]) Push(elem T) {
 	s.elems = append(s.elems, elem)
 }
 
 // Pop removes and returns the top element of the stack.
-func (s *ArrayStack) Pop() (int, error) {
+func (s *ArrayStackOf[T]) Pop() (T, error) {
 	value, err := s.Peek()
 	if err != nil {
-		return 0, err
+		return value, err
 	}
 	s.elems = s.elems[:len(s.elems)-1]
 	return value, nil
 }
 
 // Size returns the number of elements in the stack.
-func (s *ArrayStack) Size() int {
+func (s *ArrayStackOf[T]) Size() int {
 	return len(s.elems)
 }
 
 // Clear removes all elements in the stack.
-func (s *ArrayStack) Clear() {
+func (s *ArrayStackOf[T]) Clear() {
 	s.elems = nil
 }
 
 // IsEmpty returns true when the stack is empty.
-func (s *ArrayStack) IsEmpty() bool {
+func (s *ArrayStackOf[T]) IsEmpty() bool {
 	return s.Size() == 0
 }
+
+// Stack of ints.
+type Stack = StackOf[int]
+
+// StackNode represents a node in a linked stack of ints.
+type StackNode = StackNodeOf[int]
+
+// LinkedStack implements Stack using a linked list.
+type LinkedStack = LinkedStackOf[int]
+
+// ArrayStack implements Stack using an array.
+type ArrayStack = ArrayStackOf[int]
@@ -0,0 +1,140 @@
+package structures
+
+import "slices"
+
+// StronglyConnectedComponents returns the strongly connected components of the graph using Tarjan's algorithm.
+// Each component lists its vertices in insertion order, and components are ordered by their first vertex.
+func StronglyConnectedComponents(graph Graph) [][]string {
+	g := graph.directed()
+	// Order in which vertices were discovered, and the earliest discovered vertex reachable through the search tree.
+	discovered := make(map[string]int)
+	low := make(map[string]int)
+	stack := make([]string, 0)
+	onStack := make(map[string]bool)
+	result := make([][]string, 0)
+	var visit func(vertex string)
+	visit = func(vertex string) {
+		discovered[vertex] = len(discovered)
+		low[vertex] = discovered[vertex]
+		stack = append(stack, vertex)
+		onStack[vertex] = true
+		for neighbour := range g.Neighbours(vertex) {
+			if _, ok := discovered[neighbour]; !ok {
+				visit(neighbour)
+				low[vertex] = min(low[vertex], low[neighbour])
+			} else if onStack[neighbour] {
+				low[vertex] = min(low[vertex], discovered[neighbour])
+			}
+		}
+		// The vertex is the root of a component, which is everything above it on the stack.
+		if low[vertex] == discovered[vertex] {
+			index := slices.Index(stack, vertex)
+			for _, member := range stack[index:] {
+				onStack[member] = false
+			}
+			result = append(result, slices.Clone(stack[index:]))
+			stack = stack[:index]
+		}
+	}
+	for vertex := range g.Vertices() {
+		if _, ok := discovered[vertex]; !ok {
+			visit(vertex)
+		}
+	}
+	return sortComponents(g, result)
+}
+
+// KosarajuComponents returns the strongly connected components of the graph using Kosaraju's algorithm,
+// in the same order as StronglyConnectedComponents.
+func KosarajuComponents(graph Graph) [][]string {
+	g := graph.directed()
+	// Order the vertices by when their depth first search finished.
+	visited := make(map[string]bool)
+	finished := make([]string, 0, g.NumberOfVertices())
+	var visit func(vertex string)
+	visit = func(vertex string) {
+		visited[vertex] = true
+		for neighbour := range g.Neighbours(vertex) {
+			if !visited[neighbour] {
+				visit(neighbour)
+			}
+		}
+		finished = append(finished, vertex)
+	}
+	for vertex := range g.Vertices() {
+		if !visited[vertex] {
+			visit(vertex)
+		}
+	}
+
+	// Searching the reversed graph from the last finished vertex only reaches its own component.
+	predecessors := make(map[string][]string)
+	for edge := range g.Edges() {
+		predecessors[edge.To()] = append(predecessors[edge.To()], edge.From())
+	}
+	assigned := make(map[string]bool)
+	result := make([][]string, 0)
+	for _, root := range slices.Backward(finished) {
+		if assigned[root] {
+			continue
+		}
+		component := reachableHelper(root, predecessors, func(vertex string) bool { return !assigned[vertex] })
+		members := make([]string, 0, len(component))
+		for vertex := range component {
+			assigned[vertex] = true
+			members = append(members, vertex)
+		}
+		result = append(result, members)
+	}
+	return sortComponents(g, result)
+}
+
+// Orders the vertices of each component by insertion order, then the components by their first vertex.
+func sortComponents(g DirectedWeightedGraph, components [][]string) [][]string {
+	index := make(map[string]int)
+	for vertex := range g.Vertices() {
+		index[vertex] = len(index)
+	}
+	byIndex := func(a string, b string) int {
+		return index[a] - index[b]
+	}
+	for _, component := range components {
+		slices.SortFunc(component, byIndex)
+	}
+	slices.SortFunc(components, func(a []string, b []string) int {
+		return byIndex(a[0], b[0])
+	})
+	return components
+}
+
+// Condensation returns a new acyclic graph with one vertex per strongly connected component, and the components.
+// Each component is named after its first vertex. Edges between components are combined into one edge with their total weight.
+func Condensation(graph Graph) (*AdjacencyList, [][]string) {
+	g := graph.directed()
+	condensation := &AdjacencyList{}
+	condensation.Clear()
+	components := StronglyConnectedComponents(g)
+	componentOf := make(map[string]string)
+	for _, component := range components {
+		condensation.AddVertex(component[0])
+		for _, vertex := range component {
+			componentOf[vertex] = component[0]
+		}
+	}
+	weights := make(map[[2]string]int)
+	order := make([][2]string, 0)
+	for edge := range g.Edges() {
+		key := [2]string{componentOf[edge.From()], componentOf[edge.To()]}
+		if key[0] == key[1] {
+			continue
+		}
+		if _, ok := weights[key]; !ok {
+			order = append(order, key)
+		}
+		weights[key] += edge.Weight()
+	}
+	for _, key := range order {
+		condensation.AddEdge(key[0], key[1], weights[key])
+	}
+	return condensation, components
+}
@@ -0,0 +1,202 @@
+package structures
+
+import (
+	"slices"
+	"strings"
+)
+
+// CycleError is returned when an operation requires a directed acyclic graph but the graph contains a cycle.
+type CycleError struct {
+	// Cycle lists the vertices of a cycle in order, the last vertex has an edge back to the first.
+	Cycle []string
+}
+
+func (e *CycleError) Error() string {
+	return "Graph contains a cycle: " + strings.Join(append(slices.Clone(e.Cycle), e.Cycle[0]), " -> ")
+}
+
+// TopologicalSort orders the vertices so that every edge goes from an earlier vertex to a later one, using Kahn's algorithm.
+// Returns a CycleError if the graph contains a cycle.
+// Of the vertices with no remaining incoming edges, the one inserted first is removed first,
+// so the result does not depend on the order of the edges.
+func TopologicalSort(graph Graph) ([]string, error) {
+	g := graph.directed()
+	inDegree := make(map[string]int)
+	for edge := range g.Edges() {
+		inDegree[edge.To()]++
+	}
+	index := make(map[string]int)
+	pq := NewPriorityQueue(func(vertex string) int { return index[vertex] })
+	for vertex := range g.Vertices() {
+		index[vertex] = len(index)
+		if inDegree[vertex] == 0 {
+			pq.Push(vertex)
+		}
+	}
+	result := make([]string, 0, g.NumberOfVertices())
+	for !pq.IsEmpty() {
+		vertex, _ := pq.Pop()
+		result = append(result, vertex)
+		for neighbour := range g.Neighbours(vertex) {
+			inDegree[neighbour]--
+			if inDegree[neighbour] == 0 {
+				pq.Push(neighbour)
+			}
+		}
+	}
+	if len(result) < g.NumberOfVertices() {
+		// The vertices that were never removed all lie on or after a cycle.
+		_, cycle := topologicalDFSHelper(g)
+		return nil, &CycleError{Cycle: cycle}
+	}
+	return result, nil
+}
+
+// TopologicalSortDFS orders the vertices so that every edge goes from an earlier vertex to a later one, using depth first search.
+// Returns a CycleError if the graph contains a cycle.
+func TopologicalSortDFS(graph Graph) ([]string, error) {
+	g := graph.directed()
+	order, cycle := topologicalDFSHelper(g)
+	if cycle != nil {
+		return nil, &CycleError{Cycle: cycle}
+	}
+	return order, nil
+}
+
+// HasCycle returns true if the graph contains a directed cycle.
+func HasCycle(graph Graph) bool {
+	_, cycle := topologicalDFSHelper(graph.directed())
+	return cycle != nil
+}
+
+// Performs a depth first search from every unvisited vertex in insertion order.
+// Returns the vertices in reverse post order, or the first cycle found through an edge back to a vertex on the current path.
+func topologicalDFSHelper(g DirectedWeightedGraph) ([]string, []string) {
+	const (
+		unvisited = iota
+		onPath
+		finished
+	)
+	state := make(map[string]int)
+	path := make([]string, 0)
+	order := make([]string, 0, g.NumberOfVertices())
+	var visit func(vertex string) []string
+	visit = func(vertex string) []string {
+		state[vertex] = onPath
+		path = append(path, vertex)
+		for neighbour := range g.Neighbours(vertex) {
+			switch state[neighbour] {
+			case onPath:
+				return slices.Clone(path[slices.Index(path, neighbour):])
+			case unvisited:
+				if cycle := visit(neighbour); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[vertex] = finished
+		order = append(order, vertex)
+		return nil
+	}
+	for vertex := range g.Vertices() {
+		if state[vertex] == unvisited {
+			if cycle := visit(vertex); cycle != nil {
+				return nil, cycle
+			}
+		}
+	}
+	slices.Reverse(order)
+	return order, nil
+}
+
+// Cycles returns every elementary cycle in the graph using Johnson's algorithm.
+// Each cycle is listed once, starting from its vertex that was inserted first.
+// Time: O((V + E)(C + 1)) where C is the number of cycles, which can be exponential in the number of vertices.
+func Cycles(graph Graph) [][]string {
+	g := graph.directed()
+	vertices := slices.Collect(g.Vertices())
+	index := make(map[string]int)
+	for i, vertex := range vertices {
+		index[vertex] = i
+	}
+	// Parallel edges would list the same cycle more than once.
+	neighbours := make(map[string][]string)
+	predecessors := make(map[string][]string)
+	for edge := range g.Edges() {
+		if !slices.Contains(neighbours[edge.From()], edge.To()) {
+			neighbours[edge.From()] = append(neighbours[edge.From()], edge.To())
+			predecessors[edge.To()] = append(predecessors[edge.To()], edge.From())
+		}
+	}
+
+	result := make([][]string, 0)
+	for s, start := range vertices {
+		// Only search the strongly connected component of the start vertex amongst the vertices inserted after it.
+		forward := reachableHelper(start, neighbours, func(vertex string) bool { return index[vertex] >= s })
+		backward := reachableHelper(start, predecessors, func(vertex string) bool { return index[vertex] >= s })
+		component := func(vertex string) bool { return forward[vertex] && backward[vertex] }
+
+		blocked := make(map[string]bool)
+		blockedBy := make(map[string][]string)
+		var unblock func(vertex string)
+		unblock = func(vertex string) {
+			blocked[vertex] = false
+			for _, other := range blockedBy[vertex] {
+				if blocked[other] {
+					unblock(other)
+				}
+			}
+			blockedBy[vertex] = nil
+		}
+		stack := make([]string, 0)
+		var circuit func(vertex string) bool
+		circuit = func(vertex string) bool {
+			found := false
+			stack = append(stack, vertex)
+			blocked[vertex] = true
+			for _, neighbour := range neighbours[vertex] {
+				if !component(neighbour) {
+					continue
+				}
+				if neighbour == start {
+					result = append(result, slices.Clone(stack))
+					found = true
+				} else if !blocked[neighbour] && circuit(neighbour) {
+					found = true
+				}
+			}
+			if found {
+				unblock(vertex)
+			} else {
+				// Stay blocked until a neighbour is unblocked, since no new cycle can pass through this vertex before then.
+				for _, neighbour := range neighbours[vertex] {
+					if component(neighbour) && !slices.Contains(blockedBy[neighbour], vertex) {
+						blockedBy[neighbour] = append(blockedBy[neighbour], vertex)
+					}
+				}
+			}
+			stack = stack[:len(stack)-1]
+			return found
+		}
+		circuit(start)
+	}
+	return result
+}
+
+// Returns the vertices reachable from the source through allowed vertices, following the given adjacency.
+func reachableHelper(source string, adjacency map[string][]string, allowed func(vertex string) bool) map[string]bool {
+	visited := map[string]bool{source: true}
+	stack := []string{source}
+	for len(stack) > 0 {
+		vertex := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, neighbour := range adjacency[vertex] {
+			if !visited[neighbour] && allowed(neighbour) {
+				visited[neighbour] = true
+				stack = append(stack, neighbour)
+			}
+		}
+	}
+	return visited
+}
@@ -1,5 +1,12 @@
 package structures
 
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
 const (
 	// LeftChild binary node relationship.
 	LeftChild = iota
@@ -11,14 +18,25 @@ const (
 	None = iota
 )
 
-// BinaryTreeNode represents a node in a binary tree.
-type BinaryTreeNode struct {
-	value  int
-	parent *BinaryTreeNode
-	left   *BinaryTreeNode
-	right  *BinaryTreeNode
+// BinaryTreeNodeOf represents a node in a binary tree.
+// Nodes are ordered by key, value is an optional payload.
+type BinaryTreeNodeOf[K any, V any] struct {
+	key    K
+	value  V
+	parent *BinaryTreeNodeOf[K, V]
+	left   *BinaryTreeNodeOf[K, V]
+	right  *BinaryTreeNodeOf[K, V]
+	// Size is the number of nodes in the subtree rooted at this node, including itself.
+	size int
+	// Height is only used for the AVL tree implementation, a leaf has height 1.
+	height int
+	// Red is only used for the red-black tree implementation, nil children are black.
+	red bool
 }
 
+// BinaryTreeNode represents a node in a binary tree of ints.
+type BinaryTreeNode = BinaryTreeNodeOf[int, struct{}]
+
 // NaryTreeNode represents a node in a generic tree.
 type NaryTreeNode struct {
 	value    int
@@ -26,7 +44,7 @@ type NaryTreeNode struct {
 	children []*BinaryTreeNode
 }
 
-func numberOfChildren(node *BinaryTreeNode) int {
+func numberOfChildren[K, V any](node *BinaryTreeNodeOf[K, V]) int {
 	result := 0
 	if node.left != nil {
 		result++
@@ -37,40 +55,234 @@ func numberOfChildren(node *BinaryTreeNode) int {
 	return result
 }
 
-func subtreeSize(node *BinaryTreeNode) int {
+// Returns the stored size of a subtree in O(1) time.
+func subtreeSize[K, V any](node *BinaryTreeNodeOf[K, V]) int {
 	if node == nil {
 		return 0
 	}
-	return 1 + subtreeSize(node.left) + subtreeSize(node.right)
+	return node.size
+}
+
+// Recomputes the size of a node from the sizes of its children.
+func updateSize[K, V any](node *BinaryTreeNodeOf[K, V]) {
+	node.size = 1 + subtreeSize(node.left) + subtreeSize(node.right)
+}
+
+// Recomputes the sizes of a node and all of its ancestors.
+func updateSizesToRoot[K, V any](node *BinaryTreeNodeOf[K, V]) {
+	for currentNode := node; currentNode != nil; currentNode = currentNode.parent {
+		updateSize(currentNode)
+	}
 }
 
-func isRoot(node *BinaryTreeNode) bool {
+func isRoot[K, V any](node *BinaryTreeNodeOf[K, V]) bool {
 	return node.parent == nil
 }
 
-func isLeaf(node *BinaryTreeNode) bool {
+func isLeaf[K, V any](node *BinaryTreeNodeOf[K, V]) bool {
 	return numberOfChildren(node) == 0
 }
 
 // Completes the sentence: b is a's _______.
-func getRelationship(a *BinaryTreeNode, b *BinaryTreeNode) int {
-	if a.left != nil && a.left.value == b.value {
+func getRelationship[K, V any](a *BinaryTreeNodeOf[K, V], b *BinaryTreeNodeOf[K, V]) int {
+	if a.left != nil && a.left == b {
 		return LeftChild
 	}
-	if a.right != nil && a.right.value == b.value {
+	if a.right != nil && a.right == b {
 		return RightChild
 	}
-	if a.parent != nil && a.parent.value == b.value {
+	if a.parent != nil && a.parent == b {
 		return Parent
 	}
 	return None
 }
 
 // Finds the minimum value in a subtree at a given root.
-func getMinInSubtree(node *BinaryTreeNode) *BinaryTreeNode {
+func getMinInSubtree[K, V any](node *BinaryTreeNodeOf[K, V]) *BinaryTreeNodeOf[K, V] {
 	currentNode := node
 	for currentNode.left != nil {
 		currentNode = currentNode.left
 	}
 	return currentNode
 }
+
+// Rotates a subtree left and returns its new root. The parent's child pointer is updated.
+func rotateLeft[K, V any](node *BinaryTreeNodeOf[K, V]) *BinaryTreeNodeOf[K, V] {
+	pivot := node.right
+	node.right = pivot.left
+	if pivot.left != nil {
+		pivot.left.parent = node
+	}
+	replaceChild(node.parent, node, pivot)
+	pivot.left = node
+	node.parent = pivot
+	pivot.size = node.size
+	updateSize(node)
+	return pivot
+}
+
+// Rotates a subtree right and returns its new root. The parent's child pointer is updated.
+func rotateRight[K, V any](node *BinaryTreeNodeOf[K, V]) *BinaryTreeNodeOf[K, V] {
+	pivot := node.left
+	node.left = pivot.right
+	if pivot.right != nil {
+		pivot.right.parent = node
+	}
+	replaceChild(node.parent, node, pivot)
+	pivot.right = node
+	node.parent = pivot
+	pivot.size = node.size
+	updateSize(node)
+	return pivot
+}
+
+// Replaces the child of a parent, which may be nil if the child is the root.
+func replaceChild[K, V any](parent *BinaryTreeNodeOf[K, V], child *BinaryTreeNodeOf[K, V], replacement *BinaryTreeNodeOf[K, V]) {
+	if replacement != nil {
+		replacement.parent = parent
+	}
+	if parent == nil {
+		return
+	}
+	if getRelationship(parent, child) == LeftChild {
+		parent.left = replacement
+	} else {
+		parent.right = replacement
+	}
+}
+
+// Checks the ordering, parent pointers and size of a binary search tree.
+func checkBinarySearchTree[K cmp.Ordered, V any](root *BinaryTreeNodeOf[K, V], size int) error {
+	if root != nil && root.parent != nil {
+		return errors.New("Root has a parent: " + fmt.Sprint(root.key))
+	}
+	count := 0
+	var previous *BinaryTreeNodeOf[K, V]
+	var err error
+	var walk func(node *BinaryTreeNodeOf[K, V])
+	walk = func(node *BinaryTreeNodeOf[K, V]) {
+		if node == nil || err != nil {
+			return
+		}
+		for _, child := range []*BinaryTreeNodeOf[K, V]{node.left, node.right} {
+			if child != nil && child.parent != node {
+				err = errors.New("Parent pointer is broken: " + fmt.Sprint(child.key))
+				return
+			}
+		}
+		if node.size != 1+subtreeSize(node.left)+subtreeSize(node.right) {
+			err = errors.New("Stored subtree size is wrong: " + fmt.Sprint(node.key))
+			return
+		}
+		walk(node.left)
+		if err != nil {
+			return
+		}
+		if previous != nil && previous.key >= node.key {
+			err = errors.New("Keys are out of order: " + fmt.Sprint(previous.key) + ", " + fmt.Sprint(node.key))
+			return
+		}
+		previous = node
+		count++
+		walk(node.right)
+	}
+	walk(root)
+	if err != nil {
+		return err
+	}
+	if count != size {
+		return errors.New("Size is " + strconv.Itoa(size) + " but the tree has " + strconv.Itoa(count) + " nodes")
+	}
+	return nil
+}
+
+// Finds the maximum value in a subtree at a given root.
+func getMaxInSubtree[K, V any](node *BinaryTreeNodeOf[K, V]) *BinaryTreeNodeOf[K, V] {
+	currentNode := node
+	for currentNode.right != nil {
+		currentNode = currentNode.right
+	}
+	return currentNode
+}
+
+// Returns the node with the k-th smallest key (starting from 0), nil if k is out of bounds.
+func selectHelper[K, V any](node *BinaryTreeNodeOf[K, V], k int) *BinaryTreeNodeOf[K, V] {
+	currentNode := node
+	for currentNode != nil {
+		leftSize := subtreeSize(currentNode.left)
+		if k < leftSize {
+			currentNode = currentNode.left
+		} else if k > leftSize {
+			k -= leftSize + 1
+			currentNode = currentNode.right
+		} else {
+			return currentNode
+		}
+	}
+	return nil
+}
+
+// Returns the number of keys in a subtree that are less than elem.
+func rankHelper[K cmp.Ordered, V any](node *BinaryTreeNodeOf[K, V], elem K) int {
+	rank := 0
+	currentNode := node
+	for currentNode != nil {
+		if elem < currentNode.key {
+			currentNode = currentNode.left
+		} else if elem > currentNode.key {
+			rank += subtreeSize(currentNode.left) + 1
+			currentNode = currentNode.right
+		} else {
+			return rank + subtreeSize(currentNode.left)
+		}
+	}
+	return rank
+}
+
+// Returns the node with the largest key less than elem, or equal to it if inclusive is true.
+func lowerHelper[K cmp.Ordered, V any](node *BinaryTreeNodeOf[K, V], elem K, inclusive bool) *BinaryTreeNodeOf[K, V] {
+	var result *BinaryTreeNodeOf[K, V]
+	currentNode := node
+	for currentNode != nil {
+		if currentNode.key < elem || (inclusive && currentNode.key == elem) {
+			result = currentNode
+			currentNode = currentNode.right
+		} else {
+			currentNode = currentNode.left
+		}
+	}
+	return result
+}
+
+// Returns the node with the smallest key greater than elem, or equal to it if inclusive is true.
+func higherHelper[K cmp.Ordered, V any](node *BinaryTreeNodeOf[K, V], elem K, inclusive bool) *BinaryTreeNodeOf[K, V] {
+	var result *BinaryTreeNodeOf[K, V]
+	currentNode := node
+	for currentNode != nil {
+		if currentNode.key > elem || (inclusive && currentNode.key == elem) {
+			result = currentNode
+			currentNode = currentNode.left
+		} else {
+			currentNode = currentNode.right
+		}
+	}
+	return result
+}
+
+// Yields the keys in [lo, hi] in order, skipping subtrees outside of the range.
+// Returns false once the consumer stops the iteration.
+func rangeSeqHelper[K cmp.Ordered, V any](node *BinaryTreeNodeOf[K, V], lo K, hi K, yield func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	if lo < node.key && !rangeSeqHelper(node.left, lo, hi, yield) {
+		return false
+	}
+	if lo <= node.key && node.key <= hi && !yield(node.key, node.value) {
+		return false
+	}
+	if node.key < hi {
+		return rangeSeqHelper(node.right, lo, hi, yield)
+	}
+	return true
+}
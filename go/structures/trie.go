@@ -2,12 +2,15 @@ package structures
 
 import (
 	"errors"
+	"iter"
+	"slices"
 	"strings"
 )
 
 const endOfWord = "END OF WORD"
 
 // TrieNode represents a node in the trie.
+// Children are kept sorted by letter so that words can be listed in lexicographic order.
 type TrieNode struct {
 	value    string
 	parent   *TrieNode
@@ -18,6 +21,8 @@ type TrieNode struct {
 type Trie struct {
 	root *TrieNode
 	size int
+	// Incremented on every modification, used to detect modification during iteration.
+	version int
 }
 
 // Insert adds a new word to the trie.
@@ -41,8 +46,14 @@ func (trie *Trie) Insert(word string) error {
 			currentNode = currentNode.children[letterPosition]
 		} else {
 			newNode := &TrieNode{value: letter, parent: currentNode}
-			currentNode.children = append(currentNode.children, newNode)
-			currentNode = currentNode.children[len(currentNode.children)-1]
+			position := slices.IndexFunc(currentNode.children, func(child *TrieNode) bool {
+				return child.value != endOfWord && child.value > letter
+			})
+			if position == -1 {
+				position = len(currentNode.children)
+			}
+			currentNode.children = slices.Insert(currentNode.children, position, newNode)
+			currentNode = newNode
 			trie.size++
 			didInsert = true
 		}
@@ -50,6 +61,7 @@ func (trie *Trie) Insert(word string) error {
 	if didInsert || !findDummy(currentNode.children) {
 		dummy := &TrieNode{value: endOfWord}
 		currentNode.children = append(currentNode.children, dummy)
+		trie.version++
 		return nil
 	}
 	return errors.New("Word already exists in trie: " + word)
@@ -113,10 +125,42 @@ func (trie *Trie) ValidateWord(word string) bool {
 	return findDummy(currentNode.children)
 }
 
+// All returns an iterator over the words in the trie in lexicographic order.
+func (trie *Trie) All() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if trie.root == nil {
+			return
+		}
+		version := trie.version
+		allHelper(trie.root, "", func(word string) bool {
+			if !yield(word) {
+				return false
+			}
+			checkVersion(version, trie.version)
+			return true
+		})
+	}
+}
+
+// Yields the words below a node, returns false once the consumer stops the iteration.
+func allHelper(node *TrieNode, prefix string, yield func(string) bool) bool {
+	// A word comes before any longer word that it prefixes.
+	if findDummy(node.children) && !yield(prefix) {
+		return false
+	}
+	for _, child := range node.children {
+		if child.value != endOfWord && !allHelper(child, prefix+child.value, yield) {
+			return false
+		}
+	}
+	return true
+}
+
 // Clear removes all nodes in the trie.
 func (trie *Trie) Clear() {
 	trie.root = nil
 	trie.size = 0
+	trie.version++
 }
 
 // Size returns the number of nodes in the trie.
@@ -0,0 +1,193 @@
+package structures
+
+import (
+	"errors"
+	"iter"
+	"strconv"
+)
+
+// UndirectedWeightedAdjacencyList represents an undirected weighted graph implemented using an adjacency list.
+// Each edge is stored in both directions in a directed adjacency list, which the algorithms search.
+type UndirectedWeightedAdjacencyList struct {
+	undirectedGraph[AdjacencyList, *AdjacencyList]
+}
+
+// UndirectedWeightedAdjacencyMatrix represents an undirected weighted graph implemented using an adjacency matrix.
+// Each edge is stored in both directions in a directed adjacency matrix, which the algorithms search.
+type UndirectedWeightedAdjacencyMatrix struct {
+	undirectedGraph[AdjacencyMatrix, *AdjacencyMatrix]
+}
+
+// UndirectedAdjacencyList represents an undirected unweighted graph implemented using an adjacency list.
+// Every edge has weight 1, so weighted algorithms count edges.
+type UndirectedAdjacencyList struct {
+	undirectedGraph[AdjacencyList, *AdjacencyList]
+}
+
+// UndirectedAdjacencyMatrix represents an undirected unweighted graph implemented using an adjacency matrix.
+// Every edge has weight 1, so weighted algorithms count edges.
+type UndirectedAdjacencyMatrix struct {
+	undirectedGraph[AdjacencyMatrix, *AdjacencyMatrix]
+}
+
+// AddEdge adds a new edge between two vertices and returns its ID.
+// Edges that already join the same vertices in either direction are handled by the parallel edge policy.
+func (g *UndirectedWeightedAdjacencyList) AddEdge(a string, b string, weight int) (int, error) {
+	return g.addEdge(a, b, weight)
+}
+
+// AddEdge adds a new edge between two vertices and returns its ID.
+// Edges that already join the same vertices in either direction are handled by the parallel edge policy.
+func (g *UndirectedWeightedAdjacencyMatrix) AddEdge(a string, b string, weight int) (int, error) {
+	return g.addEdge(a, b, weight)
+}
+
+// AddEdge adds a new edge between two vertices and returns its ID.
+func (g *UndirectedAdjacencyList) AddEdge(a string, b string) (int, error) {
+	return g.addEdge(a, b, 1)
+}
+
+// AddEdge adds a new edge between two vertices and returns its ID.
+func (g *UndirectedAdjacencyMatrix) AddEdge(a string, b string) (int, error) {
+	return g.addEdge(a, b, 1)
+}
+
+// Generic undirected graph, keeping every edge as a pair of opposite edges sharing its ID in a directed graph of type G,
+// a self loop once. Conversions copy the directed graph.
+type undirectedGraph[G any, P interface {
+	*G
+	DirectedWeightedGraph
+}] struct {
+	graphWrapper[G, P]
+	// Each edge once, in the direction it was added.
+	undirected []*Edge
+	// The ID given to the last edge added, the directed graph numbers its edges on its own.
+	lastID int
+}
+
+// Adds an edge in both directions under one new ID.
+func (g *undirectedGraph[G, P]) addEdge(a string, b string, weight int) (int, error) {
+	inner := g.inner()
+	if inner.getVertex(a) == nil || inner.getVertex(b) == nil {
+		return -1, errors.New("Vertices do not exist in graph: " + a + ", " + b)
+	}
+	replaced, err := parallelEdgesHelper(g.EdgesBetween(a, b), inner.getParallelEdgePolicy(), a+"-"+b)
+	if err != nil {
+		return -1, err
+	}
+	for _, edge := range replaced {
+		g.RemoveEdgeByID(edge.id)
+	}
+	id := g.lastID + 1
+	if _, err := inner.AddEdge(a, b, weight); err != nil {
+		return -1, err
+	}
+	forward := inner.EdgesBetween(a, b)
+	forward[len(forward)-1].id = id
+	if a != b {
+		if _, err := inner.AddEdge(b, a, weight); err != nil {
+			inner.RemoveEdgeByID(id)
+			return -1, err
+		}
+		backward := inner.EdgesBetween(b, a)
+		backward[len(backward)-1].id = id
+	}
+	g.lastID = id
+	g.undirected = append(g.undirected, forward[len(forward)-1])
+	return id, nil
+}
+
+// RemoveVertex removes a vertex and the edges touching it from the graph.
+func (g *undirectedGraph[G, P]) RemoveVertex(value string) error {
+	err := g.inner().RemoveVertex(value)
+	if err != nil {
+		return err
+	}
+	g.undirected = removeFromEdgesArrayContaining(g.undirected, value)
+	return nil
+}
+
+// RemoveEdge removes an edge between two vertices, whichever direction it was added in.
+// Removes the first one added if there are parallel edges.
+func (g *undirectedGraph[G, P]) RemoveEdge(a string, b string) error {
+	edges := g.EdgesBetween(a, b)
+	if len(edges) == 0 {
+		return errors.New("Edge does not exist in graph: " + a + "-" + b)
+	}
+	return g.RemoveEdgeByID(edges[0].id)
+}
+
+// RemoveEdgeByID removes the edge with the given ID from the graph.
+func (g *undirectedGraph[G, P]) RemoveEdgeByID(id int) error {
+	edge := getEdgeByIDHelper(g.undirected, id)
+	if edge == nil {
+		return errors.New("Edge does not exist in graph: " + strconv.Itoa(id))
+	}
+	g.inner().RemoveEdgeByID(id)
+	if edge.vertices[0] != edge.vertices[1] {
+		g.inner().RemoveEdgeByID(id)
+	}
+	g.undirected = removeEdgeFromArray(g.undirected, edge)
+	return nil
+}
+
+// EdgesBetween returns the edges between two vertices in insertion order, each in the direction it was added.
+func (g *undirectedGraph[G, P]) EdgesBetween(a string, b string) []*Edge {
+	result := make([]*Edge, 0)
+	for _, edge := range g.undirected {
+		if (edge.vertices[0] == a && edge.vertices[1] == b) || (edge.vertices[0] == b && edge.vertices[1] == a) {
+			result = append(result, edge)
+		}
+	}
+	return result
+}
+
+// Clear removes all nodes from the graph.
+func (g *undirectedGraph[G, P]) Clear() {
+	g.inner().Clear()
+	g.undirected = make([]*Edge, 0)
+}
+
+// NumberOfEdges returns the number of edges in the graph.
+func (g *undirectedGraph[G, P]) NumberOfEdges() int {
+	return len(g.undirected)
+}
+
+// Edges returns an iterator over the edges of the graph in insertion order, each in the direction it was added.
+func (g *undirectedGraph[G, P]) Edges() iter.Seq[*Edge] {
+	return edgesSeq(g.inner(), g.undirected)
+}
+
+// OutgoingEdges returns an iterator over the edges touching a vertex, each leaving it, to read their attributes.
+func (g *undirectedGraph[G, P]) OutgoingEdges(value string) iter.Seq[*Edge] {
+	return g.inner().OutgoingEdges(value)
+}
+
+// EdgeAttribute returns the value of a named attribute of an edge, from either end, false if it has not been set.
+func (g *undirectedGraph[G, P]) EdgeAttribute(a string, b string, name string) (any, bool) {
+	return g.inner().EdgeAttribute(a, b, name)
+}
+
+// SetEdgeAttribute sets the value of a named attribute of an edge, from either end.
+func (g *undirectedGraph[G, P]) SetEdgeAttribute(a string, b string, name string, attribute any) error {
+	err := g.inner().SetEdgeAttribute(a, b, name, attribute)
+	if err != nil {
+		return err
+	}
+	if a != b {
+		g.inner().SetEdgeAttribute(b, a, name, attribute)
+	}
+	return nil
+}
+
+// SetEdgeFloatWeight sets a float64 weight on an edge, from either end.
+func (g *undirectedGraph[G, P]) SetEdgeFloatWeight(a string, b string, weight float64) error {
+	err := g.inner().SetEdgeFloatWeight(a, b, weight)
+	if err != nil {
+		return err
+	}
+	if a != b {
+		g.inner().SetEdgeFloatWeight(b, a, weight)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package structures_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"../structures"
+)
+
+func TestAllPairsPaths(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testAllPairsPaths(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testAllPairsPaths(list, t)
+}
+
+func testAllPairsPaths(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToGraphA(graph, t)
+	graph.AddVertex("h")
+	for _, algorithm := range []func(structures.Graph) (*structures.AllPairsPaths, error){structures.FloydWarshall, structures.Johnson} {
+		paths, err := algorithm(graph)
+		testError(err, t)
+		if !reflect.DeepEqual(paths.Vertices(), []string{"a", "b", "c", "d", "e", "f", "g", "h"}) {
+			t.Errorf("Vertices incorrect, got %v", paths.Vertices())
+		}
+		// Every row should agree with Dijkstra's algorithm.
+		for _, source := range paths.Vertices() {
+			tree, err := structures.ShortestPaths(graph, source)
+			testError(err, t)
+			testAllPairsRow(paths, tree, t)
+		}
+		path, err := paths.Path("a", "e")
+		testError(err, t)
+		if !reflect.DeepEqual(pathLabels(path), []string{"a", "c", "g", "e"}) || path[3].Distance != 22 {
+			t.Errorf("Path from A to E incorrect, got %v", pathLabels(path))
+		}
+		hop, err := paths.NextHop("a", "e")
+		testError(err, t)
+		if hop != "c" {
+			t.Errorf("Next hop from A to E should be C, got %s", hop)
+		}
+		if paths.Reachable("a", "h") || paths.Reachable("a", "z") {
+			t.Error("H and Z should not be reachable from A")
+		}
+		_, err = paths.Distance("h", "a")
+		if err == nil {
+			t.Error("Distance should throw error, A is unreachable from H")
+		}
+	}
+
+	resetToNegativeGraph(graph, t)
+	for _, algorithm := range []func(structures.Graph) (*structures.AllPairsPaths, error){structures.FloydWarshall, structures.Johnson} {
+		paths, err := algorithm(graph)
+		testError(err, t)
+		for _, source := range paths.Vertices() {
+			tree, err := structures.BellmanFord(graph, source)
+			testError(err, t)
+			testAllPairsRow(paths, tree, t)
+		}
+	}
+
+	// Unlike BellmanFord, negative cycles are reported even when unreachable from the first vertex.
+	graph.AddAllVertices([]string{"x", "y", "z"})
+	graph.AddEdge("x", "y", 1)
+	graph.AddEdge("y", "z", -3)
+	graph.AddEdge("z", "x", 1)
+	for _, algorithm := range []func(structures.Graph) (*structures.AllPairsPaths, error){structures.FloydWarshall, structures.Johnson} {
+		_, err := algorithm(graph)
+		var cycleErr *structures.NegativeCycleError
+		if !errors.As(err, &cycleErr) {
+			t.Fatalf("All pairs should throw a NegativeCycleError, got %v", err)
+		}
+		testCycleRotation(cycleErr.Cycle, []string{"x", "y", "z"}, t)
+	}
+}
+
+func testAllPairsRow(paths *structures.AllPairsPaths, tree *structures.PathTree, t *testing.T) {
+	source := tree.Source()
+	for _, target := range paths.Vertices() {
+		if paths.Reachable(source, target) != tree.Reachable(target) {
+			t.Errorf("Reachability from %s to %s incorrect", source, target)
+			continue
+		}
+		if !tree.Reachable(target) {
+			continue
+		}
+		expected, _ := tree.DistanceTo(target)
+		distance, err := paths.Distance(source, target)
+		testError(err, t)
+		path, err := paths.Path(source, target)
+		testError(err, t)
+		if distance != expected || path[len(path)-1].Distance != expected {
+			t.Errorf("Distance from %s to %s should be %d, got %d", source, target, expected, distance)
+		}
+	}
+}
+
+func pathLabels(path []*structures.DijkstraResult) []string {
+	labels := make([]string, 0)
+	for _, item := range path {
+		labels = append(labels, item.Value)
+	}
+	return labels
+}
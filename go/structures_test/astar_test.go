@@ -0,0 +1,131 @@
+package structures_test
+
+import (
+	"fmt"
+	"testing"
+
+	"../structures"
+)
+
+func TestAStar(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testAStar(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testAStar(list, t)
+}
+
+func testAStar(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToGrid(graph, 10, 10, t)
+	expected, err := structures.ShortestPaths(graph, "0,0")
+	testError(err, t)
+
+	manhattan := func(vertex string) int {
+		var x, y int
+		fmt.Sscanf(vertex, "%d,%d", &x, &y)
+		return abs(9-x) + abs(0-y)
+	}
+	zero := func(vertex string) int { return 0 }
+	path, expandedManhattan, err := structures.AStar(graph, "0,0", "9,0", manhattan)
+	testError(err, t)
+	testGridPath(path, "0,0", "9,0", expected, t)
+	path, expandedZero, err := structures.AStar(graph, "0,0", "9,0", zero)
+	testError(err, t)
+	testGridPath(path, "0,0", "9,0", expected, t)
+	if expandedManhattan >= expandedZero {
+		t.Errorf("Manhattan heuristic should expand fewer vertices, got %d and %d", expandedManhattan, expandedZero)
+	}
+
+	// Paths to every vertex agree with Dijkstra's algorithm.
+	for target := range graph.Vertices() {
+		path, _, err := structures.AStar(graph, "0,0", target, zero)
+		testError(err, t)
+		testGridPath(path, "0,0", target, expected, t)
+		path, _, err = structures.BidirectionalDijkstra(graph, "0,0", target)
+		testError(err, t)
+		testGridPath(path, "0,0", target, expected, t)
+	}
+
+	// The bidirectional search meets in the middle rather than exploring the whole grid.
+	_, expanded, err := structures.BidirectionalDijkstra(graph, "0,0", "4,0")
+	testError(err, t)
+	if expanded >= graph.NumberOfVertices() {
+		t.Errorf("Bidirectional search expanded too many vertices, got %d", expanded)
+	}
+
+	graph.AddVertex("island")
+	_, _, err = structures.AStar(graph, "0,0", "island", zero)
+	if err == nil {
+		t.Error("AStar should throw error, the island is unreachable")
+	}
+	_, _, err = structures.BidirectionalDijkstra(graph, "0,0", "island")
+	if err == nil {
+		t.Error("BidirectionalDijkstra should throw error, the island is unreachable")
+	}
+	_, _, err = structures.AStar(graph, "0,0", "z", zero)
+	if err == nil {
+		t.Error("AStar should throw error, vertex Z does not exist")
+	}
+	graph.AddEdge("island", "0,0", -1)
+	_, _, err = structures.BidirectionalDijkstra(graph, "0,0", "9,0")
+	if err == nil {
+		t.Error("BidirectionalDijkstra should throw error, the graph has negative edges")
+	}
+}
+
+// Creates a grid where every cell is connected to its horizontal and vertical neighbours in both directions.
+// A wall in the middle column leaves a gap in the last row, so paths across the grid must go around it.
+func resetToGrid(graph structures.DirectedWeightedGraph, width int, height int, t *testing.T) {
+	graph.Clear()
+	wall := func(x int, y int) bool {
+		return x == width/2 && y < height-1
+	}
+	for y := range height {
+		for x := range width {
+			if !wall(x, y) {
+				graph.AddVertex(fmt.Sprintf("%d,%d", x, y))
+			}
+		}
+	}
+	for y := range height {
+		for x := range width {
+			if wall(x, y) {
+				continue
+			}
+			if x+1 < width && !wall(x+1, y) {
+				graph.AddEdge(fmt.Sprintf("%d,%d", x, y), fmt.Sprintf("%d,%d", x+1, y), 1)
+				graph.AddEdge(fmt.Sprintf("%d,%d", x+1, y), fmt.Sprintf("%d,%d", x, y), 1)
+			}
+			if y+1 < height && !wall(x, y+1) {
+				graph.AddEdge(fmt.Sprintf("%d,%d", x, y), fmt.Sprintf("%d,%d", x, y+1), 1)
+				graph.AddEdge(fmt.Sprintf("%d,%d", x, y+1), fmt.Sprintf("%d,%d", x, y), 1)
+			}
+		}
+	}
+	testGraphNumberOfVertices(graph, width*height-(height-1), t)
+}
+
+// Checks that a path starts and ends at the right vertices, only moves between adjacent cells and is shortest.
+func testGridPath(path []*structures.DijkstraResult, source string, target string, expected *structures.PathTree, t *testing.T) {
+	distance, _ := expected.DistanceTo(target)
+	if path[0].Value != source || path[len(path)-1].Value != target || path[len(path)-1].Distance != distance {
+		t.Errorf("Path from %s to %s incorrect, got %v", source, target, pathLabels(path))
+		return
+	}
+	for i := 1; i < len(path); i++ {
+		var x1, y1, x2, y2 int
+		fmt.Sscanf(path[i-1].Value, "%d,%d", &x1, &y1)
+		fmt.Sscanf(path[i].Value, "%d,%d", &x2, &y2)
+		if abs(x1-x2)+abs(y1-y2) != 1 || path[i].Distance != i {
+			t.Errorf("Path from %s to %s is not contiguous, got %v", source, target, pathLabels(path))
+			return
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,252 @@
+package structures_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"../structures"
+)
+
+func TestAttributes(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testAttributes(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testAttributes(list, t)
+}
+
+func testAttributes(graph structures.DirectedWeightedGraph, t *testing.T) {
+	type point struct{ x, y int }
+	resetToGraphA(graph, t)
+	testError(graph.SetVertexAttribute("a", "label", "Start"), t)
+	testError(graph.SetVertexAttribute("a", "position", point{1, 2}), t)
+	if label, ok := graph.VertexAttribute("a", "label"); !ok || label != "Start" {
+		t.Errorf("Label of A should be Start, got %v", label)
+	}
+	if position, _ := graph.VertexAttribute("a", "position"); position.(point) != (point{1, 2}) {
+		t.Errorf("Position of A should be {1 2}, got %v", position)
+	}
+	if _, ok := graph.VertexAttribute("b", "label"); ok {
+		t.Error("B should not have a label")
+	}
+	if graph.SetVertexAttribute("z", "label", "End") == nil {
+		t.Error("SetVertexAttribute should throw error, z does not exist")
+	}
+
+	// Attributes are visible through every way of reaching the edge.
+	testError(graph.SetEdgeAttribute("a", "c", "colour", "red"), t)
+	if colour, ok := graph.EdgeAttribute("a", "c", "colour"); !ok || colour != "red" {
+		t.Errorf("Colour of a->c should be red, got %v", colour)
+	}
+	for edge := range graph.OutgoingEdges("a") {
+		colour, ok := edge.Attribute("colour")
+		if ok != (edge.To() == "c") || (ok && colour != "red") {
+			t.Errorf("Only a->c should be red, got %v for a->%s", colour, edge.To())
+		}
+	}
+	for edge := range graph.Edges() {
+		if _, ok := edge.Attribute("colour"); ok != (edge.From() == "a" && edge.To() == "c") {
+			t.Errorf("Only a->c should have a colour, got one for %s->%s", edge.From(), edge.To())
+		}
+	}
+	if _, ok := graph.EdgeAttribute("c", "a", "colour"); ok {
+		t.Error("c->a does not exist and should have no colour")
+	}
+	if graph.SetEdgeAttribute("c", "a", "colour", "blue") == nil {
+		t.Error("SetEdgeAttribute should throw error, c->a does not exist")
+	}
+
+	// Removed edges keep their ends and attributes.
+	var removed *structures.Edge
+	for edge := range graph.OutgoingEdges("a") {
+		if edge.To() == "c" {
+			removed = edge
+		}
+	}
+	graph.RemoveEdge("a", "c")
+	if removed.From() != "a" || removed.To() != "c" || removed.Weight() != 10 {
+		t.Errorf("Removed edge should still be a->c with weight 10, got %s->%s with weight %d", removed.From(), removed.To(), removed.Weight())
+	}
+	if _, ok := graph.EdgeAttribute("a", "c", "colour"); ok {
+		t.Error("Removed edge should not be found")
+	}
+
+	// Parallel edges are reached by their IDs.
+	first, _ := graph.AddEdge("a", "c", 10)
+	second, _ := graph.AddEdge("a", "c", 5)
+	testError(graph.SetEdgeAttributeByID(second, "colour", "blue"), t)
+	testError(graph.SetEdgeFloatWeightByID(second, 2.5), t)
+	if _, ok := graph.EdgeAttributeByID(first, "colour"); ok {
+		t.Error("First a->c should have no colour")
+	}
+	if colour, ok := graph.EdgeAttributeByID(second, "colour"); !ok || colour != "blue" {
+		t.Errorf("Colour of second a->c should be blue, got %v", colour)
+	}
+	for _, edge := range graph.EdgesBetween("a", "c") {
+		if edge.ID() == second && edge.FloatWeight() != 2.5 {
+			t.Errorf("Float weight of second a->c should be 2.5, got %v", edge.FloatWeight())
+		}
+	}
+	if graph.SetEdgeAttributeByID(-5, "colour", "blue") == nil {
+		t.Error("SetEdgeAttributeByID should throw error, edge -5 does not exist")
+	}
+	if graph.SetEdgeFloatWeightByID(-5, 1.5) == nil {
+		t.Error("SetEdgeFloatWeightByID should throw error, edge -5 does not exist")
+	}
+}
+
+func TestShortestPathsBy(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testShortestPathsBy(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testShortestPathsBy(list, t)
+}
+
+func testShortestPathsBy(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToGraphA(graph, t)
+	// Float weights default to the integer weights.
+	paths, err := structures.ShortestPathsBy(graph, "a", "")
+	testError(err, t)
+	testFloatPathTo(paths, "e", []string{"a", "c", "g", "e"}, []float64{0, 10, 16, 22}, t)
+
+	testError(graph.SetEdgeFloatWeight("a", "c", 0.5), t)
+	for edge := range graph.OutgoingEdges("a") {
+		if edge.To() == "c" && (edge.FloatWeight() != 0.5 || edge.Weight() != 10) {
+			t.Errorf("a->c should have float weight 0.5 and weight 10, got %v and %d", edge.FloatWeight(), edge.Weight())
+		}
+	}
+	paths, err = structures.ShortestPathsBy(graph, "a", "")
+	testError(err, t)
+	testFloatPathTo(paths, "e", []string{"a", "c", "g", "e"}, []float64{0, 0.5, 6.5, 12.5}, t)
+
+	// Travel times are the weights, except that c->g is congested.
+	for edge := range graph.Edges() {
+		testError(graph.SetEdgeAttribute(edge.From(), edge.To(), "time", edge.Weight()), t)
+	}
+	graph.SetEdgeAttribute("c", "g", "time", 20.0)
+	paths, err = structures.ShortestPathsBy(graph, "a", "time")
+	testError(err, t)
+	testFloatPathTo(paths, "e", []string{"a", "f", "d", "g", "e"}, []float64{0, 7, 17, 23, 29}, t)
+	// The other shortest path algorithms read the weights the same way.
+	paths, err = structures.BellmanFordBy(graph, "a", "time")
+	testError(err, t)
+	testFloatPathTo(paths, "e", []string{"a", "f", "d", "g", "e"}, []float64{0, 7, 17, 23, 29}, t)
+	path, _, err := structures.AStarBy(graph, "a", "e", "time", func(vertex string) float64 { return 0 })
+	testError(err, t)
+	if labels := floatPathLabels(path); !reflect.DeepEqual(labels, []string{"a", "f", "d", "g", "e"}) || path[len(path)-1].Distance != 29 {
+		t.Errorf("A* path to e should be [a f d g e] with distance 29, got %v", labels)
+	}
+	allPairs, err := structures.FloydWarshallBy(graph, "time")
+	testError(err, t)
+	if distance, _ := allPairs.Distance("a", "e"); distance != 29 {
+		t.Errorf("Floyd-Warshall distance from a to e should be 29, got %v", distance)
+	}
+	path, err = allPairs.Path("a", "e")
+	testError(err, t)
+	if labels := floatPathLabels(path); !reflect.DeepEqual(labels, []string{"a", "f", "d", "g", "e"}) {
+		t.Errorf("Floyd-Warshall path to e should be [a f d g e], got %v", labels)
+	}
+
+	graph.SetEdgeAttribute("c", "g", "time", "slow")
+	if _, err := structures.ShortestPathsBy(graph, "a", "time"); err == nil {
+		t.Error("ShortestPathsBy should throw error, time of c->g is not a number")
+	}
+	graph.SetEdgeAttribute("c", "g", "time", -1)
+	if _, err := structures.ShortestPathsBy(graph, "a", "time"); err == nil {
+		t.Error("ShortestPathsBy should throw error, time of c->g is negative")
+	}
+	if _, _, err := structures.AStarBy(graph, "a", "e", "time", func(vertex string) float64 { return 0 }); err == nil {
+		t.Error("AStarBy should throw error, time of c->g is negative")
+	}
+	// Bellman-Ford and Floyd-Warshall allow negative weights, the cheapest way to g is now through c.
+	paths, err = structures.BellmanFordBy(graph, "a", "time")
+	testError(err, t)
+	testFloatPathTo(paths, "e", []string{"a", "c", "g", "e"}, []float64{0, 10, 9, 15}, t)
+	allPairs, err = structures.FloydWarshallBy(graph, "time")
+	testError(err, t)
+	if distance, _ := allPairs.Distance("a", "e"); distance != 15 {
+		t.Errorf("Floyd-Warshall distance from a to e should be 15, got %v", distance)
+	}
+	graph.SetEdgeAttribute("g", "a", "time", -10)
+	var cycleErr *structures.NegativeCycleError
+	if _, err := structures.BellmanFordBy(graph, "a", "time"); !errors.As(err, &cycleErr) {
+		t.Errorf("BellmanFordBy should throw a NegativeCycleError, got %v", err)
+	}
+	if _, err := structures.FloydWarshallBy(graph, "time"); !errors.As(err, &cycleErr) {
+		t.Errorf("FloydWarshallBy should throw a NegativeCycleError, got %v", err)
+	}
+	if _, err := structures.ShortestPathsBy(graph, "a", "cost"); err == nil {
+		t.Error("ShortestPathsBy should throw error, no edge has a cost")
+	}
+	if _, err := structures.BellmanFordBy(graph, "a", "cost"); err == nil {
+		t.Error("BellmanFordBy should throw error, no edge has a cost")
+	}
+	if _, _, err := structures.AStarBy(graph, "a", "e", "cost", func(vertex string) float64 { return 0 }); err == nil {
+		t.Error("AStarBy should throw error, no edge has a cost")
+	}
+	if _, err := structures.FloydWarshallBy(graph, "cost"); err == nil {
+		t.Error("FloydWarshallBy should throw error, no edge has a cost")
+	}
+	if _, err := structures.ShortestPathsBy(graph, "z", ""); err == nil {
+		t.Error("ShortestPathsBy should throw error, z does not exist")
+	}
+}
+
+func TestUndirectedAttributes(t *testing.T) {
+	matrix := &structures.UndirectedWeightedAdjacencyMatrix{}
+	testUndirectedAttributes(matrix, t)
+
+	list := &structures.UndirectedWeightedAdjacencyList{}
+	testUndirectedAttributes(list, t)
+}
+
+func testUndirectedAttributes(graph structures.UndirectedWeightedGraph, t *testing.T) {
+	resetToUndirectedGraph(graph, t)
+	// Attributes can be set and read from either end.
+	testError(graph.SetEdgeAttribute("h", "a", "colour", "red"), t)
+	if colour, _ := graph.EdgeAttribute("a", "h", "colour"); colour != "red" {
+		t.Errorf("Colour of a-h should be red, got %v", colour)
+	}
+	testError(graph.SetEdgeFloatWeight("f", "e", 0.25), t)
+	// Setting an attribute by ID reaches both directions of the edge.
+	id, _ := graph.AddEdge("a", "h", 3)
+	testError(graph.SetEdgeAttributeByID(id, "colour", "blue"), t)
+	for _, end := range [][2]string{{"a", "h"}, {"h", "a"}} {
+		for edge := range graph.OutgoingEdges(end[0]) {
+			if colour, _ := edge.Attribute("colour"); edge.To() == end[1] && edge.ID() == id && colour != "blue" {
+				t.Errorf("Colour of %s-%s should be blue, got %v", end[0], end[1], colour)
+			}
+		}
+	}
+	if colour, _ := graph.EdgeAttributeByID(id, "colour"); colour != "blue" {
+		t.Errorf("Colour of second a-h should be blue, got %v", colour)
+	}
+	graph.RemoveEdgeByID(id)
+	paths, err := structures.ShortestPathsBy(graph, "a", "")
+	testError(err, t)
+	testFloatPathTo(paths, "e", []string{"a", "h", "g", "f", "e"}, []float64{0, 8, 9, 11, 11.25}, t)
+}
+
+func testFloatPathTo(paths *structures.PathTreeOf[float64], target string, expectedLabels []string, expectedDistances []float64, t *testing.T) {
+	path, err := paths.PathTo(target)
+	testError(err, t)
+	labels := make([]string, 0)
+	distances := make([]float64, 0)
+	for _, step := range path {
+		labels = append(labels, step.Value)
+		distances = append(distances, step.Distance)
+	}
+	if !reflect.DeepEqual(labels, expectedLabels) || !reflect.DeepEqual(distances, expectedDistances) {
+		t.Errorf("Path to %s should be %v with distances %v, got %v and %v", target, expectedLabels, expectedDistances, labels, distances)
+	}
+}
+
+func floatPathLabels(path []*structures.DijkstraResultOf[float64]) []string {
+	labels := make([]string, 0, len(path))
+	for _, step := range path {
+		labels = append(labels, step.Value)
+	}
+	return labels
+}
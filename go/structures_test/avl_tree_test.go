@@ -0,0 +1,48 @@
+package structures_test
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	"../structures"
+)
+
+func TestAVLTree(t *testing.T) {
+	avl := &structures.AVLTree{}
+	testOrderedTree(avl, t)
+
+	// Sorted input would degrade a plain binary search tree into a list.
+	maxDepth := sortedInsertMaxDepth(avl, 1000)
+	if float64(maxDepth) > 1.44*math.Log2(1000) {
+		t.Errorf("AVL tree is too deep for 1000 sorted elements, got depth %d", maxDepth)
+	}
+	if err := avl.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+
+	/**
+	 * Inserting 1, 2, 3 rotates left:
+	 *   2
+	 *  / \
+	 * 1   3
+	 */
+	avl.Clear()
+	avl.InsertAll([]int{1, 2, 3})
+	if avl.Depth(2) != 0 || avl.Depth(1) != 1 || avl.Depth(3) != 1 {
+		t.Error("AVL tree should be rooted at 2 after inserting 1, 2, 3")
+	}
+	if avl.Height() != 2 {
+		t.Errorf("AVL tree height should be 2, got %d", avl.Height())
+	}
+
+	values := &structures.AVLTreeOf[string, int]{}
+	values.InsertValue("b", 2)
+	values.InsertValue("a", 1)
+	values.Put("c", 3)
+	values.Put("a", 10)
+	if !reflect.DeepEqual(slices.Collect(values.Values()), []int{10, 2, 3}) {
+		t.Errorf("Values incorrect, got %v", slices.Collect(values.Values()))
+	}
+}
@@ -0,0 +1,79 @@
+package structures_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"../structures"
+)
+
+func TestBellmanFord(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testBellmanFord(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testBellmanFord(list, t)
+}
+
+func testBellmanFord(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToNegativeGraph(graph, t)
+	_, err := structures.ShortestPaths(graph, "s")
+	if err == nil {
+		t.Error("ShortestPaths should throw error, the graph has negative edges")
+	}
+	_, err = structures.BellmanFord(graph, "z")
+	if err == nil {
+		t.Error("BellmanFord should throw error, vertex Z does not exist")
+	}
+
+	paths, err := structures.BellmanFord(graph, "s")
+	testError(err, t)
+	testPathTo(paths, "d", []string{"s", "b", "c", "a", "d"}, []int{0, 7, 4, 2, -2}, t)
+	testPathTo(paths, "c", []string{"s", "b", "c"}, []int{0, 7, 4}, t)
+
+	// Negative cycles are only reported when reachable from the source.
+	graph.AddAllVertices([]string{"x", "y", "z"})
+	graph.AddEdge("x", "y", 1)
+	graph.AddEdge("y", "z", -3)
+	graph.AddEdge("z", "x", 1)
+	paths, err = structures.BellmanFord(graph, "s")
+	testError(err, t)
+	if paths.Reachable("x") {
+		t.Error("X should not be reachable from S")
+	}
+
+	graph.AddEdge("d", "x", 1)
+	_, err = structures.BellmanFord(graph, "s")
+	var cycleErr *structures.NegativeCycleError
+	if !errors.As(err, &cycleErr) {
+		t.Fatalf("BellmanFord should throw a NegativeCycleError, got %v", err)
+	}
+	testCycleRotation(cycleErr.Cycle, []string{"x", "y", "z"}, t)
+}
+
+// Negative edges without negative cycles.
+func resetToNegativeGraph(graph structures.DirectedWeightedGraph, t *testing.T) {
+	graph.Clear()
+	graph.AddAllVertices([]string{"s", "a", "b", "c", "d"})
+	graph.AddEdge("s", "a", 6)
+	graph.AddEdge("s", "b", 7)
+	graph.AddEdge("a", "b", 8)
+	graph.AddEdge("a", "c", 5)
+	graph.AddEdge("a", "d", -4)
+	graph.AddEdge("b", "c", -3)
+	graph.AddEdge("b", "d", 9)
+	graph.AddEdge("c", "a", -2)
+	graph.AddEdge("d", "s", 2)
+	graph.AddEdge("d", "c", 7)
+	testGraphNumberOfVertices(graph, 5, t)
+	testGraphNumberOfEdges(graph, 10, t)
+}
+
+// Cycles may start at any of their vertices.
+func testCycleRotation(cycle []string, expected []string, t *testing.T) {
+	start := slices.Index(cycle, expected[0])
+	if len(cycle) != len(expected) || start < 0 || !slices.Equal(slices.Concat(cycle[start:], cycle[:start]), expected) {
+		t.Errorf("Cycle should be a rotation of %v, got %v", expected, cycle)
+	}
+}
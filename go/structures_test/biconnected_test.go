@@ -0,0 +1,72 @@
+package structures_test
+
+import (
+	"reflect"
+	"testing"
+
+	"../structures"
+)
+
+func TestBiconnectivity(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testBiconnectivity(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testBiconnectivity(list, t)
+}
+
+func testBiconnectivity(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToGraphA(graph, t)
+	testBiconnected(graph, []string{}, []string{}, [][]string{{"a", "b", "c", "d", "e", "f", "g"}}, t)
+
+	// A triangle hangs off a, and k hangs off the triangle. Opposite edges and self loops add no redundancy.
+	graph.AddAllVertices([]string{"h", "i", "j", "k", "l"})
+	graph.AddEdge("a", "h", 1)
+	graph.AddEdge("h", "i", 1)
+	graph.AddEdge("i", "h", 1)
+	graph.AddEdge("i", "j", 1)
+	graph.AddEdge("j", "h", 1)
+	graph.AddEdge("k", "h", 1)
+	graph.AddEdge("h", "k", 1)
+	graph.AddEdge("k", "k", 1)
+	testBiconnected(graph, []string{"a", "h"}, []string{"a-h", "k-h"},
+		[][]string{{"a", "b", "c", "d", "e", "f", "g"}, {"a", "h"}, {"h", "i", "j"}, {"h", "k"}}, t)
+
+	// Cutting i-j leaves i and j hanging off h.
+	graph.RemoveEdge("i", "j")
+	testBiconnected(graph, []string{"a", "h"}, []string{"a-h", "h-i", "j-h", "k-h"},
+		[][]string{{"a", "b", "c", "d", "e", "f", "g"}, {"a", "h"}, {"h", "i"}, {"h", "j"}, {"h", "k"}}, t)
+
+	// The only way around the wall is through the bottom row.
+	resetToGrid(graph, 6, 5, t)
+	testBiconnected(graph, []string{"2,4", "3,4", "4,4"}, []string{"2,4-3,4", "3,4-4,4"}, nil, t)
+	components := structures.BiconnectedComponents(graph)
+	sizes := make([]int, 0)
+	for _, component := range components {
+		sizes = append(sizes, len(component))
+	}
+	if !reflect.DeepEqual(sizes, []int{15, 10, 2, 2}) {
+		t.Errorf("Grid biconnected components should have sizes [15 10 2 2], got %v", sizes)
+	}
+
+	graph.Clear()
+	testBiconnected(graph, []string{}, []string{}, [][]string{}, t)
+}
+
+// Checks articulation points, bridges as "from-to" and biconnected components, which are skipped if nil.
+func testBiconnected(graph structures.DirectedWeightedGraph, expectedPoints []string, expectedBridges []string, expectedComponents [][]string, t *testing.T) {
+	points := structures.ArticulationPoints(graph)
+	if !reflect.DeepEqual(points, expectedPoints) {
+		t.Errorf("Articulation points should be %v, got %v", expectedPoints, points)
+	}
+	bridges := make([]string, 0)
+	for _, edge := range structures.Bridges(graph) {
+		bridges = append(bridges, edge.From()+"-"+edge.To())
+	}
+	if !reflect.DeepEqual(bridges, expectedBridges) {
+		t.Errorf("Bridges should be %v, got %v", expectedBridges, bridges)
+	}
+	if components := structures.BiconnectedComponents(graph); expectedComponents != nil && !reflect.DeepEqual(components, expectedComponents) {
+		t.Errorf("Biconnected components should be %v, got %v", expectedComponents, components)
+	}
+}
@@ -1,7 +1,9 @@
 package structures_test
 
 import (
+	"math/rand/v2"
 	"reflect"
+	"slices"
 	"testing"
 
 	"../structures"
@@ -110,13 +112,11 @@ func TestBinaryMaxHeap(t *testing.T) {
 	}
 
 	// Test duplicate insertion.
-	err = heap.Insert(3)
-	testError(err, t)
-	err = heap.Insert(3)
-	if err == nil {
-		t.Error("Heap should an error, it already contains 3")
-	}
-	testHeapSize(heap, 1, t)
+	heap.Insert(3)
+	heap.Insert(3)
+	testHeapSize(heap, 2, t)
+	testRemoveTop(heap, 3, t)
+	testRemoveTop(heap, 3, t)
 
 	// Test heapsort.
 	heap.Clear()
@@ -153,3 +153,172 @@ func testHeapSize(heap *structures.BinaryHeap, expected int, t *testing.T) {
 		t.Errorf("Heap size should be %d, got %d", expected, heap.Size())
 	}
 }
+
+func TestGenericBinaryHeap(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+	heap := structures.NewBinaryHeapFunc(structures.MaxHeap, func(a task, b task) int {
+		return a.priority - b.priority
+	})
+	heap.InsertAll([]task{{"low", 1}, {"high", 10}, {"medium", 5}})
+	result := make([]string, 0)
+	for !heap.IsEmpty() {
+		value, err := heap.RemoveTop()
+		testError(err, t)
+		result = append(result, value.name)
+	}
+	if !reflect.DeepEqual(result, []string{"high", "medium", "low"}) {
+		t.Errorf("Tasks should be removed by priority, got %v", result)
+	}
+
+	words := structures.NewBinaryHeap[string](structures.MinHeap)
+	words.InsertAll([]string{"pear", "apple", "fig"})
+	top, err := words.Top()
+	testError(err, t)
+	if top != "apple" {
+		t.Errorf("Top incorrect, expected apple, got %s", top)
+	}
+
+	// Without a comparator, built-in types are ordered naturally.
+	zero := &structures.BinaryHeapOf[float64]{HeapType: structures.MaxHeap}
+	zero.InsertAll([]float64{0.5, 2.5, -1})
+	zero.Insert(1.5)
+	if !reflect.DeepEqual(slices.Collect(zero.All()), []float64{2.5, 1.5, 0.5, -1}) {
+		t.Errorf("Heap without a comparator should order naturally, got %v", slices.Collect(zero.All()))
+	}
+}
+
+func TestBinaryHeapIterator(t *testing.T) {
+	heap := &structures.BinaryHeap{HeapType: structures.MaxHeap}
+	heap.InsertAll([]int{5, 2, 9, -1, -2, 10, -10, 30, 3})
+
+	if !reflect.DeepEqual(slices.Collect(heap.All()), []int{30, 10, 9, 5, 3, 2, -1, -2, -10}) {
+		t.Errorf("All should list elements in priority order, got %v", slices.Collect(heap.All()))
+	}
+	testHeapSize(heap, 9, t)
+	testTop(heap, 30, t)
+
+	top := make([]int, 0)
+	for value := range heap.All() {
+		top = append(top, value)
+		if len(top) == 3 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(top, []int{30, 10, 9}) {
+		t.Errorf("Iteration should stop after 3 elements, got %v", top)
+	}
+
+	testModifiedDuringIteration(func() {
+		for range heap.All() {
+			heap.RemoveTop()
+		}
+	}, t)
+}
+
+func TestBinaryHeapHandles(t *testing.T) {
+	heap := &structures.BinaryHeap{HeapType: structures.MinHeap}
+	handles := heap.Heapify([]int{50, 20, 40, 10, 30})
+	testHeapSize(heap, 5, t)
+	testTop(heap, 10, t)
+	if handles[2].Value() != 40 {
+		t.Errorf("Handle should refer to 40, got %d", handles[2].Value())
+	}
+
+	// Update moves elements in either direction.
+	testError(heap.Update(handles[2], 5), t)
+	testTop(heap, 5, t)
+	testError(heap.Update(handles[2], 60), t)
+	testTop(heap, 10, t)
+
+	// DecreaseKey only accepts a higher priority.
+	testError(heap.DecreaseKey(handles[0], 1), t)
+	testTop(heap, 1, t)
+	if heap.DecreaseKey(handles[1], 25) == nil {
+		t.Error("DecreaseKey should throw error, 25 has a lower priority than 20")
+	}
+
+	// Remove by handle, then the handle is stale.
+	value, err := heap.Remove(handles[3])
+	testError(err, t)
+	if value != 10 {
+		t.Errorf("Remove incorrect, expected 10, got %d", value)
+	}
+	_, err = heap.Remove(handles[3])
+	if err == nil {
+		t.Error("Remove should throw error, the handle was already removed")
+	}
+	if heap.Update(handles[3], 0) == nil {
+		t.Error("Update should throw error, the handle was already removed")
+	}
+	if !reflect.DeepEqual(slices.Collect(heap.All()), []int{1, 20, 30, 60}) {
+		t.Errorf("Heap contents incorrect, got %v", slices.Collect(heap.All()))
+	}
+
+	// Heapify replaces the contents and invalidates old handles.
+	heap.Heapify([]int{3, 3, 1})
+	if heap.Update(handles[0], 0) == nil {
+		t.Error("Update should throw error, the heap was rebuilt")
+	}
+	testRemoveTop(heap, 1, t)
+	testRemoveTop(heap, 3, t)
+	testRemoveTop(heap, 3, t)
+}
+
+func TestBinaryHeapMerge(t *testing.T) {
+	heap := &structures.BinaryHeap{HeapType: structures.MaxHeap}
+	heap.InsertAll([]int{1, 7, 3})
+	other := &structures.BinaryHeap{HeapType: structures.MaxHeap}
+	handles := other.InsertAll([]int{4, 9, 7})
+
+	heap.Merge(other)
+	testHeapSize(heap, 6, t)
+	if !other.IsEmpty() {
+		t.Error("Merged heap should be empty")
+	}
+	if other.Update(handles[1], 0) == nil {
+		t.Error("Update should throw error, the handle moved to another heap")
+	}
+	testError(heap.Update(handles[1], 0), t)
+	if !reflect.DeepEqual(slices.Collect(heap.All()), []int{7, 7, 4, 3, 1, 0}) {
+		t.Errorf("Heap contents incorrect after merge, got %v", slices.Collect(heap.All()))
+	}
+}
+
+func TestBinaryHeapRandom(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	heap := &structures.BinaryHeap{HeapType: structures.MinHeap}
+	handles := make([]*structures.HeapHandle, 0)
+	expected := make([]int, 0)
+	for range 1000 {
+		switch op := rng.IntN(4); {
+		case op < 2 || len(handles) == 0:
+			value := rng.IntN(100)
+			handles = append(handles, heap.Insert(value))
+			expected = append(expected, value)
+		case op == 2:
+			index := rng.IntN(len(handles))
+			value := rng.IntN(100)
+			testError(heap.Update(handles[index], value), t)
+			expected[index] = value
+		default:
+			index := rng.IntN(len(handles))
+			_, err := heap.Remove(handles[index])
+			testError(err, t)
+			handles = slices.Delete(handles, index, index+1)
+			expected = slices.Delete(expected, index, index+1)
+		}
+	}
+	slices.Sort(expected)
+	result := make([]int, 0)
+	for !heap.IsEmpty() {
+		value, err := heap.RemoveTop()
+		testError(err, t)
+		result = append(result, value)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Error("Heap should remove elements in sorted order after random operations")
+	}
+}
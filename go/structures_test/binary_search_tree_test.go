@@ -2,7 +2,11 @@ package structures_test
 
 import (
 	"fmt"
+	"iter"
+	"maps"
+	"math/rand/v2"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
@@ -207,3 +211,265 @@ func testBSTSize(bst *structures.BinarySearchTree, expected int, t *testing.T) {
 func intSliceToString(slice []int) string {
 	return strings.Trim(strings.Replace(fmt.Sprint(slice), " ", ",", -1), "[]")
 }
+
+func TestGenericBinarySearchTree(t *testing.T) {
+	bst := &structures.BinarySearchTreeOf[string, int]{}
+	bst.InsertValue("m", 1)
+	bst.InsertValue("c", 2)
+	bst.InsertValue("x", 3)
+	err := bst.InsertValue("m", 4)
+	if err == nil {
+		t.Error("InsertValue should throw error, m already exists")
+	}
+	if !reflect.DeepEqual(bst.InOrder(), []string{"c", "m", "x"}) {
+		t.Errorf("In order check failed, got %v", bst.InOrder())
+	}
+
+	value, err := bst.Get("c")
+	testError(err, t)
+	if value != 2 {
+		t.Errorf("Get incorrect, expected 2, got %d", value)
+	}
+	bst.Put("c", 20)
+	bst.Put("a", 30)
+	value, _ = bst.Get("c")
+	if value != 20 {
+		t.Errorf("Put should override the value, expected 20, got %d", value)
+	}
+	if bst.Size() != 4 {
+		t.Errorf("Tree size should be 4, got %d", bst.Size())
+	}
+	_, err = bst.Get("z")
+	if err == nil {
+		t.Error("Get should throw error, z is not in the tree")
+	}
+}
+
+func TestBinarySearchTreeIterators(t *testing.T) {
+	bst := &structures.BinarySearchTree{}
+	resetToTreeA(bst, t)
+
+	if !reflect.DeepEqual(slices.Collect(bst.Keys()), []int{25, 30, 50, 60, 80, 85, 90}) {
+		t.Errorf("Keys incorrect, got %v", slices.Collect(bst.Keys()))
+	}
+	testSeqKeys(bst.Backward(), []int{90, 85, 80, 60, 50, 30, 25}, "Backward", t)
+	testSeqKeys(bst.PreOrder(), []int{50, 25, 30, 80, 60, 90, 85}, "PreOrder", t)
+	testSeqKeys(bst.PostOrder(), []int{30, 25, 60, 85, 90, 80, 50}, "PostOrder", t)
+	testSeqKeys(bst.LevelOrder(), []int{50, 25, 80, 30, 60, 90, 85}, "LevelOrder", t)
+
+	// Test early termination.
+	visited := make([]int, 0)
+	for key := range bst.All() {
+		visited = append(visited, key)
+		if key == 50 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(visited, []int{25, 30, 50}) {
+		t.Errorf("Iteration should stop at 50, visited %v", visited)
+	}
+
+	testModifiedDuringIteration(func() {
+		for key := range bst.PreOrder() {
+			bst.Delete(key)
+		}
+	}, t)
+
+	values := &structures.BinarySearchTreeOf[string, int]{}
+	values.InsertValue("b", 2)
+	values.InsertValue("a", 1)
+	values.InsertValue("c", 3)
+	for key := range values.Keys() {
+		// Overriding values is not a structural modification.
+		values.Put(key, 10)
+	}
+	if !reflect.DeepEqual(slices.Collect(values.Values()), []int{10, 10, 10}) {
+		t.Errorf("Values incorrect, got %v", slices.Collect(values.Values()))
+	}
+}
+
+func testSeqKeys(seq iter.Seq2[int, struct{}], expected []int, name string, t *testing.T) {
+	keys := make([]int, 0)
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("%s incorrect. Expected {%s} got {%s}", name, intSliceToString(expected), intSliceToString(keys))
+	}
+}
+
+// orderedTree is implemented by BinarySearchTree, AVLTree and RedBlackTree.
+type orderedTree interface {
+	Insert(elem int) error
+	InsertAll(elems []int) error
+	Delete(elem int) error
+	Search(elem int) bool
+	Depth(elem int) int
+	InOrder() []int
+	Size() int
+	Clear()
+	CheckInvariants() error
+}
+
+func TestBinarySearchTreeInvariants(t *testing.T) {
+	testOrderedTree(&structures.BinarySearchTree{}, t)
+}
+
+// Runs the tree fixtures through a tree, checking the invariants after every mutation.
+func testOrderedTree(tree orderedTree, t *testing.T) {
+	mutate := func(err error) {
+		testError(err, t)
+		if err := tree.CheckInvariants(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, fixture := range [][]int{BinarySearchTreeA, BinarySearchTreeB} {
+		tree.Clear()
+		for _, elem := range fixture {
+			mutate(tree.Insert(elem))
+		}
+		if tree.Insert(fixture[0]) == nil {
+			t.Errorf("Insert should throw error, %d already exists", fixture[0])
+		}
+		expected := slices.Sorted(slices.Values(fixture))
+		if !reflect.DeepEqual(tree.InOrder(), expected) {
+			t.Errorf("In order check failed. Expected {%s} got {%s}", intSliceToString(expected), intSliceToString(tree.InOrder()))
+		}
+		for index, elem := range fixture {
+			mutate(tree.Delete(elem))
+			if tree.Search(elem) || tree.Depth(elem) != -1 {
+				t.Errorf("Search should fail, %d was deleted", elem)
+			}
+			if tree.Size() != len(fixture)-index-1 {
+				t.Errorf("Tree size should be %d, got %d", len(fixture)-index-1, tree.Size())
+			}
+		}
+		if tree.Delete(fixture[0]) == nil {
+			t.Error("Delete should fail, the tree is empty")
+		}
+	}
+
+	// Random insertions and deletions checked against a map.
+	random := rand.New(rand.NewPCG(1, 2))
+	present := make(map[int]bool)
+	tree.Clear()
+	for i := 0; i < 2000; i++ {
+		elem := random.IntN(200)
+		if random.IntN(3) == 0 {
+			err := tree.Delete(elem)
+			if present[elem] {
+				mutate(err)
+			} else if err == nil {
+				t.Errorf("Delete should fail, %d is not in the tree", elem)
+			}
+			delete(present, elem)
+		} else {
+			err := tree.Insert(elem)
+			if !present[elem] {
+				mutate(err)
+			} else if err == nil {
+				t.Errorf("Insert should fail, %d is already in the tree", elem)
+			}
+			present[elem] = true
+		}
+	}
+	expected := slices.Sorted(maps.Keys(present))
+	if !reflect.DeepEqual(tree.InOrder(), expected) {
+		t.Errorf("In order check failed. Expected {%s} got {%s}", intSliceToString(expected), intSliceToString(tree.InOrder()))
+	}
+}
+
+// Inserts sorted input and returns the largest depth in the tree.
+func sortedInsertMaxDepth(tree orderedTree, n int) int {
+	tree.Clear()
+	maxDepth := 0
+	for i := 0; i < n; i++ {
+		tree.Insert(i)
+	}
+	for i := 0; i < n; i++ {
+		maxDepth = max(maxDepth, tree.Depth(i))
+	}
+	return maxDepth
+}
+
+func TestBinarySearchTreeOrderStatistics(t *testing.T) {
+	bst := &structures.BinarySearchTree{}
+	_, err := bst.Min()
+	if err == nil {
+		t.Error("Min should throw error, the tree is empty")
+	}
+	_, err = bst.Select(0)
+	if err == nil {
+		t.Error("Select should throw error, the tree is empty")
+	}
+
+	// In order: -30, -1, 10, 20, 30, 50, 100, 200, 300, 400.
+	resetToTreeB(bst, t)
+	sorted := bst.InOrder()
+	for k, expected := range sorted {
+		value, err := bst.Select(k)
+		testError(err, t)
+		if value != expected {
+			t.Errorf("Select(%d) incorrect, expected %d, got %d", k, expected, value)
+		}
+		if bst.Rank(expected) != k {
+			t.Errorf("Rank(%d) incorrect, expected %d, got %d", expected, k, bst.Rank(expected))
+		}
+	}
+	_, err = bst.Select(10)
+	if err == nil {
+		t.Error("Select should throw error, the tree only has 10 keys")
+	}
+	if bst.Rank(25) != 4 || bst.Rank(-100) != 0 || bst.Rank(1000) != 10 {
+		t.Error("Rank of missing keys incorrect")
+	}
+
+	testOrderQuery(bst.Min, -30, t)
+	testOrderQuery(bst.Max, 400, t)
+	testOrderQuery(func() (int, error) { return bst.Floor(25) }, 20, t)
+	testOrderQuery(func() (int, error) { return bst.Floor(30) }, 30, t)
+	testOrderQuery(func() (int, error) { return bst.Ceiling(25) }, 30, t)
+	testOrderQuery(func() (int, error) { return bst.Ceiling(30) }, 30, t)
+	testOrderQuery(func() (int, error) { return bst.Predecessor(30) }, 20, t)
+	testOrderQuery(func() (int, error) { return bst.Successor(30) }, 50, t)
+	testOrderQuery(func() (int, error) { return bst.Successor(55) }, 100, t)
+	testOrderQuery(func() (int, error) { return bst.Predecessor(0) }, -1, t)
+	if _, err := bst.Floor(-31); err == nil {
+		t.Error("Floor should throw error, no key is less than or equal to -31")
+	}
+	if _, err := bst.Ceiling(401); err == nil {
+		t.Error("Ceiling should throw error, no key is greater than or equal to 401")
+	}
+	if _, err := bst.Predecessor(-30); err == nil {
+		t.Error("Predecessor should throw error, -30 is the smallest key")
+	}
+	if _, err := bst.Successor(400); err == nil {
+		t.Error("Successor should throw error, 400 is the largest key")
+	}
+
+	testSeqKeys(bst.Range(0, 100), []int{10, 20, 30, 50, 100}, "Range", t)
+	testSeqKeys(bst.Range(-1, -1), []int{-1}, "Range", t)
+	testSeqKeys(bst.Range(500, 600), []int{}, "Range", t)
+	testSeqKeys(bst.Range(100, 0), []int{}, "Range", t)
+
+	// Subtree sizes are kept up to date by deletions.
+	bst.Delete(-1)
+	bst.Delete(300)
+	value, err := bst.Select(1)
+	testError(err, t)
+	if value != 10 {
+		t.Errorf("Select(1) incorrect after deleting, expected 10, got %d", value)
+	}
+	if bst.Rank(400) != 7 {
+		t.Errorf("Rank(400) incorrect after deleting, expected 7, got %d", bst.Rank(400))
+	}
+	testError(bst.CheckInvariants(), t)
+}
+
+func testOrderQuery(query func() (int, error), expected int, t *testing.T) {
+	value, err := query()
+	testError(err, t)
+	if value != expected {
+		t.Errorf("Order query incorrect, expected %d, got %d", expected, value)
+	}
+}
@@ -0,0 +1,267 @@
+package structures_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"../structures"
+)
+
+func TestIsBipartite(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testIsBipartite(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testIsBipartite(list, t)
+}
+
+func testIsBipartite(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToReviewers(graph, t)
+	bipartition, cycle := structures.IsBipartite(graph)
+	if bipartition == nil {
+		t.Fatalf("Reviewers should be bipartite, got odd cycle %v", cycle)
+	}
+	if !reflect.DeepEqual(bipartition.Left, []string{"alice", "bob", "carol", "dave"}) || !reflect.DeepEqual(bipartition.Right, []string{"pr1", "pr2", "pr3", "pr4"}) {
+		t.Errorf("Bipartition incorrect, got %v and %v", bipartition.Left, bipartition.Right)
+	}
+
+	// Grid cells are coloured like a chessboard.
+	resetToGrid(graph, 6, 5, t)
+	bipartition, _ = structures.IsBipartite(graph)
+	parity := func(vertex string) int {
+		var x, y int
+		fmt.Sscanf(vertex, "%d,%d", &x, &y)
+		return (x + y) % 2
+	}
+	for _, vertex := range bipartition.Left {
+		if parity(vertex) != 0 {
+			t.Errorf("Grid cell %s should be on the right", vertex)
+		}
+	}
+	for _, vertex := range bipartition.Right {
+		if parity(vertex) != 1 {
+			t.Errorf("Grid cell %s should be on the left", vertex)
+		}
+	}
+
+	resetToGraphA(graph, t)
+	bipartition, cycle = structures.IsBipartite(graph)
+	if bipartition != nil {
+		t.Error("Graph A should not be bipartite")
+	}
+	testOddCycle(graph, cycle, t)
+
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b"})
+	graph.AddEdge("a", "b", 1)
+	graph.AddEdge("b", "b", 1)
+	_, cycle = structures.IsBipartite(graph)
+	if !reflect.DeepEqual(cycle, []string{"b"}) {
+		t.Errorf("Self loop should be an odd cycle, got %v", cycle)
+	}
+}
+
+func TestHopcroftKarp(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testHopcroftKarp(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testHopcroftKarp(list, t)
+}
+
+func testHopcroftKarp(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToReviewers(graph, t)
+	matching, err := structures.HopcroftKarp(graph)
+	testError(err, t)
+	testMatching(graph, matching, 4, t)
+
+	// Alice and Carol can only review the same pull request.
+	graph.RemoveEdge("alice", "pr1")
+	graph.RemoveEdge("alice", "pr3")
+	graph.RemoveEdge("carol", "pr1")
+	graph.RemoveEdge("carol", "pr3")
+	matching, err = structures.HopcroftKarp(graph)
+	testError(err, t)
+	testMatching(graph, matching, 3, t)
+
+	resetToGraphA(graph, t)
+	_, err = structures.HopcroftKarp(graph)
+	if err == nil {
+		t.Error("HopcroftKarp should throw error, graph A is not bipartite")
+	}
+
+	// Matches as many pairs as a unit capacity flow network can carry.
+	rng := rand.New(rand.NewPCG(1, 2))
+	network := &structures.AdjacencyList{}
+	for range 50 {
+		graph.Clear()
+		network.Clear()
+		network.AddAllVertices([]string{"source", "sink"})
+		left, right := 1+rng.IntN(8), 1+rng.IntN(8)
+		for i := range left {
+			graph.AddVertex("l" + strconv.Itoa(i))
+			network.AddVertex("l" + strconv.Itoa(i))
+			network.AddEdge("source", "l"+strconv.Itoa(i), 1)
+		}
+		for j := range right {
+			graph.AddVertex("r" + strconv.Itoa(j))
+			network.AddVertex("r" + strconv.Itoa(j))
+			network.AddEdge("r"+strconv.Itoa(j), "sink", 1)
+		}
+		for range rng.IntN(2 * left * right) {
+			from, to := "l"+strconv.Itoa(rng.IntN(left)), "r"+strconv.Itoa(rng.IntN(right))
+			if rng.IntN(2) == 0 {
+				graph.AddEdge(from, to, 1)
+			} else {
+				graph.AddEdge(to, from, 1)
+			}
+			network.AddEdge(from, to, 1)
+		}
+		flow, _ := structures.Dinic(network, "source", "sink")
+		matching, err := structures.HopcroftKarp(graph)
+		testError(err, t)
+		testMatching(graph, matching, flow.Value, t)
+	}
+}
+
+func TestHungarian(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testHungarian(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testHungarian(list, t)
+}
+
+func testHungarian(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToReviewers(graph, t)
+	matching, err := structures.Hungarian(graph)
+	testError(err, t)
+	testMatching(graph, matching, 4, t)
+	if matching.Weight != 11 {
+		t.Errorf("Matching should weigh 11, got %d", matching.Weight)
+	}
+	expected := map[string]string{"alice": "pr2", "bob": "pr1", "carol": "pr3", "dave": "pr4"}
+	for reviewer, pr := range expected {
+		if matching.Mates[reviewer] != pr || matching.Mates[pr] != reviewer {
+			t.Errorf("%s should review %s, got %s", reviewer, pr, matching.Mates[reviewer])
+		}
+	}
+
+	// Alice and Carol can only review the same pull request.
+	graph.RemoveEdge("alice", "pr1")
+	graph.RemoveEdge("alice", "pr2")
+	graph.RemoveEdge("carol", "pr1")
+	graph.RemoveEdge("carol", "pr2")
+	_, err = structures.Hungarian(graph)
+	if err == nil {
+		t.Error("Hungarian should throw error, alice and carol cannot both be matched")
+	}
+	graph.AddVertex("erin")
+	_, err = structures.Hungarian(graph)
+	if err == nil {
+		t.Error("Hungarian should throw error, there are more reviewers than pull requests")
+	}
+
+	// Agrees with trying every assignment on random complete bipartite graphs.
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		graph.Clear()
+		n := 1 + rng.IntN(5)
+		weights := make([][]int, n)
+		for i := range n {
+			graph.AddVertex("l" + strconv.Itoa(i))
+		}
+		for j := range n {
+			graph.AddVertex("r" + strconv.Itoa(j))
+		}
+		for i := range n {
+			weights[i] = make([]int, n)
+			for j := range n {
+				weights[i][j] = rng.IntN(41) - 20
+				graph.AddEdge("l"+strconv.Itoa(i), "r"+strconv.Itoa(j), weights[i][j])
+			}
+		}
+		matching, err := structures.Hungarian(graph)
+		testError(err, t)
+		testMatching(graph, matching, n, t)
+		if best := minimumAssignment(weights, 0, make([]bool, n)); matching.Weight != best {
+			t.Errorf("Matching should weigh %d, got %d", best, matching.Weight)
+		}
+	}
+}
+
+// Four reviewers and four pull requests, weighted by the hours each review would take.
+func resetToReviewers(graph structures.DirectedWeightedGraph, t *testing.T) {
+	graph.Clear()
+	graph.AddAllVertices([]string{"alice", "bob", "carol", "dave", "pr1", "pr2", "pr3", "pr4"})
+	graph.AddEdge("alice", "pr1", 4)
+	graph.AddEdge("alice", "pr2", 1)
+	graph.AddEdge("alice", "pr3", 3)
+	graph.AddEdge("bob", "pr1", 2)
+	graph.AddEdge("bob", "pr2", 0)
+	graph.AddEdge("bob", "pr3", 5)
+	graph.AddEdge("bob", "pr4", 3)
+	graph.AddEdge("carol", "pr1", 3)
+	graph.AddEdge("carol", "pr2", 2)
+	graph.AddEdge("carol", "pr3", 2)
+	graph.AddEdge("dave", "pr3", 5)
+	graph.AddEdge("dave", "pr4", 6)
+	testGraphNumberOfVertices(graph, 8, t)
+	testGraphNumberOfEdges(graph, 12, t)
+}
+
+// Checks that a matching has the expected size, uses edges of the graph and matches each vertex at most once.
+func testMatching(graph structures.DirectedWeightedGraph, matching *structures.Matching, expected int, t *testing.T) {
+	if len(matching.Edges) != expected || len(matching.Mates) != 2*expected {
+		t.Errorf("Matching should have %d edges, got %d", expected, len(matching.Edges))
+	}
+	edges := make(map[*structures.Edge]bool)
+	for edge := range graph.Edges() {
+		edges[edge] = true
+	}
+	for _, edge := range matching.Edges {
+		if !edges[edge] {
+			t.Errorf("Matching edge %s->%s is not in the graph", edge.From(), edge.To())
+		}
+		if matching.Mates[edge.From()] != edge.To() || matching.Mates[edge.To()] != edge.From() {
+			t.Errorf("Matching edge %s->%s shares a vertex with another edge", edge.From(), edge.To())
+		}
+	}
+}
+
+// Checks that a cycle has odd length and that consecutive vertices are joined by an edge in either direction.
+func testOddCycle(graph structures.DirectedWeightedGraph, cycle []string, t *testing.T) {
+	if len(cycle)%2 == 0 {
+		t.Fatalf("Cycle should have odd length, got %v", cycle)
+	}
+	adjacent := make(map[[2]string]bool)
+	for edge := range graph.Edges() {
+		adjacent[[2]string{edge.From(), edge.To()}] = true
+		adjacent[[2]string{edge.To(), edge.From()}] = true
+	}
+	for i, vertex := range cycle {
+		if next := cycle[(i+1)%len(cycle)]; !adjacent[[2]string{vertex, next}] {
+			t.Errorf("Cycle %v has no edge between %s and %s", cycle, vertex, next)
+		}
+	}
+}
+
+// Lightest assignment of the rows from row onwards to the columns not yet used.
+func minimumAssignment(weights [][]int, row int, used []bool) int {
+	if row == len(weights) {
+		return 0
+	}
+	best := math.MaxInt
+	for j, taken := range used {
+		if !taken {
+			used[j] = true
+			best = min(best, weights[row][j]+minimumAssignment(weights, row+1, used))
+			used[j] = false
+		}
+	}
+	return best
+}
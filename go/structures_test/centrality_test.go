@@ -0,0 +1,308 @@
+package structures_test
+
+import (
+	"math"
+	"testing"
+
+	"../structures"
+)
+
+func TestClosenessCentrality(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testClosenessCentrality(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testClosenessCentrality(list, t)
+}
+
+func testClosenessCentrality(graph structures.DirectedWeightedGraph, t *testing.T) {
+	structures.Star(graph, 6, structures.GeneratorOptions{})
+	closeness, err := structures.ClosenessCentrality(graph)
+	testError(err, t)
+	testFloat("Closeness of the centre", closeness["0"], 1, t)
+	testFloat("Closeness of a leaf", closeness["1"], 5.0/9, t)
+
+	// Each vertex of a directed path reaches fewer vertices than the one before it.
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b", "c"})
+	graph.AddEdge("a", "b", 2)
+	graph.AddEdge("b", "c", 3)
+	closeness, err = structures.ClosenessCentrality(graph)
+	testError(err, t)
+	testFloat("Closeness of a", closeness["a"], 2.0/7, t)
+	testFloat("Closeness of b", closeness["b"], 1.0/3*1.0/2, t)
+	testFloat("Closeness of c", closeness["c"], 0, t)
+
+	// A vertex reaching others only at distance 0 is as close as possible, unlike one reaching none.
+	graph.AddVertex("d")
+	graph.AddEdge("c", "d", 0)
+	closeness, err = structures.ClosenessCentrality(graph)
+	testError(err, t)
+	if !math.IsInf(closeness["c"], 1) {
+		t.Errorf("Closeness of c should be +Inf, got %v", closeness["c"])
+	}
+	testFloat("Closeness of d", closeness["d"], 0, t)
+	testFloat("Closeness of b", closeness["b"], 2.0/6*2.0/3, t)
+
+	resetToGraphA(graph, t)
+	paths, err := structures.FloydWarshall(graph)
+	testError(err, t)
+	closeness, err = structures.ClosenessCentrality(graph)
+	testError(err, t)
+	for from := range graph.Vertices() {
+		sum := 0
+		for to := range graph.Vertices() {
+			distance, _ := paths.Distance(from, to)
+			sum += distance
+		}
+		testFloat("Closeness of "+from, closeness[from], 6/float64(sum), t)
+	}
+
+	graph.AddEdge("a", "b", -1)
+	if _, err := structures.ClosenessCentrality(graph); err == nil {
+		t.Error("Closeness with a negative edge weight should throw error")
+	}
+}
+
+func TestBetweennessCentrality(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testBetweennessCentrality(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testBetweennessCentrality(list, t)
+}
+
+func testBetweennessCentrality(graph structures.DirectedWeightedGraph, t *testing.T) {
+	// Every path between two leaves goes through the centre.
+	structures.Star(graph, 6, structures.GeneratorOptions{})
+	betweenness, err := structures.BetweennessCentrality(graph)
+	testError(err, t)
+	testFloat("Betweenness of the centre", betweenness["0"], 5*4, t)
+	testFloat("Betweenness of a leaf", betweenness["1"], 0, t)
+
+	// Opposite corners of a square have two shortest paths, one through each other corner.
+	structures.Grid(graph, 2, 2, structures.GeneratorOptions{})
+	betweenness, err = structures.BetweennessCentrality(graph)
+	testError(err, t)
+	for vertex, value := range betweenness {
+		testFloat("Betweenness of "+vertex, value, 1, t)
+	}
+
+	// A parallel edge doubles the paths through it.
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b", "c", "d"})
+	graph.AddEdge("a", "b", 1)
+	graph.AddEdge("a", "b", 1)
+	graph.AddEdge("a", "c", 1)
+	graph.AddEdge("b", "d", 1)
+	graph.AddEdge("c", "d", 1)
+	graph.AddEdge("d", "d", 0)
+	betweenness, err = structures.BetweennessCentrality(graph)
+	testError(err, t)
+	testFloat("Betweenness of b", betweenness["b"], 2.0/3, t)
+	testFloat("Betweenness of c", betweenness["c"], 1.0/3, t)
+
+	// In a tree, a vertex lies on the only path between every two vertices it separates.
+	// The directed tree from the same seed gives the size of the subtree under each vertex.
+	options := structures.GeneratorOptions{Seed: 5, Weight: uniformWeights(1, 10, t), Directed: true}
+	structures.RandomTree(graph, 30, options)
+	subtree := make(map[string]int)
+	for vertex := range graph.Vertices() {
+		reached, err := graph.BFS(vertex)
+		testError(err, t)
+		subtree[vertex] = len(reached)
+	}
+	separated := make(map[string]int)
+	for vertex := range graph.Vertices() {
+		parentSide := 30 - subtree[vertex]
+		separated[vertex] = 29*29 - parentSide*parentSide
+		for child := range graph.Neighbours(vertex) {
+			separated[vertex] -= subtree[child] * subtree[child]
+		}
+	}
+	options.Directed = false
+	structures.RandomTree(graph, 30, options)
+	betweenness, err = structures.BetweennessCentrality(graph)
+	testError(err, t)
+	for vertex, expected := range separated {
+		testFloat("Betweenness of "+vertex, betweenness[vertex], float64(expected), t)
+	}
+
+	for _, seed := range []uint64{1, 2, 3} {
+		options := structures.GeneratorOptions{Seed: seed, Weight: uniformWeights(1, 4, t)}
+		structures.ErdosRenyi(graph, 15, 0.25, options)
+		testBrandes(graph, t)
+		options.Directed = true
+		structures.ErdosRenyi(graph, 15, 0.2, options)
+		testBrandes(graph, t)
+	}
+
+	// A zero weight edge from x to u gives u a second shortest path from s, whichever order the edges are added in.
+	for _, edges := range [][][2]string{{{"s", "x"}, {"s", "u"}, {"x", "u"}}, {{"x", "u"}, {"s", "u"}, {"s", "x"}}} {
+		graph.Clear()
+		graph.AddAllVertices([]string{"s", "x", "u", "t"})
+		for _, edge := range edges {
+			weight := 1
+			if edge == [2]string{"x", "u"} {
+				weight = 0
+			}
+			graph.AddEdge(edge[0], edge[1], weight)
+		}
+		graph.AddEdge("u", "t", 1)
+		betweenness, err = structures.BetweennessCentrality(graph)
+		testError(err, t)
+		testFloat("Betweenness of x", betweenness["x"], 1, t)
+		testFloat("Betweenness of u", betweenness["u"], 2, t)
+	}
+	for _, seed := range []uint64{1, 2, 3} {
+		options := structures.GeneratorOptions{Seed: seed, Weight: uniformWeights(0, 3, t)}
+		structures.RandomDAG(graph, 15, 0.3, options)
+		testBrandes(graph, t)
+	}
+
+	// Around a cycle of zero weight edges there are infinitely many shortest paths.
+	graph.AddEdge("1", "0", 0)
+	graph.AddEdge("0", "1", 0)
+	if _, err := structures.BetweennessCentrality(graph); err == nil {
+		t.Error("Betweenness with a cycle of zero weight edges should throw error")
+	}
+
+	graph.AddEdge("0", "1", -1)
+	if _, err := structures.BetweennessCentrality(graph); err == nil {
+		t.Error("Betweenness with a negative edge weight should throw error")
+	}
+}
+
+// Checks betweenness against counts of shortest paths built from the Floyd-Warshall distances.
+// Edge weights must be non-negative, and zero weight edges must not form a cycle.
+func testBrandes(graph structures.DirectedWeightedGraph, t *testing.T) {
+	paths, err := structures.FloydWarshall(graph)
+	testError(err, t)
+	// Number of shortest paths from each vertex to each other, by the last edge of the path.
+	counts := make(map[[2]string]float64)
+	var count func(from string, to string) float64
+	count = func(from string, to string) float64 {
+		if from == to {
+			return 1
+		}
+		if result, ok := counts[[2]string{from, to}]; ok {
+			return result
+		}
+		result := 0.0
+		distance, err := paths.Distance(from, to)
+		if err == nil {
+			for edge := range graph.Edges() {
+				if edge.To() != to {
+					continue
+				}
+				if before, err := paths.Distance(from, edge.From()); err == nil && before+edge.Weight() == distance {
+					result += count(from, edge.From())
+				}
+			}
+		}
+		counts[[2]string{from, to}] = result
+		return result
+	}
+	betweenness, err := structures.BetweennessCentrality(graph)
+	testError(err, t)
+	for vertex := range graph.Vertices() {
+		expected := 0.0
+		for from := range graph.Vertices() {
+			for to := range graph.Vertices() {
+				if from == vertex || to == vertex || from == to || count(from, to) == 0 {
+					continue
+				}
+				first, err := paths.Distance(from, vertex)
+				second, otherErr := paths.Distance(vertex, to)
+				distance, _ := paths.Distance(from, to)
+				if err == nil && otherErr == nil && first+second == distance {
+					expected += count(from, vertex) * count(vertex, to) / count(from, to)
+				}
+			}
+		}
+		testFloat("Betweenness of "+vertex, betweenness[vertex], expected, t)
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testPageRank(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testPageRank(list, t)
+}
+
+func testPageRank(graph structures.DirectedWeightedGraph, t *testing.T) {
+	// b has no outgoing edges, so its rank is spread over both vertices.
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b"})
+	graph.AddEdge("a", "b", 1)
+	rank, err := structures.PageRank(graph, 0.85, 1e-12)
+	testError(err, t)
+	testFloat("PageRank of a", rank["a"], 0.5/1.425, t)
+	testFloat("PageRank of b", rank["b"], 1-0.5/1.425, t)
+
+	// Every vertex of a complete graph is alike.
+	structures.Complete(graph, 5, structures.GeneratorOptions{Seed: 1, Weight: structures.ConstantWeights(3)})
+	rank, err = structures.PageRank(graph, 0.85, 1e-12)
+	testError(err, t)
+	for vertex, value := range rank {
+		testFloat("PageRank of "+vertex, value, 0.2, t)
+	}
+
+	// Weights steer the walk, a takes b three times as often as c.
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b", "c"})
+	graph.AddEdge("a", "b", 3)
+	graph.AddEdge("a", "c", 1)
+	graph.AddEdge("b", "a", 1)
+	graph.AddEdge("c", "a", 1)
+	rank, err = structures.PageRank(graph, 0.85, 1e-12)
+	testError(err, t)
+	a := 0.135 / 0.2775
+	testFloat("PageRank of a", rank["a"], a, t)
+	testFloat("PageRank of b", rank["b"], 0.05+0.85*0.75*a, t)
+	testFloat("PageRank of c", rank["c"], 0.05+0.85*0.25*a, t)
+
+	// Without damping the walk alternates between a and the others, and the ranks never settle.
+	if _, err := structures.PageRank(graph, 1, 1e-9); err == nil {
+		t.Error("PageRank that does not converge should throw error")
+	}
+
+	// The vertex with the most edges collects the most rank and the ranks add up to 1.
+	structures.BarabasiAlbert(graph, 100, 2, structures.GeneratorOptions{Seed: 4})
+	rank, err = structures.PageRank(graph, 0.85, 1e-9)
+	testError(err, t)
+	sum := 0.0
+	for _, value := range rank {
+		sum += value
+	}
+	if math.Abs(sum-1) > 1e-6 {
+		t.Errorf("PageRanks should add up to 1, got %v", sum)
+	}
+	highest, highestDegree := "", 0
+	for vertex := range graph.Vertices() {
+		degree, _ := structures.OutDegree(graph, vertex)
+		if degree > highestDegree {
+			highest, highestDegree = vertex, degree
+		}
+	}
+	for vertex, value := range rank {
+		if value > rank[highest] {
+			t.Errorf("Vertex %s of degree %d should have the highest PageRank, %s has %v", highest, highestDegree, vertex, value)
+		}
+	}
+
+	if rank, err := structures.PageRank(&structures.AdjacencyList{}, 0.85, 1e-9); err != nil || len(rank) != 0 {
+		t.Errorf("PageRank of an empty graph should be empty, got %v, %v", rank, err)
+	}
+	for _, test := range [][2]float64{{-0.1, 1e-9}, {1.5, 1e-9}, {math.NaN(), 1e-9}, {0.85, 0}, {0.85, -1}} {
+		if _, err := structures.PageRank(graph, test[0], test[1]); err == nil {
+			t.Errorf("PageRank with damping %v and tolerance %v should throw error", test[0], test[1])
+		}
+	}
+	graph.AddEdge("1", "0", -1)
+	if _, err := structures.PageRank(graph, 0.85, 1e-9); err == nil {
+		t.Error("PageRank with a negative edge weight should throw error")
+	}
+}
@@ -0,0 +1,223 @@
+package structures_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"../structures"
+)
+
+func TestModularity(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testModularity(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testModularity(list, t)
+}
+
+func testModularity(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToCaveman(graph, 2, 5, t)
+	community := make(map[string]int)
+	for vertex := range graph.Vertices() {
+		community[vertex] = len(community) / 5
+	}
+	// 21 edges, 10 inside each clique, and half the degree on each side.
+	modularity, err := structures.Modularity(graph, community)
+	testError(err, t)
+	testFloat("Modularity of the cliques", modularity, 20.0/21-0.5, t)
+	for vertex := range community {
+		community[vertex] = 0
+	}
+	modularity, err = structures.Modularity(graph, community)
+	testError(err, t)
+	testFloat("Modularity of a single community", modularity, 0, t)
+
+	// Edges in one direction only, parallel edges and self loops.
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b", "c"})
+	graph.AddEdge("a", "b", 1)
+	graph.AddEdge("a", "b", 2)
+	graph.AddEdge("c", "c", 1)
+	modularity, err = structures.Modularity(graph, map[string]int{"a": 0, "b": 0, "c": 1})
+	testError(err, t)
+	testFloat("Modularity", modularity, (6.0/8-36.0/64)+(2.0/8-4.0/64), t)
+
+	if _, err := structures.Modularity(graph, map[string]int{"a": 0, "b": 0}); err == nil {
+		t.Error("Modularity without a community for every vertex should throw error")
+	}
+	graph.AddEdge("b", "c", -1)
+	if _, err := structures.Modularity(graph, map[string]int{"a": 0, "b": 0, "c": 1}); err == nil {
+		t.Error("Modularity with a negative edge weight should throw error")
+	}
+	graph.Clear()
+	if modularity, err := structures.Modularity(graph, map[string]int{}); err != nil || modularity != 0 {
+		t.Errorf("Modularity of an empty graph should be 0, got %v, %v", modularity, err)
+	}
+}
+
+func TestCommunities(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testCommunities(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testCommunities(list, t)
+	resetToCaveman(list, 6, 5, t)
+	testCommunities(list.ToCSR(), t)
+}
+
+func testCommunities(graph structures.DirectedWeightedGraph, t *testing.T) {
+	_, readOnly := graph.(*structures.CSRGraph)
+	if !readOnly {
+		resetToCaveman(graph, 6, 5, t)
+	}
+	// Each clique of a ring of cliques is a community.
+	expected := make([][]string, 6)
+	for vertex := range graph.Vertices() {
+		i, _ := strconv.Atoi(vertex)
+		expected[i/5] = append(expected[i/5], vertex)
+	}
+	for _, detect := range []func(graph structures.Graph, seed uint64) (*structures.Partition, error){structures.LabelPropagation, structures.Louvain} {
+		for seed := range uint64(5) {
+			partition, err := detect(graph, seed)
+			testError(err, t)
+			if !reflect.DeepEqual(partition.Communities, expected) {
+				t.Errorf("Communities should be the cliques %v, got %v", expected, partition.Communities)
+			}
+			testPartition(graph, partition, t)
+		}
+	}
+	if readOnly {
+		return
+	}
+
+	resetToCaveman(graph, 2, 5, t)
+	for seed := range uint64(5) {
+		partition, err := structures.KernighanLin(graph, seed)
+		testError(err, t)
+		if len(partition.Communities) != 2 || partition.Community["0"] == partition.Community["5"] {
+			t.Errorf("Halves should be the two cliques, got %v", partition.Communities)
+		}
+		testFloat("Modularity of the cliques", partition.Modularity, 20.0/21-0.5, t)
+		testPartition(graph, partition, t)
+	}
+
+	graph.Clear()
+	for _, detect := range []func(graph structures.Graph, seed uint64) (*structures.Partition, error){structures.LabelPropagation, structures.Louvain, structures.KernighanLin} {
+		if partition, err := detect(graph, 1); err != nil || len(partition.Communities) != 0 || partition.Modularity != 0 {
+			t.Errorf("Partition of an empty graph should be empty, got %v, %v", partition, err)
+		}
+	}
+	graph.AddAllVertices([]string{"a", "b", "c"})
+	partition, err := structures.Louvain(graph, 1)
+	testError(err, t)
+	if len(partition.Communities) != 3 {
+		t.Errorf("Vertices without edges should be in communities of their own, got %v", partition.Communities)
+	}
+	graph.AddEdge("a", "b", -1)
+	for _, detect := range []func(graph structures.Graph, seed uint64) (*structures.Partition, error){structures.LabelPropagation, structures.Louvain, structures.KernighanLin} {
+		if _, err := detect(graph, 1); err == nil {
+			t.Error("Community detection with a negative edge weight should throw error")
+		}
+	}
+}
+
+func TestCommunitiesGenerated(t *testing.T) {
+	graph := &structures.AdjacencyList{}
+	options := structures.GeneratorOptions{Seed: 2, Weight: uniformWeights(1, 5, t)}
+	for name, generate := range map[string]func() error{
+		"Grid":           func() error { return structures.Grid(graph, 12, 12, options) },
+		"WattsStrogatz":  func() error { return structures.WattsStrogatz(graph, 200, 6, 0.05, options) },
+		"BarabasiAlbert": func() error { return structures.BarabasiAlbert(graph, 200, 2, options) },
+		"ErdosRenyi":     func() error { return structures.ErdosRenyi(graph, 200, 0.03, options) },
+	} {
+		testError(generate(), t)
+		louvain, err := structures.Louvain(graph, 1)
+		testError(err, t)
+		labels, err := structures.LabelPropagation(graph, 1)
+		testError(err, t)
+		halves, err := structures.KernighanLin(graph, 1)
+		testError(err, t)
+		for _, partition := range []*structures.Partition{louvain, labels, halves} {
+			testPartition(graph, partition, t)
+		}
+		// Louvain optimises modularity directly, so it should do at least as well as the others.
+		if louvain.Modularity < labels.Modularity || louvain.Modularity < halves.Modularity {
+			t.Errorf("%s: Louvain modularity %v should not be below label propagation %v or Kernighan-Lin %v",
+				name, louvain.Modularity, labels.Modularity, halves.Modularity)
+		}
+		if louvain.Modularity < 0.3 {
+			t.Errorf("%s: Louvain modularity should be at least 0.3, got %v", name, louvain.Modularity)
+		}
+		if sizes := [2]int{len(halves.Communities[0]), len(halves.Communities[1])}; sizes[0]-sizes[1] > 1 || sizes[1]-sizes[0] > 1 {
+			t.Errorf("%s: Kernighan-Lin halves should have equal sizes, got %v", name, sizes)
+		}
+
+		// A random split of the grid cuts about half of its edges, Kernighan-Lin far fewer.
+		if name == "Grid" && halves.Modularity < 0.4 {
+			t.Errorf("Kernighan-Lin should split the grid with modularity near 0.5, got %v", halves.Modularity)
+		}
+
+		// The same seed gives the same result, whichever kind of graph holds it.
+		for _, other := range []structures.DirectedWeightedGraph{graph, graph.ToMatrix()} {
+			again, err := structures.Louvain(other, 1)
+			testError(err, t)
+			if !reflect.DeepEqual(again, louvain) {
+				t.Errorf("%s: Louvain should give the same communities for the same seed", name)
+			}
+			again, err = structures.LabelPropagation(other, 1)
+			testError(err, t)
+			if !reflect.DeepEqual(again, labels) {
+				t.Errorf("%s: label propagation should give the same communities for the same seed", name)
+			}
+			again, err = structures.KernighanLin(other, 1)
+			testError(err, t)
+			if !reflect.DeepEqual(again, halves) {
+				t.Errorf("%s: Kernighan-Lin should give the same halves for the same seed", name)
+			}
+		}
+	}
+}
+
+// A ring of cliques of vertices "0" to "n-1", each clique joined to the next by a single edge,
+// with every edge added in both directions.
+func resetToCaveman(graph structures.DirectedWeightedGraph, cliques int, size int, t *testing.T) {
+	graph.Clear()
+	for i := range cliques * size {
+		graph.AddVertex(strconv.Itoa(i))
+	}
+	join := func(a int, b int) {
+		graph.AddEdge(strconv.Itoa(a), strconv.Itoa(b), 1)
+		graph.AddEdge(strconv.Itoa(b), strconv.Itoa(a), 1)
+	}
+	for clique := range cliques {
+		for a := range size {
+			for b := a + 1; b < size; b++ {
+				join(clique*size+a, clique*size+b)
+			}
+		}
+		if cliques > 2 || clique == 0 {
+			join(clique*size+size-1, (clique+1)%cliques*size)
+		}
+	}
+	testGraphNumberOfVertices(graph, cliques*size, t)
+}
+
+// Checks that the communities cover every vertex once and agree with the map and the modularity.
+func testPartition(graph structures.DirectedWeightedGraph, partition *structures.Partition, t *testing.T) {
+	seen := 0
+	for i, community := range partition.Communities {
+		for _, vertex := range community {
+			if partition.Community[vertex] != i {
+				t.Errorf("Vertex %s should be in community %d, got %d", vertex, i, partition.Community[vertex])
+			}
+			seen++
+		}
+	}
+	if seen != graph.NumberOfVertices() || len(partition.Community) != seen {
+		t.Errorf("Communities should cover all %d vertices once, got %d", graph.NumberOfVertices(), seen)
+	}
+	modularity, err := structures.Modularity(graph, partition.Community)
+	testError(err, t)
+	testFloat("Modularity", partition.Modularity, modularity, t)
+}
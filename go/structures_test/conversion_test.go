@@ -0,0 +1,73 @@
+package structures_test
+
+import (
+	"testing"
+
+	"../structures"
+)
+
+func TestConversion(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testConversion(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testConversion(list, t)
+}
+
+func testConversion(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToEncodingGraph(graph, t)
+	graph.RemoveEdge("a", "f")
+	graph.SetVertexAttribute("a", "label", "Start")
+	graph.SetEdgeAttribute("c", "g", "colour", "red")
+	graph.SetEdgeFloatWeight("c", "g", 0.5)
+	graph.SetParallelEdgePolicy(structures.RejectParallelEdges)
+
+	for name, converted := range map[string]structures.DirectedWeightedGraph{
+		"ToList":   graph.ToList(),
+		"ToMatrix": graph.ToMatrix(),
+		"ToCSR":    graph.ToCSR(),
+	} {
+		testSameGraph(graph, converted, true, t)
+		ids := make([]int, 0)
+		for edge := range graph.Edges() {
+			ids = append(ids, edge.ID())
+		}
+		i := 0
+		for edge := range converted.Edges() {
+			if edge.ID() != ids[i] {
+				t.Errorf("%s should keep edge ID %d, got %d", name, ids[i], edge.ID())
+			}
+			i++
+		}
+		if label, _ := converted.VertexAttribute("a", "label"); label != "Start" {
+			t.Errorf("%s should keep the label of A, got %v", name, label)
+		}
+		if colour, _ := converted.EdgeAttribute("c", "g", "colour"); colour != "red" {
+			t.Errorf("%s should keep the colour of c->g, got %v", name, colour)
+		}
+		if edge := converted.EdgesBetween("c", "g")[0]; edge.FloatWeight() != 0.5 {
+			t.Errorf("%s should keep the float weight of c->g, got %v", name, edge.FloatWeight())
+		}
+		if name == "ToCSR" {
+			continue
+		}
+
+		// The copy is independent of the graph and keeps its parallel edge policy.
+		converted.SetEdgeAttribute("c", "g", "colour", "blue")
+		converted.RemoveVertex("b")
+		if colour, _ := graph.EdgeAttribute("c", "g", "colour"); colour != "red" || graph.NumberOfVertices() != 11 {
+			t.Errorf("%s should copy the graph, the original changed", name)
+		}
+		if _, err := converted.AddEdge("c", "g", 1); err == nil {
+			t.Errorf("%s should keep the parallel edge policy, c->g was added twice", name)
+		}
+		// New edges get new IDs.
+		id, err := converted.AddEdge("a", "f", 7)
+		testError(err, t)
+		for _, old := range ids {
+			if id == old {
+				t.Errorf("%s should give new edges new IDs, got %d", name, id)
+			}
+		}
+	}
+}
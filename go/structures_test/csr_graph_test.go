@@ -0,0 +1,161 @@
+package structures_test
+
+import (
+	"errors"
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"../structures"
+)
+
+func TestCSRGraph(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testCSRGraph(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testCSRGraph(list, t)
+}
+
+func testCSRGraph(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToGraphA(graph, t)
+	graph.AddVertex("h")
+	graph.AddEdge("a", "c", 12)
+	csr := graph.ToCSR()
+	testSameGraph(graph, csr, true, t)
+	testSameResults(graph, csr, t)
+	paths, err := structures.ShortestPaths(csr, "a")
+	testError(err, t)
+	testPathTo(paths, "e", []string{"a", "c", "g", "e"}, []int{0, 10, 16, 22}, t)
+	testEdgesBetween(csr, "a", "c", nil, []int{10, 12}, t)
+	if !reflect.DeepEqual(structures.StronglyConnectedComponents(csr), structures.StronglyConnectedComponents(graph)) {
+		t.Errorf("CSR components should be %v, got %v", structures.StronglyConnectedComponents(graph), structures.StronglyConnectedComponents(csr))
+	}
+	if structures.Kruskal(csr).Weight != structures.Kruskal(graph).Weight {
+		t.Errorf("CSR spanning forest should weigh %d, got %d", structures.Kruskal(graph).Weight, structures.Kruskal(csr).Weight)
+	}
+	flow, err := structures.Dinic(csr, "a", "e")
+	testError(err, t)
+	if expected, _ := structures.Dinic(graph, "a", "e"); flow.Value != expected.Value {
+		t.Errorf("CSR maximum flow should be %d, got %d", expected.Value, flow.Value)
+	}
+	testError(csr.Dijkstra("a"), t)
+	path, err := csr.GetShortestPath("e")
+	testError(err, t)
+	var b strings.Builder
+	testError(structures.EncodeDOT(csr, &b, path), t)
+	if !strings.Contains(b.String(), `"c" -> "g" [weight=6, label=6, color=red, penwidth=2];`) {
+		t.Errorf("CSR DOT output should highlight c->g, got\n%s", b.String())
+	}
+
+	// The graph cannot be changed, and does not change with the graph it came from.
+	for _, err := range []error{
+		csr.AddVertex("z"),
+		csr.RemoveVertex("a"),
+		csr.RemoveEdge("a", "c"),
+		csr.SetEdgeAttribute("a", "c", "colour", "red"),
+		csr.SetEdgeAttributeByID(0, "colour", "red"),
+		csr.SetEdgeFloatWeightByID(0, 0.5),
+		structures.DecodeJSON(csr, strings.NewReader("{}")),
+	} {
+		if !errors.Is(err, structures.ErrReadOnly) {
+			t.Errorf("CSR graph should be read-only, got %v", err)
+		}
+	}
+	if id, err := csr.AddEdge("a", "b", 1); id != -1 || !errors.Is(err, structures.ErrReadOnly) {
+		t.Errorf("CSR graph should be read-only, got %d and %v", id, err)
+	}
+	graph.RemoveVertex("a")
+	testGraphNumberOfVertices(csr, 8, t)
+	testGraphNumberOfEdges(csr, 18, t)
+	if csr.ToCSR() != csr {
+		t.Error("ToCSR of a CSR graph should return the graph itself")
+	}
+
+	// The policy is passed on to conversions, and clearing leaves an empty read-only graph.
+	csr.SetParallelEdgePolicy(structures.RejectParallelEdges)
+	if _, err := csr.ToList().AddEdge("a", "c", 1); err == nil {
+		t.Error("AddEdge should throw error, the list converted from the CSR graph rejects parallel edges")
+	}
+	csr.Clear()
+	if !csr.IsEmpty() || csr.NumberOfEdges() != 0 {
+		t.Errorf("Cleared CSR graph should be empty, got %d vertices and %d edges", csr.NumberOfVertices(), csr.NumberOfEdges())
+	}
+	if _, ok := csr.EdgeAttribute("a", "c", "colour"); ok {
+		t.Error("Cleared CSR graph should have no edges")
+	}
+	if err := csr.AddVertex("a"); !errors.Is(err, structures.ErrReadOnly) {
+		t.Errorf("Cleared CSR graph should be read-only, got %v", err)
+	}
+	testGraphNumberOfVertices(graph, 7, t)
+
+	empty := &structures.CSRGraph{}
+	if !empty.IsEmpty() {
+		t.Error("Zero CSR graph should be empty")
+	}
+	if _, err := empty.BFS("a"); err == nil {
+		t.Error("BFS should throw error, vertex A does not exist")
+	}
+	if _, err := empty.DFS("a"); err == nil {
+		t.Error("DFS should throw error, vertex A does not exist")
+	}
+}
+
+func TestCSRGraphRandom(t *testing.T) {
+	list := &structures.AdjacencyList{}
+	testCSRGraphRandom(list, t)
+
+	matrix := &structures.AdjacencyMatrix{}
+	testCSRGraphRandom(matrix, t)
+}
+
+// Algorithms give the same results on random graphs and their CSR form.
+func testCSRGraphRandom(graph structures.DirectedWeightedGraph, t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 20 {
+		graph.Clear()
+		n := 1 + rng.IntN(30)
+		for i := range n {
+			graph.AddVertex(strconv.Itoa(i))
+		}
+		for range rng.IntN(3 * n) {
+			graph.AddEdge(strconv.Itoa(rng.IntN(n)), strconv.Itoa(rng.IntN(n)), rng.IntN(20))
+		}
+		csr := graph.ToCSR()
+		testSameGraph(graph, csr, true, t)
+		testSameResults(graph, csr, t)
+		if !reflect.DeepEqual(structures.BiconnectedComponents(csr), structures.BiconnectedComponents(graph)) {
+			t.Errorf("CSR biconnected components should be %v, got %v", structures.BiconnectedComponents(graph), structures.BiconnectedComponents(csr))
+		}
+	}
+}
+
+// Checks that traversals and shortest paths from every vertex agree between two graphs.
+func testSameResults(expected structures.DirectedWeightedGraph, actual structures.DirectedWeightedGraph, t *testing.T) {
+	for vertex := range expected.Vertices() {
+		bfs, err := expected.BFS(vertex)
+		testError(err, t)
+		testTraversal("BFS", actual.BFS, vertex, bfs, t)
+		dfs, err := expected.DFS(vertex)
+		testError(err, t)
+		testTraversal("DFS", actual.DFS, vertex, dfs, t)
+		expectedPaths, err := structures.ShortestPaths(expected, vertex)
+		testError(err, t)
+		actualPaths, err := structures.ShortestPaths(actual, vertex)
+		testError(err, t)
+		for target := range expected.Vertices() {
+			expectedDistance, expectedErr := expectedPaths.DistanceTo(target)
+			actualDistance, actualErr := actualPaths.DistanceTo(target)
+			if actualDistance != expectedDistance || (actualErr == nil) != (expectedErr == nil) {
+				t.Errorf("Distance from %s to %s should be %d, got %d", vertex, target, expectedDistance, actualDistance)
+			}
+			expectedPath, _ := expectedPaths.PathTo(target)
+			actualPath, _ := actualPaths.PathTo(target)
+			if !reflect.DeepEqual(actualPath, expectedPath) {
+				t.Errorf("Path from %s to %s should be the same on both graphs", vertex, target)
+			}
+		}
+	}
+}
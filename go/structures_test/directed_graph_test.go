@@ -0,0 +1,55 @@
+package structures_test
+
+import (
+	"reflect"
+	"testing"
+
+	"../structures"
+)
+
+func TestDirectedGraph(t *testing.T) {
+	matrix := &structures.DirectedAdjacencyMatrix{}
+	testDirectedGraph(matrix, t)
+
+	list := &structures.DirectedAdjacencyList{}
+	testDirectedGraph(list, t)
+}
+
+func testDirectedGraph(graph structures.DirectedGraph, t *testing.T) {
+	if _, ok := any(graph).(structures.WeightedGraph); ok {
+		t.Error("Unweighted graph should not accept weighted edges")
+	}
+	graph.Clear()
+	graph.AddAllVertices([]string{"shirt", "tie", "jacket", "belt", "trousers", "shoes"})
+	graph.AddEdge("shirt", "tie")
+	graph.AddEdge("tie", "jacket")
+	graph.AddEdge("shirt", "belt")
+	graph.AddEdge("belt", "jacket")
+	graph.AddEdge("trousers", "belt")
+	graph.AddEdge("trousers", "shoes")
+	testGraphNumberOfVertices(graph, 6, t)
+	testGraphNumberOfEdges(graph, 6, t)
+	testTraversal("DFS", graph.DFS, "trousers", []string{"trousers", "belt", "jacket", "shoes"}, t)
+
+	// Every edge has weight 1, so distances count edges.
+	paths, err := structures.ShortestPaths(graph, "trousers")
+	testError(err, t)
+	testPathTo(paths, "jacket", []string{"trousers", "belt", "jacket"}, []int{0, 1, 2}, t)
+	if paths.Reachable("shirt") {
+		t.Error("Shirt should not be reachable from trousers")
+	}
+	order, err := structures.TopologicalSort(graph)
+	testError(err, t)
+	if !reflect.DeepEqual(order, []string{"shirt", "tie", "trousers", "belt", "jacket", "shoes"}) {
+		t.Errorf("Topological order incorrect, got %v", order)
+	}
+
+	// Edges can only be removed in the direction they were added.
+	if graph.RemoveEdge("belt", "shirt") == nil {
+		t.Error("Graph should have thrown error, edge belt->shirt does not exist")
+	}
+	testError(graph.RemoveEdge("shirt", "belt"), t)
+	testGraphNumberOfEdges(graph, 5, t)
+	graph.RemoveVertex("belt")
+	testGraphNumberOfEdges(graph, 3, t)
+}
@@ -0,0 +1,131 @@
+package structures_test
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+
+	"../structures"
+)
+
+func TestDisjointSet(t *testing.T) {
+	set := &structures.DisjointSet[string]{}
+	testError(set.AddAll([]string{"a", "b", "c", "d", "e"}), t)
+	if set.Add("a") == nil {
+		t.Error("Add should throw error, a already exists")
+	}
+	testSetCount(set, 5, t)
+
+	merged, err := set.Union("a", "b")
+	testError(err, t)
+	if !merged {
+		t.Error("Union should merge a and b")
+	}
+	set.Union("c", "d")
+	set.Union("b", "d")
+	merged, _ = set.Union("a", "c")
+	if merged {
+		t.Error("Union should not merge a and c, they are already in the same set")
+	}
+	testSetCount(set, 2, t)
+	if !set.Connected("a", "d") || set.Connected("a", "e") || set.Connected("a", "z") {
+		t.Error("Connected incorrect")
+	}
+	a, _ := set.Find("a")
+	d, _ := set.Find("d")
+	if a != d {
+		t.Errorf("A and D should have the same representative, got %s and %s", a, d)
+	}
+	size, err := set.SetSize("c")
+	testError(err, t)
+	if size != 4 {
+		t.Errorf("Set of C should have 4 elements, got %d", size)
+	}
+	members, err := set.Members("d")
+	testError(err, t)
+	if !reflect.DeepEqual(members, []string{"a", "b", "c", "d"}) {
+		t.Errorf("Members of D incorrect, got %v", members)
+	}
+
+	_, err = set.Find("z")
+	if err == nil {
+		t.Error("Find should throw error, z does not exist")
+	}
+	_, err = set.Union("a", "z")
+	if err == nil {
+		t.Error("Union should throw error, z does not exist")
+	}
+	set.Clear()
+	if set.Size() != 0 || set.SetCount() != 0 || set.Contains("a") {
+		t.Error("Set should be empty after clearing")
+	}
+}
+
+func TestRollbackDisjointSet(t *testing.T) {
+	set := &structures.RollbackDisjointSet[int]{}
+	set.AddAll([]int{0, 1, 2, 3, 4, 5})
+	set.Union(0, 1)
+	checkpoint := set.Checkpoint()
+	set.Union(2, 3)
+	set.Union(1, 3)
+	set.Union(0, 2)
+	testSetCount(&set.DisjointSet, 3, t)
+	testError(set.Rollback(checkpoint), t)
+	testSetCount(&set.DisjointSet, 5, t)
+	if set.Connected(0, 3) || set.Connected(2, 3) || !set.Connected(0, 1) {
+		t.Error("Unions after the checkpoint should be undone")
+	}
+	members, _ := set.Members(1)
+	if !reflect.DeepEqual(members, []int{0, 1}) {
+		t.Errorf("Members of 1 incorrect after rollback, got %v", members)
+	}
+	if set.Rollback(10) == nil {
+		t.Error("Rollback should throw error, the checkpoint is in the future")
+	}
+
+	// Random unions and rollbacks agree with recomputing the sets from the unions that remain.
+	rng := rand.New(rand.NewPCG(1, 2))
+	set = &structures.RollbackDisjointSet[int]{}
+	n := 30
+	for i := range n {
+		set.Add(i)
+	}
+	unions := make([][2]int, 0)
+	// Number of unions and the checkpoint at each point that may be rolled back to.
+	checkpoints := make([][2]int, 0)
+	for range 500 {
+		switch op := rng.IntN(10); {
+		case op < 6:
+			pair := [2]int{rng.IntN(n), rng.IntN(n)}
+			set.Union(pair[0], pair[1])
+			unions = append(unions, pair)
+		case op < 8:
+			checkpoints = append(checkpoints, [2]int{len(unions), set.Checkpoint()})
+		case len(checkpoints) > 0:
+			last := checkpoints[len(checkpoints)-1]
+			checkpoints = checkpoints[:len(checkpoints)-1]
+			testError(set.Rollback(last[1]), t)
+			unions = unions[:last[0]]
+		}
+		expected := &structures.DisjointSet[int]{}
+		for i := range n {
+			expected.Add(i)
+		}
+		for _, pair := range unions {
+			expected.Union(pair[0], pair[1])
+		}
+		for i := range n {
+			want, _ := expected.Members(i)
+			got, _ := set.Members(i)
+			if !reflect.DeepEqual(want, got) {
+				t.Fatalf("Members of %d incorrect, expected %v, got %v", i, want, got)
+			}
+		}
+	}
+}
+
+func testSetCount[T comparable](set *structures.DisjointSet[T], expected int, t *testing.T) {
+	if set.SetCount() != expected {
+		t.Errorf("Set count should be %d, got %d", expected, set.SetCount())
+	}
+}
@@ -0,0 +1,145 @@
+package structures_test
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"../structures"
+)
+
+func TestDOT(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	list := &structures.AdjacencyList{}
+	testDOT(matrix, list, t)
+	testDOT(list, matrix, t)
+}
+
+func testDOT(graph structures.DirectedWeightedGraph, decoded structures.DirectedWeightedGraph, t *testing.T) {
+	resetToEncodingGraph(graph, t)
+	var b strings.Builder
+	testError(structures.EncodeDOT(graph, &b, nil), t)
+	if strings.Contains(b.String(), "red") {
+		t.Error("DOT output should not highlight anything without a path")
+	}
+	testError(structures.DecodeDOT(decoded, strings.NewReader(b.String())), t)
+	testSameGraph(graph, decoded, true, t)
+
+	// The lighter of the parallel edges a->c is on the path.
+	graph.RemoveEdge(`say "hi"`, `back\slash`)
+	testError(graph.Dijkstra("a"), t)
+	path, err := graph.GetShortestPath("e")
+	testError(err, t)
+	b.Reset()
+	testError(structures.EncodeDOT(graph, &b, path), t)
+	for _, line := range []string{
+		`"a" [color=red];`,
+		`"e" [color=red];`,
+		`"b";`,
+		`"a" -> "c" [weight=10, label=10, color=red, penwidth=2];`,
+		`"a" -> "c" [weight=12, label=12];`,
+		`"g" -> "e" [weight=6, label=6, color=red, penwidth=2];`,
+		`"d" -> "g" [weight=6, label=6];`,
+	} {
+		if !strings.Contains(b.String(), "\t"+line+"\n") {
+			t.Errorf("DOT output should contain %s, got\n%s", line, b.String())
+		}
+	}
+
+	// Comments, default attributes, graph attributes and chains of edges are understood.
+	input := `/* Example */ strict digraph G {
+	rankdir=LR; node [shape=box]
+	// Chain of edges.
+	x -> y -> z [weight=-4, color="blue"]
+	w
+	"z" -> x # Back to the start.
+}
+`
+	testError(structures.DecodeDOT(decoded, strings.NewReader(input)), t)
+	testDecoded(decoded, []string{"x", "y", "z", "w"}, []string{"x->y -4", "y->z -4", "z->x 1"}, t)
+	testError(structures.DecodeDOT(decoded, strings.NewReader("digraph {}")), t)
+	testDecoded(decoded, []string{}, []string{}, t)
+	testError(structures.DecodeDOT(decoded, strings.NewReader(input)), t)
+
+	for _, test := range []struct {
+		input  string
+		line   int
+		column int
+	}{
+		{"digraph {\n  a -> b [weight=x];\n}", 2, 18},
+		{"digraph {\n  a -- b\n}", 2, 5},
+		{"graph { a }", 1, 1},
+		{"digraph {\n  \"a\n}", 2, 3},
+		{"digraph {\n  a -> b", 2, 9},
+		{"digraph { a @ b }", 1, 13},
+		{"digraph { subgraph { a } }", 1, 11},
+		{"digraph { a } b", 1, 15},
+	} {
+		testParseError(structures.DecodeDOT(decoded, strings.NewReader(test.input)), "DOT", test.line, test.column, t)
+	}
+	// The graph is unchanged when decoding fails, also when an edge is rejected by the parallel edge policy.
+	decoded.SetParallelEdgePolicy(structures.RejectParallelEdges)
+	testParseError(structures.DecodeDOT(decoded, strings.NewReader("digraph {\n  a -> b\n  a -> b -> c\n}")), "DOT", 3, 3, t)
+	decoded.SetParallelEdgePolicy(structures.AllowParallelEdges)
+	testDecoded(decoded, []string{"x", "y", "z", "w"}, []string{"x->y -4", "y->z -4", "z->x 1"}, t)
+}
+
+// Graph A with awkward vertex names, a vertex without edges, a parallel edge, a self loop and a negative weight.
+func resetToEncodingGraph(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToGraphA(graph, t)
+	graph.AddAllVertices([]string{`say "hi"`, `back\slash`, "ünïcode, <tag> & more", "alone"})
+	graph.AddEdge(`say "hi"`, `back\slash`, -3)
+	graph.AddEdge(`back\slash`, "ünïcode, <tag> & more", 0)
+	graph.AddEdge("ünïcode, <tag> & more", "ünïcode, <tag> & more", 5)
+	graph.AddEdge("a", "c", 12)
+	testGraphNumberOfVertices(graph, 11, t)
+	testGraphNumberOfEdges(graph, 21, t)
+}
+
+// Checks that two graphs have the same vertices, in the same order if ordered, and the same edges in the same order.
+func testSameGraph(expected structures.DirectedWeightedGraph, actual structures.DirectedWeightedGraph, ordered bool, t *testing.T) {
+	expectedVertices, actualVertices := slices.Collect(expected.Vertices()), slices.Collect(actual.Vertices())
+	if !ordered {
+		slices.Sort(expectedVertices)
+		slices.Sort(actualVertices)
+	}
+	if !reflect.DeepEqual(actualVertices, expectedVertices) {
+		t.Errorf("Vertices should be %v, got %v", expectedVertices, actualVertices)
+	}
+	if actualEdges, expectedEdges := edgeStrings(actual), edgeStrings(expected); !reflect.DeepEqual(actualEdges, expectedEdges) {
+		t.Errorf("Edges should be %v, got %v", expectedEdges, actualEdges)
+	}
+}
+
+// Checks the vertices in order and the edges as "from->to weight" in order.
+func testDecoded(graph structures.DirectedWeightedGraph, expectedVertices []string, expectedEdges []string, t *testing.T) {
+	if vertices := slices.Collect(graph.Vertices()); !slices.Equal(vertices, expectedVertices) {
+		t.Errorf("Vertices should be %v, got %v", expectedVertices, vertices)
+	}
+	if edges := edgeStrings(graph); !reflect.DeepEqual(edges, expectedEdges) {
+		t.Errorf("Edges should be %v, got %v", expectedEdges, edges)
+	}
+}
+
+func edgeStrings(graph structures.DirectedWeightedGraph) []string {
+	result := make([]string, 0)
+	for edge := range graph.Edges() {
+		result = append(result, edge.From()+"->"+edge.To()+" "+strconv.Itoa(edge.Weight()))
+	}
+	return result
+}
+
+// Checks that decoding failed with a ParseError at the given position.
+func testParseError(err error, format string, line int, column int, t *testing.T) {
+	var parseError *structures.ParseError
+	if !errors.As(err, &parseError) {
+		t.Errorf("Decoding should throw ParseError, got %v", err)
+		return
+	}
+	if parseError.Format != format || parseError.Line != line || parseError.Column != column {
+		t.Errorf("ParseError should be at %s line %d, column %d, got %v", format, line, column, parseError)
+	}
+}
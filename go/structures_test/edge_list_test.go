@@ -0,0 +1,56 @@
+package structures_test
+
+import (
+	"strings"
+	"testing"
+
+	"../structures"
+)
+
+func TestEdgeList(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	list := &structures.AdjacencyList{}
+	testEdgeList(matrix, list, t)
+	testEdgeList(list, matrix, t)
+}
+
+func testEdgeList(graph structures.DirectedWeightedGraph, decoded structures.DirectedWeightedGraph, t *testing.T) {
+	resetToEncodingGraph(graph, t)
+	var b strings.Builder
+	testError(structures.EncodeEdgeList(graph, &b), t)
+	if !strings.HasPrefix(b.String(), "source,target,weight\nalone,,\na,c,10\n") {
+		t.Errorf("Edge list should start with the header, the vertices without edges and the edges, got\n%s", b.String())
+	}
+	// Vertices are added when first mentioned, so only their order changes.
+	testError(structures.DecodeEdgeList(decoded, strings.NewReader(b.String())), t)
+	testSameGraph(graph, decoded, false, t)
+
+	// The header and the weights are optional.
+	testError(structures.DecodeEdgeList(decoded, strings.NewReader("x,y,7\ny,x\nw\nz, x,-2\n")), t)
+	testDecoded(decoded, []string{"x", "y", "w", "z"}, []string{"x->y 7", "y->x 1", "z->x -2"}, t)
+
+	for _, test := range []struct {
+		input  string
+		line   int
+		column int
+	}{
+		{"source,target,weight\na,b,1\nb,c,heavy\n", 3, 5},
+		{"a,b,1,2\n", 1, 7},
+		{"a,\"b\n", 1, 6},
+		{"a,b\n,c\n", 2, 1},
+		{"a,,3\n", 1, 3},
+	} {
+		testParseError(structures.DecodeEdgeList(decoded, strings.NewReader(test.input)), "CSV", test.line, test.column, t)
+	}
+	// The graph is unchanged when decoding fails, also when an edge is rejected by the parallel edge policy.
+	decoded.SetParallelEdgePolicy(structures.RejectParallelEdges)
+	testParseError(structures.DecodeEdgeList(decoded, strings.NewReader("a,b,1\na,b,2\n")), "CSV", 2, 3, t)
+	decoded.SetParallelEdgePolicy(structures.AllowParallelEdges)
+	testDecoded(decoded, []string{"x", "y", "w", "z"}, []string{"x->y 7", "y->x 1", "z->x -2"}, t)
+
+	// A vertex with an empty name cannot be told apart from a missing target.
+	graph.AddVertex("")
+	if structures.EncodeEdgeList(graph, &b) == nil {
+		t.Error("EncodeEdgeList should throw error, a vertex has an empty name")
+	}
+}
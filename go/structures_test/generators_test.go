@@ -0,0 +1,355 @@
+package structures_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+
+	"../structures"
+)
+
+func TestGenerators(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testGenerators(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testGenerators(list, t)
+}
+
+func testGenerators(graph structures.DirectedWeightedGraph, t *testing.T) {
+	options := structures.GeneratorOptions{Seed: 1}
+	directed := structures.GeneratorOptions{Seed: 1, Directed: true}
+
+	testError(structures.Complete(graph, 6, options), t)
+	testGenerated(graph, 6, 30, t)
+	testSymmetric(graph, t)
+	testError(structures.Complete(graph, 6, directed), t)
+	testGenerated(graph, 6, 30, t)
+
+	testError(structures.Star(graph, 5, options), t)
+	testGenerated(graph, 5, 8, t)
+	testError(structures.Star(graph, 5, directed), t)
+	testGenerated(graph, 5, 4, t)
+	for edge := range graph.Edges() {
+		if edge.From() != "0" {
+			t.Errorf("Star edges should leave the centre, got %s->%s", edge.From(), edge.To())
+		}
+	}
+
+	testError(structures.Grid(graph, 4, 3, options), t)
+	testGenerated(graph, 12, 2*(3*3+4*2), t)
+	testSymmetric(graph, t)
+	paths, err := structures.ShortestPaths(graph, "0,0")
+	testError(err, t)
+	if distance, err := paths.DistanceTo("3,2"); err != nil || distance != 5 {
+		t.Errorf("Opposite corners of the grid should be 5 apart, got %d", distance)
+	}
+	testError(structures.Grid(graph, 4, 3, directed), t)
+	testGenerated(graph, 12, 3*3+4*2, t)
+
+	// Every vertex after the first m adds m edges.
+	testError(structures.BarabasiAlbert(graph, 50, 3, options), t)
+	testGenerated(graph, 50, 2*3*47, t)
+	testSymmetric(graph, t)
+	testError(structures.BarabasiAlbert(graph, 50, 3, directed), t)
+	testGenerated(graph, 50, 3*47, t)
+	for edge := range graph.Edges() {
+		if from, _ := strconv.Atoi(edge.From()); from < 3 {
+			t.Errorf("Barabási–Albert edges should leave the new vertex, got %s->%s", edge.From(), edge.To())
+		}
+	}
+
+	// Rewiring keeps the number of edges and never adds self loops or parallel edges.
+	for _, beta := range []float64{0, 0.3, 1} {
+		testError(structures.WattsStrogatz(graph, 30, 4, beta, options), t)
+		testGenerated(graph, 30, 2*30*2, t)
+		testSymmetric(graph, t)
+		testSimple(graph, t)
+	}
+	testError(structures.WattsStrogatz(graph, 30, 4, 0, directed), t)
+	testEdgesBetween(graph, "0", "2", nil, []int{1}, t)
+	testEdgesBetween(graph, "2", "0", nil, []int{}, t)
+
+	testError(structures.ErdosRenyi(graph, 40, 0, options), t)
+	testGenerated(graph, 40, 0, t)
+	testError(structures.ErdosRenyi(graph, 40, 1, options), t)
+	testGenerated(graph, 40, 40*39, t)
+	testError(structures.ErdosRenyi(graph, 40, 1, directed), t)
+	testGenerated(graph, 40, 40*39, t)
+	testError(structures.ErdosRenyi(graph, 40, 0.2, options), t)
+	testSymmetric(graph, t)
+	testSimple(graph, t)
+	// 780 pairs each joined with probability 0.2, 156 expected with a standard deviation of about 11.
+	if pairs := graph.NumberOfEdges() / 2; pairs < 120 || pairs > 192 {
+		t.Errorf("G(40, 0.2) should have about 156 edges, got %d", pairs)
+	}
+
+	testError(structures.RandomDAG(graph, 40, 0.3, options), t)
+	testSimple(graph, t)
+	if structures.HasCycle(graph) {
+		t.Error("Random DAG should not have a cycle")
+	}
+	testError(structures.RandomDAG(graph, 40, 1, options), t)
+	testGenerated(graph, 40, 40*39/2, t)
+	if structures.HasCycle(graph) {
+		t.Error("Random DAG should not have a cycle")
+	}
+
+	for _, n := range []int{1, 2, 3, 40} {
+		testError(structures.RandomTree(graph, n, directed), t)
+		testGenerated(graph, n, n-1, t)
+		if reached, err := graph.BFS("0"); err != nil || len(reached) != n {
+			t.Errorf("Random tree should reach all %d vertices from the root, got %v, %v", n, reached, err)
+		}
+	}
+	testError(structures.RandomTree(graph, 40, options), t)
+	testGenerated(graph, 40, 2*39, t)
+	testSymmetric(graph, t)
+	testError(structures.RandomTree(graph, 0, options), t)
+	testGenerated(graph, 0, 0, t)
+
+	for _, err := range []error{
+		structures.ErdosRenyi(graph, -1, 0.5, options),
+		structures.ErdosRenyi(graph, 10, 1.5, options),
+		structures.RandomDAG(graph, 10, -0.1, options),
+		structures.BarabasiAlbert(graph, 10, 0, options),
+		structures.BarabasiAlbert(graph, 10, 10, options),
+		structures.WattsStrogatz(graph, 10, 3, 0.5, options),
+		structures.WattsStrogatz(graph, 10, 10, 0.5, options),
+		structures.WattsStrogatz(graph, 10, 4, 2, options),
+		structures.Grid(graph, -1, 3, options),
+		structures.Complete(graph, -1, options),
+		structures.Star(graph, -1, options),
+		structures.RandomTree(graph, -1, options),
+	} {
+		if err == nil {
+			t.Error("Generating a graph with invalid parameters should throw error")
+		}
+	}
+}
+
+func TestGeneratorsDeterministic(t *testing.T) {
+	generators := map[string]func(graph structures.DirectedWeightedGraph, options structures.GeneratorOptions) error{
+		"ErdosRenyi": func(graph structures.DirectedWeightedGraph, options structures.GeneratorOptions) error {
+			return structures.ErdosRenyi(graph, 30, 0.2, options)
+		},
+		"BarabasiAlbert": func(graph structures.DirectedWeightedGraph, options structures.GeneratorOptions) error {
+			return structures.BarabasiAlbert(graph, 30, 2, options)
+		},
+		"WattsStrogatz": func(graph structures.DirectedWeightedGraph, options structures.GeneratorOptions) error {
+			return structures.WattsStrogatz(graph, 30, 4, 0.3, options)
+		},
+		"RandomDAG": func(graph structures.DirectedWeightedGraph, options structures.GeneratorOptions) error {
+			return structures.RandomDAG(graph, 30, 0.2, options)
+		},
+		"RandomTree": func(graph structures.DirectedWeightedGraph, options structures.GeneratorOptions) error {
+			return structures.RandomTree(graph, 30, options)
+		},
+	}
+	for name, generate := range generators {
+		first, second, list := &structures.AdjacencyMatrix{}, &structures.AdjacencyMatrix{}, &structures.AdjacencyList{}
+		options := structures.GeneratorOptions{Seed: 7, Weight: uniformWeights(1, 100, t)}
+		testError(generate(first, options), t)
+		testError(generate(second, options), t)
+		testError(generate(list, options), t)
+		if !reflect.DeepEqual(edgeStrings(first), edgeStrings(second)) {
+			t.Errorf("%s should generate the same graph for the same seed", name)
+		}
+		if !reflect.DeepEqual(edgeStrings(first), edgeStrings(list)) {
+			t.Errorf("%s should generate the same graph in a list and a matrix", name)
+		}
+		options.Seed = 8
+		testError(generate(second, options), t)
+		if reflect.DeepEqual(edgeStrings(first), edgeStrings(second)) {
+			t.Errorf("%s should generate a different graph for a different seed", name)
+		}
+	}
+}
+
+func TestWeightDistributions(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for _, test := range []struct {
+		distribution structures.WeightDistribution
+		low          int
+		high         int
+		mean         float64
+	}{
+		{structures.ConstantWeights(7), 7, 7, 7},
+		{uniformWeights(-5, 5, t), -5, 5, 0},
+		{structures.NormalWeights(50, 5), 20, 80, 50},
+		{structures.ExponentialWeights(10), 0, 1000, 10},
+	} {
+		sum := 0
+		seen := make(map[int]bool)
+		for range 10000 {
+			weight := test.distribution(rng)
+			if weight < test.low || weight > test.high {
+				t.Errorf("Weight should be between %d and %d, got %d", test.low, test.high, weight)
+			}
+			seen[weight] = true
+			sum += weight
+		}
+		if mean := float64(sum) / 10000; mean < test.mean-0.5 || mean > test.mean+0.5 {
+			t.Errorf("Mean weight should be about %v, got %v", test.mean, mean)
+		}
+		if test.low == -5 && len(seen) != 11 {
+			t.Errorf("Uniform weights should cover all 11 values, got %d", len(seen))
+		}
+	}
+
+	graph := &structures.AdjacencyList{}
+	testError(structures.Complete(graph, 20, structures.GeneratorOptions{Weight: uniformWeights(3, 9, t)}), t)
+	for edge := range graph.Edges() {
+		if edge.Weight() < 3 || edge.Weight() > 9 {
+			t.Errorf("Generated weight should be between 3 and 9, got %d", edge.Weight())
+		}
+	}
+	testSymmetric(graph, t)
+
+	if _, err := structures.UniformWeights(5, 4); err == nil {
+		t.Error("UniformWeights should throw error, 4 is below 5")
+	}
+	// The full range of ints does not overflow.
+	full := uniformWeights(math.MinInt, math.MaxInt, t)
+	negative := 0
+	for range 1000 {
+		if full(rng) < 0 {
+			negative++
+		}
+	}
+	if negative < 400 || negative > 600 {
+		t.Errorf("About half of the weights over all ints should be negative, got %d of 1000", negative)
+	}
+}
+
+// Returns uniform weights between low and high, which must be a valid range.
+func uniformWeights(low int, high int, t *testing.T) structures.WeightDistribution {
+	weights, err := structures.UniformWeights(low, high)
+	testError(err, t)
+	return weights
+}
+
+func TestErdosRenyiFrequencies(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testErdosRenyiFrequencies(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testErdosRenyiFrequencies(list, t)
+}
+
+// Every pair of vertices is joined with probability p, including the pairs next to the diagonal of the matrix.
+func testErdosRenyiFrequencies(graph structures.DirectedWeightedGraph, t *testing.T) {
+	const trials = 4000
+	for _, directed := range []bool{false, true} {
+		for _, p := range []float64{0.1, 0.5} {
+			counts := make(map[[2]string]int)
+			for seed := range uint64(trials) {
+				testError(structures.ErdosRenyi(graph, 4, p, structures.GeneratorOptions{Seed: seed, Directed: directed}), t)
+				for edge := range graph.Edges() {
+					counts[[2]string{edge.From(), edge.To()}]++
+				}
+			}
+			// The count of each pair is binomial, with a standard deviation of at most 32 over 4000 trials.
+			for from := range 4 {
+				for to := range 4 {
+					pair := [2]string{strconv.Itoa(from), strconv.Itoa(to)}
+					expected := p * trials
+					if from == to {
+						expected = 0
+					}
+					if count := float64(counts[pair]); math.Abs(count-expected) > 150 {
+						t.Errorf("Directed %v, p = %v: %s->%s should be joined about %v times, got %v", directed, p, pair[0], pair[1], expected, count)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGeneratorsUndirected(t *testing.T) {
+	matrix := &structures.UndirectedWeightedAdjacencyMatrix{}
+	testGeneratorsUndirected(matrix, t)
+
+	list := &structures.UndirectedWeightedAdjacencyList{}
+	testGeneratorsUndirected(list, t)
+}
+
+func testGeneratorsUndirected(graph structures.WeightedGraph, t *testing.T) {
+	options := structures.GeneratorOptions{Seed: 1, Weight: uniformWeights(1, 9, t)}
+	testError(structures.ErdosRenyi(graph, 5, 1, options), t)
+	testGraphNumberOfEdges(graph, 10, t)
+
+	// Undirected graphs get each edge of the directed graph from the same seed once, with the same weight.
+	directed := &structures.AdjacencyList{}
+	for name, generate := range map[string]func(g structures.WeightedGraph) error{
+		"ErdosRenyi":     func(g structures.WeightedGraph) error { return structures.ErdosRenyi(g, 30, 0.2, options) },
+		"BarabasiAlbert": func(g structures.WeightedGraph) error { return structures.BarabasiAlbert(g, 30, 2, options) },
+		"WattsStrogatz":  func(g structures.WeightedGraph) error { return structures.WattsStrogatz(g, 30, 4, 0.3, options) },
+		"Grid":           func(g structures.WeightedGraph) error { return structures.Grid(g, 5, 4, options) },
+		"Complete":       func(g structures.WeightedGraph) error { return structures.Complete(g, 6, options) },
+		"Star":           func(g structures.WeightedGraph) error { return structures.Star(g, 6, options) },
+		"RandomTree":     func(g structures.WeightedGraph) error { return structures.RandomTree(g, 30, options) },
+	} {
+		testError(generate(graph), t)
+		testError(generate(directed), t)
+		if graph.NumberOfEdges()*2 != directed.NumberOfEdges() {
+			t.Errorf("%s: undirected graph should have %d edges, got %d", name, directed.NumberOfEdges()/2, graph.NumberOfEdges())
+		}
+		for edge := range graph.Edges() {
+			between := directed.EdgesBetween(edge.From(), edge.To())
+			if len(between) != 1 || between[0].Weight() != edge.Weight() {
+				t.Errorf("%s: edge %s-%s of weight %d should match the directed graph", name, edge.From(), edge.To(), edge.Weight())
+			}
+		}
+	}
+
+	if structures.RandomDAG(graph, 5, 0.5, options) == nil {
+		t.Error("RandomDAG should throw error, the graph is undirected")
+	}
+	options.Directed = true
+	if structures.ErdosRenyi(graph, 5, 0.5, options) == nil {
+		t.Error("Directed ErdosRenyi should throw error, the graph is undirected")
+	}
+}
+
+func BenchmarkShortestPathsErdosRenyi(b *testing.B) {
+	graph := &structures.AdjacencyList{}
+	weights, _ := structures.UniformWeights(1, 100)
+	structures.ErdosRenyi(graph, 10000, 0.001, structures.GeneratorOptions{Seed: 1, Weight: weights})
+	for b.Loop() {
+		structures.ShortestPaths(graph, "0")
+	}
+}
+
+func testGenerated(graph structures.DirectedWeightedGraph, vertices int, edges int, t *testing.T) {
+	testGraphNumberOfVertices(graph, vertices, t)
+	testGraphNumberOfEdges(graph, edges, t)
+}
+
+// Checks that every edge has a reverse edge with the same weight.
+func testSymmetric(graph structures.DirectedWeightedGraph, t *testing.T) {
+	for edge := range graph.Edges() {
+		weights := make([]int, 0)
+		for _, reverse := range graph.EdgesBetween(edge.To(), edge.From()) {
+			weights = append(weights, reverse.Weight())
+		}
+		if !slices.Contains(weights, edge.Weight()) {
+			t.Errorf("Edge %s->%s should have a reverse edge of weight %d, got %v", edge.From(), edge.To(), edge.Weight(), weights)
+		}
+	}
+}
+
+// Checks that there are no self loops or parallel edges.
+func testSimple(graph structures.DirectedWeightedGraph, t *testing.T) {
+	for edge := range graph.Edges() {
+		if edge.From() == edge.To() {
+			t.Errorf("Generated graph should not have self loop at %s", edge.From())
+		}
+		if n := len(graph.EdgesBetween(edge.From(), edge.To())); n != 1 {
+			t.Errorf("Generated graph should have one edge %s->%s, got %d", edge.From(), edge.To(), n)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package structures_test
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 
 	"../structures"
@@ -50,7 +51,7 @@ func testGraph(graph structures.DirectedWeightedGraph, t *testing.T) {
 	}
 	testGraphNumberOfEdges(graph, 7, t)
 
-	err = graph.AddEdge("a", "b", 100)
+	_, err = graph.AddEdge("a", "b", 100)
 	if err == nil {
 		t.Error("Graph should have thrown error, edge A-B already exists")
 	}
@@ -90,18 +91,50 @@ func testGraph(graph structures.DirectedWeightedGraph, t *testing.T) {
 	}
 }
 
-func testGraphNumberOfVertices(graph structures.DirectedWeightedGraph, expected int, t *testing.T) {
+func testGraphNumberOfVertices(graph structures.Graph, expected int, t *testing.T) {
 	if graph.NumberOfVertices() != expected {
 		t.Errorf("Graph should contain %d vertices, got %d", expected, graph.NumberOfVertices())
 	}
 }
 
-func testGraphNumberOfEdges(graph structures.DirectedWeightedGraph, expected int, t *testing.T) {
+func testGraphNumberOfEdges(graph structures.Graph, expected int, t *testing.T) {
 	if graph.NumberOfEdges() != expected {
 		t.Errorf("Graph should contain %d edges, got %d", expected, graph.NumberOfEdges())
 	}
 }
 
+// Checks the order a traversal visits the vertices in from a source that exists.
+func testTraversal(name string, traverse func(source string) ([]string, error), source string, expected []string, t *testing.T) {
+	result, err := traverse(source)
+	testError(err, t)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("%s from %s should be %v, got %v", name, source, expected, result)
+	}
+}
+
+func TestTraversals(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	resetToGraphA(matrix, t)
+	testTraversals(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	resetToGraphA(list, t)
+	testTraversals(list, t)
+	testTraversals(list.ToCSR(), t)
+}
+
+// Checks the traversals of graph A.
+func testTraversals(graph structures.DirectedWeightedGraph, t *testing.T) {
+	testTraversal("BFS", graph.BFS, "a", []string{"a", "c", "f", "g", "b", "d", "e"}, t)
+	testTraversal("DFS", graph.DFS, "a", []string{"a", "c", "f", "b", "d", "g", "e"}, t)
+	if _, err := graph.BFS("z"); err == nil {
+		t.Error("BFS should throw error, vertex Z does not exist")
+	}
+	if _, err := graph.DFS("z"); err == nil {
+		t.Error("DFS should throw error, vertex Z does not exist")
+	}
+}
+
 func resetToGraphA(graph structures.DirectedWeightedGraph, t *testing.T) {
 	graph.Clear()
 	graph.AddAllVertices([]string{"a", "b", "c", "d", "e", "f", "g"})
@@ -125,3 +158,132 @@ func resetToGraphA(graph structures.DirectedWeightedGraph, t *testing.T) {
 	testGraphNumberOfVertices(graph, 7, t)
 	testGraphNumberOfEdges(graph, 17, t)
 }
+
+func TestGraphIterators(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testGraphIterators(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testGraphIterators(list, t)
+}
+
+func testGraphIterators(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToGraphA(graph, t)
+	if !reflect.DeepEqual(slices.Collect(graph.Vertices()), []string{"a", "b", "c", "d", "e", "f", "g"}) {
+		t.Errorf("Vertices incorrect, got %v", slices.Collect(graph.Vertices()))
+	}
+
+	edges := 0
+	totalWeight := 0
+	for edge := range graph.Edges() {
+		edges++
+		totalWeight += edge.Weight()
+		if edge.From() == "a" && edge.To() == "c" && edge.Weight() != 10 {
+			t.Errorf("Edge A-C should have weight 10, got %d", edge.Weight())
+		}
+	}
+	if edges != 17 || totalWeight != 132 {
+		t.Errorf("Edges incorrect, expected 17 edges of total weight 132, got %d edges of total weight %d", edges, totalWeight)
+	}
+
+	neighbours := make(map[string]int)
+	for neighbour, weight := range graph.Neighbours("d") {
+		neighbours[neighbour] = weight
+	}
+	if !reflect.DeepEqual(neighbours, map[string]int{"a": 9, "c": 4, "f": 8, "g": 6}) {
+		t.Errorf("Neighbours of D incorrect, got %v", neighbours)
+	}
+	graph.RemoveEdge("d", "c")
+	for neighbour := range graph.Neighbours("d") {
+		if neighbour == "c" {
+			t.Error("C should not be a neighbour of D after removing the edge")
+		}
+	}
+	for range graph.Neighbours("z") {
+		t.Error("Neighbours should not yield anything, Z does not exist")
+	}
+
+	for vertex := range graph.Vertices() {
+		if vertex != "a" {
+			t.Error("Iteration should stop after the first vertex")
+		}
+		break
+	}
+
+	testModifiedDuringIteration(func() {
+		for vertex := range graph.Vertices() {
+			graph.RemoveVertex(vertex)
+		}
+	}, t)
+}
+
+func TestShortestPaths(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testShortestPaths(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testShortestPaths(list, t)
+}
+
+func testShortestPaths(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToGraphA(graph, t)
+	graph.AddVertex("h")
+	_, err := structures.ShortestPaths(graph, "z")
+	if err == nil {
+		t.Error("ShortestPaths should throw error, vertex Z does not exist")
+	}
+
+	fromA, err := structures.ShortestPaths(graph, "a")
+	testError(err, t)
+	fromE, err := structures.ShortestPaths(graph, "e")
+	testError(err, t)
+	testPathTo(fromA, "e", []string{"a", "c", "g", "e"}, []int{0, 10, 16, 22}, t)
+	testPathTo(fromE, "b", []string{"e", "d", "g", "b"}, []int{0, 2, 8, 11}, t)
+	testPathTo(fromA, "a", []string{"a"}, []int{0}, t)
+	if fromA.Reachable("h") {
+		t.Error("H should not be reachable")
+	}
+	_, err = fromA.DistanceTo("h")
+	if err == nil {
+		t.Error("DistanceTo should throw error, vertex H is unreachable")
+	}
+	_, err = fromA.PathTo("h")
+	if err == nil {
+		t.Error("PathTo should throw error, vertex H is unreachable")
+	}
+
+	// Results are not affected by later changes to the graph, the stored result of Dijkstra is invalidated.
+	testError(graph.Dijkstra("a"), t)
+	graph.RemoveEdge("c", "g")
+	testPathTo(fromA, "e", []string{"a", "c", "g", "e"}, []int{0, 10, 16, 22}, t)
+	_, err = graph.GetShortestPath("e")
+	if err == nil {
+		t.Error("GetShortestPath should throw error, the graph was modified")
+	}
+	if graph.Dijkstra("z") == nil {
+		t.Error("Dijkstra should throw error, vertex Z does not exist")
+	}
+	_, err = graph.GetShortestPath("e")
+	if err == nil {
+		t.Error("GetShortestPath should throw error, vertex Z does not exist")
+	}
+}
+
+func testPathTo(paths *structures.PathTree, target string, expectedLabels []string, expectedDistances []int, t *testing.T) {
+	path, err := paths.PathTo(target)
+	testError(err, t)
+	labels := make([]string, 0)
+	distances := make([]int, 0)
+	for _, item := range path {
+		labels = append(labels, item.Value)
+		distances = append(distances, item.Distance)
+	}
+	if !reflect.DeepEqual(labels, expectedLabels) || !reflect.DeepEqual(distances, expectedDistances) {
+		t.Errorf("Path from %s to %s incorrect, got %v with distances %v", paths.Source(), target, labels, distances)
+	}
+	distance, err := paths.DistanceTo(target)
+	testError(err, t)
+	if distance != expectedDistances[len(expectedDistances)-1] {
+		t.Errorf("Distance from %s to %s incorrect, got %d", paths.Source(), target, distance)
+	}
+}
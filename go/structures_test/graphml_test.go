@@ -0,0 +1,67 @@
+package structures_test
+
+import (
+	"strings"
+	"testing"
+
+	"../structures"
+)
+
+func TestGraphML(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	list := &structures.AdjacencyList{}
+	testGraphML(matrix, list, t)
+	testGraphML(list, matrix, t)
+}
+
+func testGraphML(graph structures.DirectedWeightedGraph, decoded structures.DirectedWeightedGraph, t *testing.T) {
+	resetToEncodingGraph(graph, t)
+	var b strings.Builder
+	testError(structures.EncodeGraphML(graph, &b), t)
+	testError(structures.DecodeGraphML(decoded, strings.NewReader(b.String())), t)
+	testSameGraph(graph, decoded, true, t)
+
+	// Weights are found by attribute name, other data and elements are skipped.
+	input := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="colour" attr.type="string"/>
+  <key id="d1" for="edge" attr.name="weight" attr.type="int"/>
+  <graph id="G" edgedefault="directed">
+    <desc>Example</desc>
+    <node id="x"><data key="d0">red</data></node>
+    <node id="y"/>
+    <edge source="x" target="y"><data key="d1"> 7 </data></edge>
+    <edge id="e1" source="y" target="x"/>
+  </graph>
+</graphml>
+`
+	testError(structures.DecodeGraphML(decoded, strings.NewReader(input)), t)
+	testDecoded(decoded, []string{"x", "y"}, []string{"x->y 7", "y->x 1"}, t)
+
+	for _, test := range []struct {
+		input  string
+		line   int
+		column int
+	}{
+		{"<graphml>\n  <graph>\n    <node id=\"a\"/>\n    <edge source=\"a\" target=\"b\"/>\n  </graph>\n</graphml>", 4, 5},
+		{"<graphml><graph>\n<node id=\"a\"/><edge source=\"a\" target=\"a\"><data key=\"weight\">1.5</data></edge>\n</graph></graphml>", 2, 62},
+		{"<graphml><graph edgedefault=\"undirected\"/></graphml>", 1, 10},
+		{"<graphml>\n<graph>\n<node/>", 3, 1},
+		{"<graphml>\n<graph>\n</graphml>", 3, 11},
+		{"<graphml/>", 1, 11},
+	} {
+		testParseError(structures.DecodeGraphML(decoded, strings.NewReader(test.input)), "GraphML", test.line, test.column, t)
+	}
+	// The graph is unchanged when decoding fails, also when an edge is rejected by the parallel edge policy.
+	decoded.SetParallelEdgePolicy(structures.RejectParallelEdges)
+	input = "<graphml><graph>\n<node id=\"a\"/><node id=\"b\"/>\n<edge source=\"a\" target=\"b\"/>\n<edge source=\"a\" target=\"b\"/>\n</graph></graphml>"
+	testParseError(structures.DecodeGraphML(decoded, strings.NewReader(input)), "GraphML", 4, 1, t)
+	decoded.SetParallelEdgePolicy(structures.AllowParallelEdges)
+	testDecoded(decoded, []string{"x", "y"}, []string{"x->y 7", "y->x 1"}, t)
+
+	// Nodes need an id, so a vertex with an empty name cannot be written.
+	graph.AddVertex("")
+	if structures.EncodeGraphML(graph, &b) == nil {
+		t.Error("EncodeGraphML should throw error, a vertex has an empty name")
+	}
+}
@@ -2,6 +2,7 @@ package structures_test
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 
 	"../structures"
@@ -58,7 +59,81 @@ func TestHashmap(t *testing.T) {
 		t.Error("Hashmap should not contain value 5")
 	}
 
-	if !reflect.DeepEqual(hashmap.Values(), []int{2, 3, 4}) {
+	if !reflect.DeepEqual(slices.Collect(hashmap.Values()), []int{2, 3, 4}) {
 		t.Error("Map should contain {2, 3, 4}")
 	}
 }
+
+func TestGenericHashmap(t *testing.T) {
+	type coordinate struct{ row, column int }
+	hashmap := structures.HashmapOf[coordinate, []string]{}
+
+	hashmap.Put(coordinate{0, 0}, []string{"origin"})
+	hashmap.Put(coordinate{1, 2}, []string{"a", "b"})
+	hashmap.Put(coordinate{0, 0}, []string{"start"})
+	if hashmap.Size() != 2 {
+		t.Errorf("Size should be 2, got %d", hashmap.Size())
+	}
+	value, err := hashmap.Get(coordinate{0, 0})
+	testError(err, t)
+	if !reflect.DeepEqual(value, []string{"start"}) {
+		t.Errorf("Get incorrect, expected [start], got %v", value)
+	}
+	if !hashmap.ContainsValue([]string{"a", "b"}) {
+		t.Error("Hashmap should contain value [a b]")
+	}
+
+	value, err = hashmap.Remove(coordinate{1, 2})
+	testError(err, t)
+	if !reflect.DeepEqual(value, []string{"a", "b"}) {
+		t.Errorf("Remove incorrect, expected [a b], got %v", value)
+	}
+	if hashmap.ContainsKey(coordinate{1, 2}) {
+		t.Error("Hashmap should not contain key {1 2} after removing it")
+	}
+	_, err = hashmap.Remove(coordinate{1, 2})
+	if err == nil {
+		t.Error("Hashmap should throw an error, the key {1 2} was already removed")
+	}
+	if !reflect.DeepEqual(slices.Collect(hashmap.Values()), [][]string{{"start"}}) {
+		t.Error("Map should contain {[start]}")
+	}
+}
+
+func TestHashmapIterators(t *testing.T) {
+	hashmap := structures.Hashmap{}
+	hashmap.Put("c", 3)
+	hashmap.Put("a", 1)
+	hashmap.Put("b", 2)
+	hashmap.Remove("a")
+	hashmap.Put("a", 4)
+
+	if !reflect.DeepEqual(slices.Collect(hashmap.Keys()), []string{"c", "b", "a"}) {
+		t.Errorf("Keys should be in insertion order, got %v", slices.Collect(hashmap.Keys()))
+	}
+	for key, value := range hashmap.All() {
+		expected, err := hashmap.Get(key)
+		testError(err, t)
+		if value != expected {
+			t.Errorf("All incorrect, expected %d for %s, got %d", expected, key, value)
+		}
+		// Overriding values is not a structural modification.
+		hashmap.Put(key, value*10)
+	}
+	if !reflect.DeepEqual(slices.Collect(hashmap.Values()), []int{30, 20, 40}) {
+		t.Errorf("Values incorrect, got %v", slices.Collect(hashmap.Values()))
+	}
+
+	for key := range hashmap.Keys() {
+		if key != "c" {
+			t.Error("Iteration should stop after the first key")
+		}
+		break
+	}
+
+	testModifiedDuringIteration(func() {
+		for key := range hashmap.Keys() {
+			hashmap.Remove(key)
+		}
+	}, t)
+}
@@ -2,6 +2,7 @@ package structures_test
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 
 	"../structures"
@@ -126,3 +127,70 @@ func TestLinkedList(t *testing.T) {
 		t.Errorf("2 should have been found in the list, got %d", value)
 	}
 }
+
+func TestGenericLinkedList(t *testing.T) {
+	type point struct{ x, y int }
+	ll := structures.LinkedListOf[point]{}
+	ll.AppendAll([]point{{0, 0}, {1, 2}, {3, 4}})
+
+	value, err := ll.Get(1)
+	testError(err, t)
+	if value != (point{1, 2}) {
+		t.Errorf("Get incorrect, expected {1 2}, got %v", value)
+	}
+	if !ll.Contains(point{3, 4}) {
+		t.Error("List should contain {3 4}")
+	}
+	err = ll.Remove(point{0, 0})
+	testError(err, t)
+	if !reflect.DeepEqual(ll.ToArray(), []point{{1, 2}, {3, 4}}) {
+		t.Error("List should contain {1 2} and {3 4}")
+	}
+	err = ll.Remove(point{5, 5})
+	if err == nil {
+		t.Error("Remove should throw an error, {5 5} is not in the list")
+	}
+}
+
+func TestLinkedListIterators(t *testing.T) {
+	ll := structures.LinkedList{}
+	ll.AppendAll([]int{2, 3, 5})
+	ll.Add(1, 0)
+	ll.Add(4, 3)
+
+	if !reflect.DeepEqual(slices.Collect(ll.Values()), []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Values incorrect, got %v", slices.Collect(ll.Values()))
+	}
+	backward := make([]int, 0)
+	for index, value := range ll.Backward() {
+		if index != value-1 {
+			t.Errorf("Backward index incorrect, expected %d, got %d", value-1, index)
+		}
+		backward = append(backward, value)
+	}
+	if !reflect.DeepEqual(backward, []int{5, 4, 3, 2, 1}) {
+		t.Errorf("Backward incorrect, got %v", backward)
+	}
+
+	// Test early termination.
+	visited := 0
+	for index := range ll.All() {
+		visited++
+		if index == 1 {
+			break
+		}
+	}
+	if visited != 2 {
+		t.Errorf("Iteration should stop after 2 elements, visited %d", visited)
+	}
+
+	testModifiedDuringIteration(func() {
+		for value := range ll.Values() {
+			ll.Append(value)
+		}
+	}, t)
+	ll.Clear()
+	for range ll.Backward() {
+		t.Error("Backward should not yield anything, the list is empty")
+	}
+}
@@ -0,0 +1,204 @@
+package structures_test
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"../structures"
+)
+
+func TestMaxFlow(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testMaxFlow(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testMaxFlow(list, t)
+}
+
+func testMaxFlow(graph structures.DirectedWeightedGraph, t *testing.T) {
+	algorithms := map[string]func(graph structures.Graph, source string, sink string) (*structures.FlowResult, error){
+		"EdmondsKarp": structures.EdmondsKarp,
+		"Dinic":       structures.Dinic,
+		"MinCostMaxFlow": func(graph structures.Graph, source string, sink string) (*structures.FlowResult, error) {
+			return structures.MinCostMaxFlow(graph, source, sink, nil)
+		},
+	}
+
+	resetToFlowNetwork(graph, t)
+	for name, algorithm := range algorithms {
+		result, err := algorithm(graph, "s", "t")
+		testError(err, t)
+		testFlow(name, graph, result, "s", "t", 23, t)
+		if !reflect.DeepEqual(result.SourceSide, []string{"s", "v1", "v2", "v4"}) || !reflect.DeepEqual(result.SinkSide, []string{"v3", "t"}) {
+			t.Errorf("%s cut incorrect, got %v and %v", name, result.SourceSide, result.SinkSide)
+		}
+		cut := make([]string, 0)
+		for _, edge := range result.CutEdges {
+			cut = append(cut, edge.From()+"-"+edge.To())
+		}
+		if !reflect.DeepEqual(cut, []string{"v1-v3", "v4-v3", "v4-t"}) {
+			t.Errorf("%s cut edges incorrect, got %v", name, cut)
+		}
+
+		// Nothing flows against the edges.
+		result, err = algorithm(graph, "t", "s")
+		testError(err, t)
+		testFlow(name, graph, result, "t", "s", 0, t)
+		if !reflect.DeepEqual(result.SourceSide, []string{"t"}) {
+			t.Errorf("%s source side should only contain t, got %v", name, result.SourceSide)
+		}
+
+		_, err = algorithm(graph, "s", "z")
+		if err == nil {
+			t.Errorf("%s should throw error, z does not exist", name)
+		}
+		_, err = algorithm(graph, "s", "s")
+		if err == nil {
+			t.Errorf("%s should throw error, source and sink are the same", name)
+		}
+	}
+
+	graph.AddEdge("v1", "v2", -1)
+	for name, algorithm := range algorithms {
+		_, err := algorithm(graph, "s", "t")
+		if err == nil {
+			t.Errorf("%s should throw error, v1->v2 has a negative capacity", name)
+		}
+	}
+}
+
+func TestMinCostMaxFlow(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testMinCostMaxFlow(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testMinCostMaxFlow(list, t)
+}
+
+func testMinCostMaxFlow(graph structures.DirectedWeightedGraph, t *testing.T) {
+	graph.Clear()
+	graph.AddAllVertices([]string{"s", "a", "b", "t"})
+	costs := map[string]int{"s-a": 1, "s-b": 4, "a-b": 1, "a-t": 5, "b-t": 1}
+	graph.AddEdge("s", "a", 2)
+	graph.AddEdge("s", "b", 2)
+	graph.AddEdge("a", "b", 1)
+	graph.AddEdge("a", "t", 2)
+	graph.AddEdge("b", "t", 3)
+	cost := func(edge *structures.Edge) int {
+		return costs[edge.From()+"-"+edge.To()]
+	}
+
+	result, err := structures.MinCostMaxFlow(graph, "s", "t", cost)
+	testError(err, t)
+	testFlow("MinCostMaxFlow", graph, result, "s", "t", 4, t)
+	// Sending one unit from a through b is cheaper than sending both straight to t.
+	if result.Cost != 19 {
+		t.Errorf("Flow should cost 19, got %d", result.Cost)
+	}
+	for edge, flow := range result.Flows {
+		if edge.From() == "a" && edge.To() == "b" && flow != 1 {
+			t.Errorf("a->b should carry 1 unit, got %d", flow)
+		}
+	}
+
+	// A cycle of negative cost can always be made cheaper.
+	costs["b-a"] = -2
+	graph.AddEdge("b", "a", 1)
+	_, err = structures.MinCostMaxFlow(graph, "s", "t", cost)
+	if err == nil {
+		t.Error("MinCostMaxFlow should throw error, a->b->a has a negative cost")
+	}
+}
+
+func TestMaxFlowRandom(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testMaxFlowRandom(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testMaxFlowRandom(list, t)
+}
+
+// Every algorithm finds the same flow value on random graphs.
+func testMaxFlowRandom(graph structures.DirectedWeightedGraph, t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for range 50 {
+		graph.Clear()
+		n := 2 + rng.IntN(10)
+		for i := range n {
+			graph.AddVertex(strconv.Itoa(i))
+		}
+		for range rng.IntN(4 * n) {
+			graph.AddEdge(strconv.Itoa(rng.IntN(n)), strconv.Itoa(rng.IntN(n)), rng.IntN(20))
+		}
+		sink := strconv.Itoa(n - 1)
+		expected, err := structures.EdmondsKarp(graph, "0", sink)
+		testError(err, t)
+		testFlow("EdmondsKarp", graph, expected, "0", sink, expected.Value, t)
+		result, err := structures.Dinic(graph, "0", sink)
+		testError(err, t)
+		testFlow("Dinic", graph, result, "0", sink, expected.Value, t)
+		result, err = structures.MinCostMaxFlow(graph, "0", sink, func(edge *structures.Edge) int {
+			return len(edge.From()) + len(edge.To())
+		})
+		testError(err, t)
+		testFlow("MinCostMaxFlow", graph, result, "0", sink, expected.Value, t)
+	}
+}
+
+func resetToFlowNetwork(graph structures.DirectedWeightedGraph, t *testing.T) {
+	graph.Clear()
+	graph.AddAllVertices([]string{"s", "v1", "v2", "v3", "v4", "t"})
+	graph.AddEdge("s", "v1", 16)
+	graph.AddEdge("s", "v2", 13)
+	graph.AddEdge("v1", "v3", 12)
+	graph.AddEdge("v2", "v1", 4)
+	graph.AddEdge("v2", "v4", 14)
+	graph.AddEdge("v3", "v2", 9)
+	graph.AddEdge("v3", "t", 20)
+	graph.AddEdge("v4", "v3", 7)
+	graph.AddEdge("v4", "t", 4)
+	testGraphNumberOfVertices(graph, 6, t)
+	testGraphNumberOfEdges(graph, 9, t)
+}
+
+// Checks capacities and conservation of the flow, and that the cut edges are saturated and add up to the flow value.
+func testFlow(name string, graph structures.DirectedWeightedGraph, result *structures.FlowResult, source string, sink string, expected int, t *testing.T) {
+	if result.Value != expected {
+		t.Errorf("%s flow from %s to %s should be %d, got %d", name, source, sink, expected, result.Value)
+	}
+	if len(result.Flows) != graph.NumberOfEdges() {
+		t.Errorf("%s should give the flow through all %d edges, got %d", name, graph.NumberOfEdges(), len(result.Flows))
+	}
+	excess := make(map[string]int)
+	for edge := range graph.Edges() {
+		flow := result.Flows[edge]
+		if flow < 0 || flow > edge.Weight() {
+			t.Errorf("%s flow through %s->%s should be between 0 and %d, got %d", name, edge.From(), edge.To(), edge.Weight(), flow)
+		}
+		excess[edge.From()] -= flow
+		excess[edge.To()] += flow
+	}
+	for vertex := range graph.Vertices() {
+		if vertex != source && vertex != sink && excess[vertex] != 0 {
+			t.Errorf("%s flow into %s should equal the flow out, got a difference of %d", name, vertex, excess[vertex])
+		}
+	}
+	if excess[sink] != expected {
+		t.Errorf("%s flow into %s should be %d, got %d", name, sink, expected, excess[sink])
+	}
+	capacity := 0
+	for _, edge := range result.CutEdges {
+		capacity += edge.Weight()
+		if result.Flows[edge] != edge.Weight() {
+			t.Errorf("%s cut edge %s->%s should be saturated", name, edge.From(), edge.To())
+		}
+	}
+	if capacity != expected {
+		t.Errorf("%s cut capacity should be %d, got %d", name, expected, capacity)
+	}
+	if len(result.SourceSide)+len(result.SinkSide) != graph.NumberOfVertices() || result.SourceSide[0] != source {
+		t.Errorf("%s cut should partition the vertices with %s on the source side, got %v and %v", name, source, result.SourceSide, result.SinkSide)
+	}
+}
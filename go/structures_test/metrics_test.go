@@ -0,0 +1,214 @@
+package structures_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"../structures"
+)
+
+func TestDegrees(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	resetToGraphA(matrix, t)
+	testDegrees(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	resetToGraphA(list, t)
+	testDegrees(list, t)
+	testDegrees(list.ToCSR(), t)
+}
+
+func testDegrees(graph structures.DirectedWeightedGraph, t *testing.T) {
+	for vertex, expected := range map[string][2]int{"a": {3, 2}, "b": {2, 2}, "d": {3, 4}, "e": {1, 2}, "g": {2, 3}} {
+		in, err := structures.InDegree(graph, vertex)
+		testError(err, t)
+		out, err := structures.OutDegree(graph, vertex)
+		testError(err, t)
+		if in != expected[0] || out != expected[1] {
+			t.Errorf("Degrees of %s should be %v, got [%d %d]", vertex, expected, in, out)
+		}
+	}
+	if _, err := structures.InDegree(graph, "z"); err == nil {
+		t.Error("In-degree of a vertex not in the graph should throw error")
+	}
+	if _, err := structures.OutDegree(graph, "z"); err == nil {
+		t.Error("Out-degree of a vertex not in the graph should throw error")
+	}
+	distribution := structures.Degrees(graph)
+	if expected := map[int]int{1: 1, 2: 2, 3: 4}; !reflect.DeepEqual(distribution.In, expected) {
+		t.Errorf("In-degree distribution should be %v, got %v", expected, distribution.In)
+	}
+	if expected := map[int]int{2: 5, 3: 1, 4: 1}; !reflect.DeepEqual(distribution.Out, expected) {
+		t.Errorf("Out-degree distribution should be %v, got %v", expected, distribution.Out)
+	}
+	testFloat("Density", structures.Density(graph), 17.0/42, t)
+}
+
+func TestDegreesMultigraph(t *testing.T) {
+	graph := &structures.AdjacencyList{}
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b", "c"})
+	graph.AddEdge("a", "b", 1)
+	graph.AddEdge("a", "b", 2)
+	graph.AddEdge("b", "b", 3)
+
+	// Parallel edges and self loops count towards degrees but not density.
+	if degree, _ := structures.InDegree(graph, "b"); degree != 3 {
+		t.Errorf("In-degree of b should be 3, got %d", degree)
+	}
+	if degree, _ := structures.OutDegree(graph, "a"); degree != 2 {
+		t.Errorf("Out-degree of a should be 2, got %d", degree)
+	}
+	testFloat("Density", structures.Density(graph), 1.0/6, t)
+	graph.Clear()
+	testFloat("Density of an empty graph", structures.Density(graph), 0, t)
+	if distribution := structures.Degrees(graph); len(distribution.In) != 0 || len(distribution.Out) != 0 {
+		t.Errorf("Degree distribution of an empty graph should be empty, got %v", distribution)
+	}
+}
+
+func TestEccentricity(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testEccentricity(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testEccentricity(list, t)
+}
+
+func testEccentricity(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToGraphA(graph, t)
+	eccentricity, err := structures.Eccentricity(graph, "a")
+	testError(err, t)
+	if eccentricity != 22 {
+		t.Errorf("Eccentricity of a should be 22, got %d", eccentricity)
+	}
+	testEccentricities(graph, t)
+
+	// The centre of a grid is closest to everything, the corners furthest.
+	testError(structures.Grid(graph, 5, 5, structures.GeneratorOptions{}), t)
+	testEccentricities(graph, t)
+	diameter, err := structures.Diameter(graph)
+	testError(err, t)
+	radius, err := structures.Radius(graph)
+	testError(err, t)
+	if diameter != 8 || radius != 4 {
+		t.Errorf("5x5 grid should have diameter 8 and radius 4, got %d and %d", diameter, radius)
+	}
+
+	options := structures.GeneratorOptions{Seed: 3, Weight: uniformWeights(0, 20, t)}
+	testError(structures.WattsStrogatz(graph, 30, 4, 0.2, options), t)
+	testEccentricities(graph, t)
+
+	// A directed ring can only be walked one way.
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b", "c", "d"})
+	graph.AddEdge("a", "b", 1)
+	graph.AddEdge("b", "c", 1)
+	graph.AddEdge("c", "d", 1)
+	graph.AddEdge("d", "a", 1)
+	testEccentricities(graph, t)
+	if diameter, _ := structures.Diameter(graph); diameter != 3 {
+		t.Errorf("Ring of 4 should have diameter 3, got %d", diameter)
+	}
+
+	graph.RemoveEdge("d", "a")
+	if _, err := structures.Eccentricity(graph, "b"); err == nil {
+		t.Error("Eccentricity of a vertex that cannot reach every vertex should throw error")
+	}
+	if eccentricity, err := structures.Eccentricity(graph, "a"); err != nil || eccentricity != 3 {
+		t.Errorf("Eccentricity of a should be 3, got %d, %v", eccentricity, err)
+	}
+	if _, err := structures.Diameter(graph); err == nil {
+		t.Error("Diameter of a graph that is not strongly connected should throw error")
+	}
+	if _, err := structures.Radius(graph); err == nil {
+		t.Error("Radius of a graph that is not strongly connected should throw error")
+	}
+	graph.AddEdge("d", "a", -1)
+	if _, err := structures.Eccentricity(graph, "a"); err == nil {
+		t.Error("Eccentricity with a negative edge weight should throw error")
+	}
+	if _, err := structures.Eccentricity(graph, "z"); err == nil {
+		t.Error("Eccentricity of a vertex not in the graph should throw error")
+	}
+	graph.Clear()
+	if _, err := structures.Diameter(graph); err == nil {
+		t.Error("Diameter of an empty graph should throw error")
+	}
+}
+
+// Checks the eccentricity of every vertex and the diameter and radius against the Floyd-Warshall distances.
+func testEccentricities(graph structures.DirectedWeightedGraph, t *testing.T) {
+	paths, err := structures.FloydWarshall(graph)
+	testError(err, t)
+	diameter, radius := 0, math.MaxInt
+	for from := range graph.Vertices() {
+		expected := 0
+		for to := range graph.Vertices() {
+			distance, _ := paths.Distance(from, to)
+			expected = max(expected, distance)
+		}
+		eccentricity, err := structures.Eccentricity(graph, from)
+		testError(err, t)
+		if eccentricity != expected {
+			t.Errorf("Eccentricity of %s should be %d, got %d", from, expected, eccentricity)
+		}
+		diameter, radius = max(diameter, expected), min(radius, expected)
+	}
+	if actual, err := structures.Diameter(graph); err != nil || actual != diameter {
+		t.Errorf("Diameter should be %d, got %d, %v", diameter, actual, err)
+	}
+	if actual, err := structures.Radius(graph); err != nil || actual != radius {
+		t.Errorf("Radius should be %d, got %d, %v", radius, actual, err)
+	}
+}
+
+func TestAverageClustering(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testAverageClustering(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testAverageClustering(list, t)
+}
+
+func testAverageClustering(graph structures.DirectedWeightedGraph, t *testing.T) {
+	// A triangle with edges in one direction only and a vertex hanging off it.
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b", "c", "d"})
+	graph.AddEdge("a", "b", 1)
+	graph.AddEdge("b", "c", 1)
+	graph.AddEdge("a", "c", 1)
+	graph.AddEdge("d", "a", 1)
+	testFloat("Clustering", structures.AverageClustering(graph), (1.0/3+1+1+0)/4, t)
+
+	// Opposite edges, parallel edges and self loops do not change it.
+	graph.AddEdge("c", "a", 1)
+	graph.AddEdge("a", "b", 2)
+	graph.AddEdge("b", "b", 1)
+	testFloat("Clustering", structures.AverageClustering(graph), (1.0/3+1+1+0)/4, t)
+
+	options := structures.GeneratorOptions{Seed: 1}
+	structures.Complete(graph, 8, options)
+	testFloat("Clustering of a complete graph", structures.AverageClustering(graph), 1, t)
+	structures.Star(graph, 8, options)
+	testFloat("Clustering of a star", structures.AverageClustering(graph), 0, t)
+	structures.Grid(graph, 4, 4, options)
+	testFloat("Clustering of a grid", structures.AverageClustering(graph), 0, t)
+
+	// Without rewiring each vertex of a ring lattice with k = 4 has 3 of its 6 pairs of neighbours joined.
+	structures.WattsStrogatz(graph, 20, 4, 0, options)
+	testFloat("Clustering of a ring lattice", structures.AverageClustering(graph), 0.5, t)
+	structures.WattsStrogatz(graph, 20, 4, 1, options)
+	if clustering := structures.AverageClustering(graph); clustering >= 0.5 {
+		t.Errorf("Rewiring should lower the clustering of a ring lattice, got %v", clustering)
+	}
+	graph.Clear()
+	testFloat("Clustering of an empty graph", structures.AverageClustering(graph), 0, t)
+}
+
+func testFloat(name string, actual float64, expected float64, t *testing.T) {
+	if math.Abs(actual-expected) > 1e-9 {
+		t.Errorf("%s should be %v, got %v", name, expected, actual)
+	}
+}
@@ -0,0 +1,145 @@
+package structures_test
+
+import (
+	"reflect"
+	"testing"
+
+	"../structures"
+)
+
+func TestMultigraph(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testMultigraph(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testMultigraph(list, t)
+}
+
+func testMultigraph(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToGraphA(graph, t)
+	ids := make(map[int]bool)
+	for edge := range graph.Edges() {
+		ids[edge.ID()] = true
+	}
+	if len(ids) != 17 {
+		t.Errorf("Edges should have 17 different IDs, got %d", len(ids))
+	}
+
+	// Parallel edges are allowed by default, and algorithms take the lighter one.
+	slow := graph.EdgesBetween("a", "c")[0].ID()
+	fast, err := graph.AddEdge("a", "c", 3)
+	testError(err, t)
+	if ids[fast] {
+		t.Errorf("New edge should have a new ID, got %d", fast)
+	}
+	testGraphNumberOfEdges(graph, 18, t)
+	testEdgesBetween(graph, "a", "c", []int{slow, fast}, []int{10, 3}, t)
+	testEdgesBetween(graph, "c", "a", []int{}, []int{}, t)
+	paths, err := structures.ShortestPaths(graph, "a")
+	testError(err, t)
+	testPathTo(paths, "c", []string{"a", "c"}, []int{0, 3}, t)
+
+	// RemoveEdge removes the first edge added, RemoveEdgeByID a given one.
+	testError(graph.RemoveEdge("a", "c"), t)
+	testEdgesBetween(graph, "a", "c", []int{fast}, []int{3}, t)
+	slow, _ = graph.AddEdge("a", "c", 10)
+	testError(graph.RemoveEdgeByID(fast), t)
+	testEdgesBetween(graph, "a", "c", []int{slow}, []int{10}, t)
+	if graph.RemoveEdgeByID(fast) == nil {
+		t.Error("RemoveEdgeByID should throw error, the edge was removed")
+	}
+	// IDs are not reused.
+	if id, _ := graph.AddEdge("a", "c", 3); id == fast {
+		t.Errorf("New edge should not reuse ID %d", fast)
+	}
+	graph.RemoveEdge("a", "c")
+	testGraphNumberOfEdges(graph, 17, t)
+
+	graph.SetParallelEdgePolicy(structures.RejectParallelEdges)
+	id, err := graph.AddEdge("d", "g", 1)
+	if err == nil || id != -1 {
+		t.Errorf("AddEdge should throw error, d->g exists, got ID %d", id)
+	}
+	testEdgesBetween(graph, "d", "g", []int{graph.EdgesBetween("d", "g")[0].ID()}, []int{6}, t)
+	// Opposite edges are not parallel.
+	_, err = graph.AddEdge("g", "d", 1)
+	testError(err, t)
+
+	graph.SetParallelEdgePolicy(structures.ReplaceParallelEdges)
+	old := graph.EdgesBetween("d", "g")[0].ID()
+	id, err = graph.AddEdge("d", "g", 1)
+	testError(err, t)
+	testEdgesBetween(graph, "d", "g", []int{id}, []int{1}, t)
+	if graph.RemoveEdgeByID(old) == nil {
+		t.Error("RemoveEdgeByID should throw error, the edge was replaced")
+	}
+	testGraphNumberOfEdges(graph, 18, t)
+
+	// The policy applies to edges added after it is set.
+	graph.SetParallelEdgePolicy(structures.AllowParallelEdges)
+	graph.AddEdge("e", "d", 4)
+	graph.SetParallelEdgePolicy(structures.RejectParallelEdges)
+	testEdgesBetween(graph, "e", "d", nil, []int{2, 4}, t)
+	graph.RemoveVertex("d")
+	testEdgesBetween(graph, "e", "d", []int{}, []int{}, t)
+	if _, err := graph.AddEdge("a", "z", 1); err == nil {
+		t.Error("AddEdge should throw error, z does not exist")
+	}
+}
+
+func TestUndirectedMultigraph(t *testing.T) {
+	matrix := &structures.UndirectedWeightedAdjacencyMatrix{}
+	testUndirectedMultigraph(matrix, t)
+
+	list := &structures.UndirectedWeightedAdjacencyList{}
+	testUndirectedMultigraph(list, t)
+}
+
+func testUndirectedMultigraph(graph structures.UndirectedWeightedGraph, t *testing.T) {
+	resetToUndirectedGraph(graph, t)
+	// Edges added in either direction are parallel.
+	first := graph.EdgesBetween("b", "a")[0].ID()
+	second, err := graph.AddEdge("b", "a", 2)
+	testError(err, t)
+	testGraphNumberOfEdges(graph, 15, t)
+	testEdgesBetween(graph, "a", "b", []int{first, second}, []int{4, 2}, t)
+	testError(graph.RemoveEdgeByID(first), t)
+	if weight, ok := neighbourWeights(graph, "a")["b"]; !ok || weight != 2 {
+		t.Errorf("A-B should have weight 2 from A, got %d", weight)
+	}
+	if weight, ok := neighbourWeights(graph, "b")["a"]; !ok || weight != 2 {
+		t.Errorf("A-B should have weight 2 from B, got %d", weight)
+	}
+
+	graph.SetParallelEdgePolicy(structures.RejectParallelEdges)
+	if _, err := graph.AddEdge("a", "b", 1); err == nil {
+		t.Error("AddEdge should throw error, A-B exists")
+	}
+	graph.SetParallelEdgePolicy(structures.ReplaceParallelEdges)
+	third, err := graph.AddEdge("a", "b", 1)
+	testError(err, t)
+	testEdgesBetween(graph, "b", "a", []int{third}, []int{1}, t)
+	testGraphNumberOfEdges(graph, 14, t)
+
+	testError(graph.RemoveEdgeByID(third), t)
+	testGraphNumberOfEdges(graph, 13, t)
+	if _, ok := neighbourWeights(graph, "b")["a"]; ok {
+		t.Error("A should not be a neighbour of B after removing the edge")
+	}
+}
+
+// Checks the IDs, skipped if nil, and the weights of the edges between two vertices.
+func testEdgesBetween(graph structures.Graph, from string, to string, expectedIDs []int, expectedWeights []int, t *testing.T) {
+	ids := make([]int, 0)
+	weights := make([]int, 0)
+	for _, edge := range graph.EdgesBetween(from, to) {
+		ids = append(ids, edge.ID())
+		weights = append(weights, edge.Weight())
+	}
+	if expectedIDs != nil && !reflect.DeepEqual(ids, expectedIDs) {
+		t.Errorf("Edges between %s and %s should have IDs %v, got %v", from, to, expectedIDs, ids)
+	}
+	if !reflect.DeepEqual(weights, expectedWeights) {
+		t.Errorf("Edges between %s and %s should have weights %v, got %v", from, to, expectedWeights, weights)
+	}
+}
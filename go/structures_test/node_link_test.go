@@ -0,0 +1,58 @@
+package structures_test
+
+import (
+	"strings"
+	"testing"
+
+	"../structures"
+)
+
+func TestNodeLinkJSON(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	list := &structures.AdjacencyList{}
+	testNodeLinkJSON(matrix, list, t)
+	testNodeLinkJSON(list, matrix, t)
+}
+
+func testNodeLinkJSON(graph structures.DirectedWeightedGraph, decoded structures.DirectedWeightedGraph, t *testing.T) {
+	resetToEncodingGraph(graph, t)
+	var b strings.Builder
+	testError(structures.EncodeJSON(graph, &b), t)
+	if !strings.HasPrefix(b.String(), "{\n  \"directed\": true,\n  \"multigraph\": true,\n  \"nodes\": [\n    {\n      \"id\": \"a\"\n    },") {
+		t.Errorf("JSON output should start with the directed flag and the nodes, got\n%s", b.String())
+	}
+	testError(structures.DecodeJSON(decoded, strings.NewReader(b.String())), t)
+	testSameGraph(graph, decoded, true, t)
+
+	// Links may come before nodes, unknown fields are skipped.
+	input := `{
+  "graph": {"name": "example"},
+  "links": [{"source": "x", "target": "y", "weight": 7}, {"source": "y", "target": "x", "key": 0}],
+  "nodes": [{"id": "x", "colour": "red"}, {"id": "y"}]
+}`
+	testError(structures.DecodeJSON(decoded, strings.NewReader(input)), t)
+	testDecoded(decoded, []string{"x", "y"}, []string{"x->y 7", "y->x 1"}, t)
+
+	for _, test := range []struct {
+		input  string
+		line   int
+		column int
+	}{
+		{"{\n  \"nodes\": [{\"id\": \"a\"},]\n}", 2, 24},
+		{"{\n  \"nodes\": [{\"id\": \"a\"}],\n  \"links\": [\n    {\"source\": \"a\", \"target\": \"a\"},\n    {\"source\": \"a\", \"target\": \"b\"}\n  ]\n}", 5, 5},
+		{"{\"nodes\": [{\"id\": \"a\"}], \"links\": [{\"source\": \"a\", \"target\": \"a\", \"weight\": 1.5}]}", 1, 36},
+		{"{\"directed\": false}", 1, 14},
+		{"{\"nodes\": [{\"name\": \"a\"}]}", 1, 12},
+		{"{\"nodes\": [\n", 2, 1},
+		{"[]", 1, 1},
+		{"{} {}", 1, 4},
+	} {
+		testParseError(structures.DecodeJSON(decoded, strings.NewReader(test.input)), "JSON", test.line, test.column, t)
+	}
+	// The graph is unchanged when decoding fails, also when a link is rejected by the parallel edge policy.
+	decoded.SetParallelEdgePolicy(structures.RejectParallelEdges)
+	input = "{\"nodes\": [{\"id\": \"a\"}, {\"id\": \"b\"}],\n\"links\": [\n{\"source\": \"a\", \"target\": \"b\"},\n{\"source\": \"a\", \"target\": \"b\"}\n]}"
+	testParseError(structures.DecodeJSON(decoded, strings.NewReader(input)), "JSON", 4, 1, t)
+	decoded.SetParallelEdgePolicy(structures.AllowParallelEdges)
+	testDecoded(decoded, []string{"x", "y"}, []string{"x->y 7", "y->x 1"}, t)
+}
@@ -0,0 +1,108 @@
+package structures_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"../structures"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	distances := map[string]int{"a": 5, "b": 3, "c": 8, "d": 1}
+	pq := structures.NewPriorityQueue(func(vertex string) int { return distances[vertex] })
+	for _, vertex := range []string{"a", "b", "c", "d"} {
+		testError(pq.Push(vertex), t)
+	}
+	if pq.Push("a") == nil {
+		t.Error("Push should throw error, the queue already contains a")
+	}
+	if pq.Len() != 4 {
+		t.Errorf("Queue length should be 4, got %d", pq.Len())
+	}
+	if !pq.Contains("c") || pq.Contains("e") {
+		t.Error("Contains incorrect")
+	}
+	front, err := pq.Peek()
+	testError(err, t)
+	if front != "d" {
+		t.Errorf("Peek incorrect, expected d, got %s", front)
+	}
+
+	// Priorities are cached until ChangePriority is called.
+	distances["c"] = 0
+	distances["d"] = 10
+	front, _ = pq.Peek()
+	if front != "d" {
+		t.Errorf("Priority should be cached, expected d, got %s", front)
+	}
+	testError(pq.ChangePriority("c"), t)
+	testError(pq.ChangePriority("d"), t)
+	if pq.ChangePriority("e") == nil {
+		t.Error("ChangePriority should throw error, the queue does not contain e")
+	}
+
+	result := make([]string, 0)
+	for !pq.IsEmpty() {
+		vertex, err := pq.Pop()
+		testError(err, t)
+		result = append(result, vertex)
+	}
+	if !reflect.DeepEqual(result, []string{"c", "b", "a", "d"}) {
+		t.Errorf("Pop order incorrect, got %v", result)
+	}
+	if pq.Contains("c") {
+		t.Error("Queue should not contain popped elements")
+	}
+	_, err = pq.Pop()
+	if err == nil {
+		t.Error("Pop should throw error, the queue is empty")
+	}
+	_, err = pq.Peek()
+	if err == nil {
+		t.Error("Peek should throw error, the queue is empty")
+	}
+}
+
+func TestPriorityQueueExtremePriorities(t *testing.T) {
+	// Priorities far apart must not overflow when compared.
+	priorities := map[string]int{"min": math.MinInt, "zero": 0, "max": math.MaxInt, "one": 1}
+	pq := structures.NewPriorityQueue(func(elem string) int { return priorities[elem] })
+	for _, elem := range []string{"max", "min", "one", "zero"} {
+		testError(pq.Push(elem), t)
+	}
+	result := make([]string, 0)
+	for !pq.IsEmpty() {
+		elem, _ := pq.Pop()
+		result = append(result, elem)
+	}
+	if !reflect.DeepEqual(result, []string{"min", "zero", "one", "max"}) {
+		t.Errorf("Pop order incorrect, got %v", result)
+	}
+}
+
+func TestPriorityQueueDecreaseKey(t *testing.T) {
+	distances := map[string]float64{"a": 2.5, "b": 1.5, "c": 3.5}
+	pq := structures.NewPriorityQueue(func(vertex string) float64 { return distances[vertex] })
+	for _, vertex := range []string{"a", "b", "c"} {
+		testError(pq.Push(vertex), t)
+	}
+	distances["c"] = 0.5
+	testError(pq.DecreaseKey("c"), t)
+	distances["a"] = 4.5
+	if pq.DecreaseKey("a") == nil {
+		t.Error("DecreaseKey should throw error, the priority of a increased")
+	}
+	if pq.DecreaseKey("d") == nil {
+		t.Error("DecreaseKey should throw error, the queue does not contain d")
+	}
+
+	result := make([]string, 0)
+	for !pq.IsEmpty() {
+		vertex, _ := pq.Pop()
+		result = append(result, vertex)
+	}
+	if !reflect.DeepEqual(result, []string{"c", "b", "a"}) {
+		t.Errorf("Pop order incorrect, got %v", result)
+	}
+}
@@ -66,3 +66,28 @@ func queueTests(queue structures.Queue, t *testing.T) {
 		return
 	}
 }
+
+func TestGenericQueue(t *testing.T) {
+	type job struct{ name string }
+	queues := []structures.QueueOf[*job]{&structures.ArrayQueueOf[*job]{}, &structures.LinkedQueueOf[*job]{}}
+	for _, queue := range queues {
+		first, second := &job{"first"}, &job{"second"}
+		queue.Enqueue(first)
+		queue.Enqueue(second)
+		front, err := queue.Dequeue()
+		testError(err, t)
+		if front != first {
+			t.Errorf("Dequeue incorrect, expected first, got %s", front.name)
+		}
+		front, err = queue.Peek()
+		testError(err, t)
+		if front != second {
+			t.Errorf("Peek incorrect, expected second, got %s", front.name)
+		}
+		queue.Clear()
+		front, err = queue.Dequeue()
+		if err == nil || front != nil {
+			t.Error("Dequeue should throw an error and return nil, the queue is empty")
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package structures_test
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	"../structures"
+)
+
+func TestRedBlackTree(t *testing.T) {
+	rbt := &structures.RedBlackTree{}
+	testOrderedTree(rbt, t)
+
+	// Sorted input would degrade a plain binary search tree into a list.
+	maxDepth := sortedInsertMaxDepth(rbt, 1000)
+	if float64(maxDepth) > 2*math.Log2(1001) {
+		t.Errorf("Red-black tree is too deep for 1000 sorted elements, got depth %d", maxDepth)
+	}
+	if err := rbt.CheckInvariants(); err != nil {
+		t.Error(err)
+	}
+	for i := 0; i < 1000; i += 2 {
+		err := rbt.Delete(i)
+		testError(err, t)
+		if err := rbt.CheckInvariants(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rbt.Size() != 500 {
+		t.Errorf("Tree size should be 500, got %d", rbt.Size())
+	}
+
+	values := &structures.RedBlackTreeOf[string, int]{}
+	values.InsertValue("b", 2)
+	values.InsertValue("a", 1)
+	values.Put("c", 3)
+	values.Put("a", 10)
+	if !reflect.DeepEqual(slices.Collect(values.Values()), []int{10, 2, 3}) {
+		t.Errorf("Values incorrect, got %v", slices.Collect(values.Values()))
+	}
+}
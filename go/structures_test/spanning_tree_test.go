@@ -0,0 +1,56 @@
+package structures_test
+
+import (
+	"reflect"
+	"testing"
+
+	"../structures"
+)
+
+func TestSpanningForest(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testSpanningForest(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testSpanningForest(list, t)
+}
+
+func testSpanningForest(graph structures.DirectedWeightedGraph, t *testing.T) {
+	algorithms := map[string]func(graph structures.Graph) *structures.SpanningForest{
+		"Prim":    structures.Prim,
+		"Kruskal": structures.Kruskal,
+		"Boruvka": structures.Boruvka,
+	}
+
+	resetToGraphA(graph, t)
+	for name, algorithm := range algorithms {
+		testForest(name, algorithm(graph), []string{"a-f", "b-c", "d-c", "e-d", "g-a", "g-b"}, 24, 1, t)
+	}
+
+	// Disconnected input gives one tree per component, self loops are never chosen.
+	graph.AddAllVertices([]string{"h", "i", "j"})
+	graph.AddEdge("h", "i", 5)
+	graph.AddEdge("i", "h", 1)
+	graph.AddEdge("j", "j", -1)
+	for name, algorithm := range algorithms {
+		testForest(name, algorithm(graph), []string{"a-f", "b-c", "d-c", "e-d", "g-a", "g-b", "i-h"}, 25, 3, t)
+	}
+
+	graph.Clear()
+	for name, algorithm := range algorithms {
+		testForest(name, algorithm(graph), []string{}, 0, 0, t)
+	}
+}
+
+func testForest(name string, forest *structures.SpanningForest, expectedEdges []string, expectedWeight int, expectedTrees int, t *testing.T) {
+	edges := make([]string, 0)
+	for _, edge := range forest.Edges {
+		edges = append(edges, edge.From()+"-"+edge.To())
+	}
+	if !reflect.DeepEqual(edges, expectedEdges) {
+		t.Errorf("%s edges incorrect, got %v", name, edges)
+	}
+	if forest.Weight != expectedWeight || forest.Trees != expectedTrees {
+		t.Errorf("%s should have weight %d and %d trees, got %d and %d", name, expectedWeight, expectedTrees, forest.Weight, forest.Trees)
+	}
+}
@@ -66,3 +66,26 @@ func stackTests(stack structures.Stack, t *testing.T) {
 		return
 	}
 }
+
+func TestGenericStack(t *testing.T) {
+	stacks := []structures.StackOf[string]{&structures.ArrayStackOf[string]{}, &structures.LinkedStackOf[string]{}}
+	for _, stack := range stacks {
+		stack.Push("a")
+		stack.Push("b")
+		top, err := stack.Pop()
+		testError(err, t)
+		if top != "b" {
+			t.Errorf("Pop incorrect, expected b, got %s", top)
+		}
+		top, err = stack.Peek()
+		testError(err, t)
+		if top != "a" {
+			t.Errorf("Peek incorrect, expected a, got %s", top)
+		}
+		stack.Pop()
+		top, err = stack.Pop()
+		if err == nil || top != "" {
+			t.Error("Pop should throw an error and return the zero value, the stack is empty")
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package structures_test
+
+import (
+	"reflect"
+	"testing"
+
+	"../structures"
+)
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testStronglyConnectedComponents(matrix, t)
+	matrixCondensation, components := structures.Condensation(matrix)
+	testCondensation(matrixCondensation, components, t)
+
+	list := &structures.AdjacencyList{}
+	testStronglyConnectedComponents(list, t)
+	listCondensation, components := structures.Condensation(list)
+	testCondensation(listCondensation, components, t)
+}
+
+func testStronglyConnectedComponents(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToGraphA(graph, t)
+	expected := [][]string{{"a", "b", "c", "d", "e", "f", "g"}}
+	if !reflect.DeepEqual(structures.StronglyConnectedComponents(graph), expected) {
+		t.Errorf("Graph A should be strongly connected, got %v", structures.StronglyConnectedComponents(graph))
+	}
+	if !reflect.DeepEqual(structures.KosarajuComponents(graph), expected) {
+		t.Errorf("Graph A should be strongly connected, got %v", structures.KosarajuComponents(graph))
+	}
+
+	resetToCallGraph(graph, t)
+	expected = [][]string{{"api"}, {"auth", "users"}, {"billing", "invoices"}, {"mail"}, {"log"}}
+	if !reflect.DeepEqual(structures.StronglyConnectedComponents(graph), expected) {
+		t.Errorf("Tarjan's components incorrect, got %v", structures.StronglyConnectedComponents(graph))
+	}
+	if !reflect.DeepEqual(structures.KosarajuComponents(graph), expected) {
+		t.Errorf("Kosaraju's components incorrect, got %v", structures.KosarajuComponents(graph))
+	}
+}
+
+func testCondensation(condensation structures.DirectedWeightedGraph, components [][]string, t *testing.T) {
+	if len(components) != 5 {
+		t.Errorf("Condensation should have 5 components, got %v", components)
+	}
+	testGraphNumberOfVertices(condensation, 5, t)
+	testGraphNumberOfEdges(condensation, 5, t)
+	if structures.HasCycle(condensation) {
+		t.Error("Condensation should be acyclic")
+	}
+	weights := make(map[string]int)
+	for edge := range condensation.Edges() {
+		weights[edge.From()+"->"+edge.To()] = edge.Weight()
+	}
+	expected := map[string]int{"api->auth": 1, "api->billing": 4, "billing->mail": 3, "mail->log": 1, "auth->log": 3}
+	if !reflect.DeepEqual(weights, expected) {
+		t.Errorf("Condensation edges incorrect, got %v", weights)
+	}
+}
+
+// Services calling each other, auth and users depend on each other as do billing and invoices.
+func resetToCallGraph(graph structures.DirectedWeightedGraph, t *testing.T) {
+	graph.Clear()
+	graph.AddAllVertices([]string{"api", "auth", "users", "billing", "invoices", "mail", "log"})
+	graph.AddEdge("api", "auth", 1)
+	graph.AddEdge("auth", "users", 2)
+	graph.AddEdge("users", "auth", 3)
+	graph.AddEdge("api", "billing", 4)
+	graph.AddEdge("billing", "invoices", 5)
+	graph.AddEdge("invoices", "billing", 6)
+	graph.AddEdge("invoices", "mail", 1)
+	graph.AddEdge("billing", "mail", 2)
+	graph.AddEdge("mail", "log", 1)
+	graph.AddEdge("users", "log", 1)
+	graph.AddEdge("auth", "log", 2)
+	testGraphNumberOfVertices(graph, 7, t)
+	testGraphNumberOfEdges(graph, 11, t)
+}
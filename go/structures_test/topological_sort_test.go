@@ -0,0 +1,132 @@
+package structures_test
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"../structures"
+)
+
+func TestTopologicalSort(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testTopologicalSort(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testTopologicalSort(list, t)
+}
+
+func testTopologicalSort(graph structures.DirectedWeightedGraph, t *testing.T) {
+	resetToPipeline(graph, t)
+	if structures.HasCycle(graph) {
+		t.Error("Pipeline should not have a cycle")
+	}
+	if len(structures.Cycles(graph)) != 0 {
+		t.Errorf("Pipeline should not have cycles, got %v", structures.Cycles(graph))
+	}
+	order, err := structures.TopologicalSort(graph)
+	testError(err, t)
+	if !reflect.DeepEqual(order, []string{"checkout", "lint", "build", "test", "docs", "package", "deploy"}) {
+		t.Errorf("Kahn's order incorrect, got %v", order)
+	}
+	testTopologicalOrder(graph, order, t)
+	order, err = structures.TopologicalSortDFS(graph)
+	testError(err, t)
+	testTopologicalOrder(graph, order, t)
+
+	// Deploying triggers a new checkout.
+	graph.AddEdge("deploy", "checkout", 1)
+	if !structures.HasCycle(graph) {
+		t.Error("Pipeline should have a cycle")
+	}
+	for _, sort := range []func(structures.Graph) ([]string, error){structures.TopologicalSort, structures.TopologicalSortDFS} {
+		_, err := sort(graph)
+		var cycleErr *structures.CycleError
+		if !errors.As(err, &cycleErr) {
+			t.Fatalf("Topological sort should throw a CycleError, got %v", err)
+		}
+		testCycle(graph, cycleErr.Cycle, t)
+	}
+}
+
+func TestCycles(t *testing.T) {
+	matrix := &structures.AdjacencyMatrix{}
+	testCycles(matrix, t)
+
+	list := &structures.AdjacencyList{}
+	testCycles(list, t)
+}
+
+func testCycles(graph structures.DirectedWeightedGraph, t *testing.T) {
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b", "c"})
+	graph.AddEdge("a", "b", 1)
+	graph.AddEdge("b", "a", 1)
+	graph.AddEdge("b", "c", 1)
+	graph.AddEdge("c", "a", 1)
+	graph.AddEdge("c", "c", 1)
+	if !reflect.DeepEqual(structures.Cycles(graph), [][]string{{"a", "b"}, {"a", "b", "c"}, {"c"}}) {
+		t.Errorf("Cycles incorrect, got %v", structures.Cycles(graph))
+	}
+
+	resetToGraphA(graph, t)
+	cycles := structures.Cycles(graph)
+	seen := make(map[string]bool)
+	for _, cycle := range cycles {
+		testCycle(graph, cycle, t)
+		key := strings.Join(cycle, ",")
+		if seen[key] {
+			t.Errorf("Cycle listed twice: %v", cycle)
+		}
+		seen[key] = true
+	}
+	if len(cycles) != 35 || !seen["a,c,g"] || !seen["d,g,e"] {
+		t.Errorf("Graph A should have 35 cycles, got %v", cycles)
+	}
+}
+
+// A build pipeline where each edge points from a step to a step that depends on it.
+func resetToPipeline(graph structures.DirectedWeightedGraph, t *testing.T) {
+	graph.Clear()
+	graph.AddAllVertices([]string{"checkout", "lint", "build", "test", "docs", "package", "deploy"})
+	graph.AddEdge("checkout", "build", 1)
+	graph.AddEdge("checkout", "lint", 1)
+	graph.AddEdge("lint", "test", 1)
+	graph.AddEdge("build", "test", 1)
+	graph.AddEdge("build", "docs", 1)
+	graph.AddEdge("test", "package", 1)
+	graph.AddEdge("docs", "package", 1)
+	graph.AddEdge("package", "deploy", 1)
+	testGraphNumberOfVertices(graph, 7, t)
+	testGraphNumberOfEdges(graph, 8, t)
+}
+
+func testTopologicalOrder(graph structures.DirectedWeightedGraph, order []string, t *testing.T) {
+	if len(order) != graph.NumberOfVertices() {
+		t.Errorf("Order should contain every vertex, got %v", order)
+	}
+	for edge := range graph.Edges() {
+		if slices.Index(order, edge.From()) > slices.Index(order, edge.To()) {
+			t.Errorf("Edge %s->%s goes backwards in %v", edge.From(), edge.To(), order)
+		}
+	}
+}
+
+// Checks that consecutive vertices of a cycle are connected by edges, and that no vertex repeats.
+func testCycle(graph structures.DirectedWeightedGraph, cycle []string, t *testing.T) {
+	for i, vertex := range cycle {
+		next := cycle[(i+1)%len(cycle)]
+		found := false
+		for neighbour := range graph.Neighbours(vertex) {
+			if neighbour == next {
+				found = true
+			}
+		}
+		if !found || slices.Index(cycle, vertex) != i {
+			t.Errorf("Invalid cycle %v", cycle)
+			return
+		}
+	}
+}
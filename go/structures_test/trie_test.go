@@ -1,6 +1,8 @@
 package structures_test
 
 import (
+	"reflect"
+	"slices"
 	"testing"
 
 	"../structures"
@@ -72,3 +74,33 @@ func testTrieSize(trie *structures.Trie, expected int, t *testing.T) {
 		t.Errorf("Trie size should be %d, got %d", expected, trie.Size())
 	}
 }
+
+func TestTrieIterator(t *testing.T) {
+	trie := &structures.Trie{}
+	for range trie.All() {
+		t.Error("All should not yield anything, the trie is empty")
+	}
+
+	trie.InsertAll([]string{"the", "quick", "brown", "fox", "jumps", "over", "the", "lazy", "dog", "then", "th"})
+	expected := []string{"brown", "dog", "fox", "jumps", "lazy", "over", "quick", "th", "the", "then"}
+	if !reflect.DeepEqual(slices.Collect(trie.All()), expected) {
+		t.Errorf("All should list words in lexicographic order, got %v", slices.Collect(trie.All()))
+	}
+
+	words := make([]string, 0)
+	for word := range trie.All() {
+		words = append(words, word)
+		if len(words) == 3 {
+			break
+		}
+	}
+	if !reflect.DeepEqual(words, []string{"brown", "dog", "fox"}) {
+		t.Errorf("Iteration should stop after 3 words, got %v", words)
+	}
+
+	testModifiedDuringIteration(func() {
+		for word := range trie.All() {
+			trie.Insert(word + "s")
+		}
+	}, t)
+}
@@ -0,0 +1,192 @@
+package structures_test
+
+import (
+	"strings"
+	"testing"
+
+	"../structures"
+)
+
+func TestUndirectedWeightedGraph(t *testing.T) {
+	matrix := &structures.UndirectedWeightedAdjacencyMatrix{}
+	testUndirectedWeightedGraph(matrix, t)
+
+	list := &structures.UndirectedWeightedAdjacencyList{}
+	testUndirectedWeightedGraph(list, t)
+}
+
+func testUndirectedWeightedGraph(graph structures.UndirectedWeightedGraph, t *testing.T) {
+	resetToUndirectedGraph(graph, t)
+	testTraversal("BFS", graph.BFS, "a", []string{"a", "b", "h", "c", "g", "i", "d", "f", "e"}, t)
+	// Edges can be followed from either end, but are iterated once in the direction they were added.
+	if _, ok := neighbourWeights(graph, "h")["a"]; !ok {
+		t.Error("A should be a neighbour of H")
+	}
+	id := 0
+	for edge := range graph.Edges() {
+		if edge.From() == "h" && edge.To() == "a" {
+			t.Error("Edge A-H should be iterated from A to H")
+		}
+		// Each edge has a single ID, given in the order the edges were added.
+		if id++; edge.ID() != id {
+			t.Errorf("Edge %s-%s should have ID %d, got %d", edge.From(), edge.To(), id, edge.ID())
+		}
+	}
+
+	// Algorithms see every edge in both directions.
+	paths, err := structures.ShortestPaths(graph, "e")
+	testError(err, t)
+	testPathTo(paths, "a", []string{"e", "f", "g", "h", "a"}, []int{0, 10, 12, 13, 21}, t)
+	testForest("Kruskal", structures.Kruskal(graph), []string{"a-b", "a-h", "c-d", "c-f", "c-i", "d-e", "f-g", "g-h"}, 37, 1, t)
+
+	testError(graph.RemoveEdge("b", "a"), t)
+	testGraphNumberOfEdges(graph, 13, t)
+	if graph.RemoveEdge("a", "b") == nil {
+		t.Error("Graph should have thrown error, edge A-B was removed")
+	}
+	if _, ok := neighbourWeights(graph, "a")["b"]; ok {
+		t.Error("B should not be a neighbour of A after removing the edge")
+	}
+	graph.RemoveVertex("c")
+	testGraphNumberOfVertices(graph, 8, t)
+	testGraphNumberOfEdges(graph, 9, t)
+
+	// A self loop is a single edge.
+	id, err = graph.AddEdge("a", "a", 3)
+	testError(err, t)
+	if id != 15 {
+		t.Errorf("Self loop should have ID 15, got %d", id)
+	}
+	testGraphNumberOfEdges(graph, 10, t)
+	testError(graph.RemoveEdge("a", "a"), t)
+	testGraphNumberOfEdges(graph, 9, t)
+
+	graph.Clear()
+	if !graph.IsEmpty() || graph.NumberOfEdges() != 0 {
+		t.Error("Graph should be empty after clearing")
+	}
+}
+
+func TestUndirectedGraph(t *testing.T) {
+	matrix := &structures.UndirectedAdjacencyMatrix{}
+	testUndirectedGraph(matrix, t)
+
+	list := &structures.UndirectedAdjacencyList{}
+	testUndirectedGraph(list, t)
+}
+
+func testUndirectedGraph(graph structures.UndirectedGraph, t *testing.T) {
+	if _, ok := any(graph).(structures.WeightedGraph); ok {
+		t.Error("Unweighted graph should not accept weighted edges")
+	}
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b", "c", "d", "e"})
+	graph.AddEdge("a", "b")
+	graph.AddEdge("c", "b")
+	graph.AddEdge("d", "a")
+	graph.AddEdge("d", "e")
+	testGraphNumberOfEdges(graph, 4, t)
+	testTraversal("DFS", graph.DFS, "e", []string{"e", "d", "a", "b", "c"}, t)
+
+	// Every edge has weight 1, so distances count edges.
+	paths, err := structures.ShortestPaths(graph, "b")
+	testError(err, t)
+	testPathTo(paths, "e", []string{"b", "a", "d", "e"}, []int{0, 1, 2, 3}, t)
+	for _, weight := range neighbourWeights(graph, "d") {
+		if weight != 1 {
+			t.Errorf("Edges should have weight 1, got %d", weight)
+		}
+	}
+
+	testError(graph.RemoveEdge("b", "c"), t)
+	testGraphNumberOfEdges(graph, 3, t)
+	graph.RemoveVertex("d")
+	testGraphNumberOfEdges(graph, 1, t)
+	testTraversal("BFS", graph.BFS, "a", []string{"a", "b"}, t)
+}
+
+func TestUndirectedDecoding(t *testing.T) {
+	testUndirectedDecoding(&structures.UndirectedWeightedAdjacencyMatrix{}, t)
+	testUndirectedDecoding(&structures.UndirectedWeightedAdjacencyList{}, t)
+}
+
+func testUndirectedDecoding(graph structures.UndirectedWeightedGraph, t *testing.T) {
+	if _, ok := any(graph).(structures.DirectedWeightedGraph); ok {
+		t.Error("Undirected graph should not be usable as a directed graph")
+	}
+	// Each edge of a digraph becomes an undirected edge.
+	directed := &structures.AdjacencyList{}
+	resetToUndirectedGraph(directed, t)
+	var b strings.Builder
+	testError(structures.EncodeDOT(directed, &b, nil), t)
+	testError(structures.DecodeDOT(graph, strings.NewReader(b.String())), t)
+	testGraphNumberOfVertices(graph, 9, t)
+	testGraphNumberOfEdges(graph, 14, t)
+	if edges := graph.EdgesBetween("h", "a"); len(edges) != 1 || edges[0].From() != "a" {
+		t.Error("Edge A-H should be decoded once, from A to H")
+	}
+	if _, ok := neighbourWeights(graph, "h")["a"]; !ok {
+		t.Error("A should be a neighbour of H")
+	}
+
+	// The directed copy holds every edge in both directions, so metrics see the undirected graph.
+	list := graph.ToList()
+	testGraphNumberOfEdges(list, 28, t)
+	for vertex, expected := range map[string]int{"a": 2, "b": 3, "c": 4, "d": 3, "e": 2, "f": 4, "g": 3, "h": 4, "i": 3} {
+		in, err := structures.InDegree(list, vertex)
+		testError(err, t)
+		out, err := structures.OutDegree(list, vertex)
+		testError(err, t)
+		if in != expected || out != expected {
+			t.Errorf("Degrees of %s should both be %d, got %d and %d", vertex, expected, in, out)
+		}
+	}
+	testFloat("Density", structures.Density(list), 28.0/72, t)
+	testFloat("Clustering", structures.AverageClustering(list), 0.5, t)
+	for vertex := range graph.Vertices() {
+		paths, err := structures.ShortestPaths(graph, vertex)
+		testError(err, t)
+		expected := 0
+		for other := range graph.Vertices() {
+			distance, _ := paths.DistanceTo(other)
+			expected = max(expected, distance)
+		}
+		if eccentricity, err := structures.Eccentricity(list, vertex); err != nil || eccentricity != expected {
+			t.Errorf("Eccentricity of %s should be %d, got %d, %v", vertex, expected, eccentricity, err)
+		}
+	}
+	if _, err := structures.TopologicalSort(list); err == nil {
+		t.Error("Topological sort of an undirected graph should throw error")
+	}
+}
+
+// Undirected version of the minimum spanning tree example from CLRS.
+func resetToUndirectedGraph(graph structures.UndirectedWeightedGraph, t *testing.T) {
+	graph.Clear()
+	graph.AddAllVertices([]string{"a", "b", "c", "d", "e", "f", "g", "h", "i"})
+	graph.AddEdge("a", "b", 4)
+	graph.AddEdge("a", "h", 8)
+	graph.AddEdge("b", "c", 8)
+	graph.AddEdge("b", "h", 11)
+	graph.AddEdge("c", "d", 7)
+	graph.AddEdge("c", "f", 4)
+	graph.AddEdge("c", "i", 2)
+	graph.AddEdge("d", "e", 9)
+	graph.AddEdge("d", "f", 14)
+	graph.AddEdge("e", "f", 10)
+	graph.AddEdge("f", "g", 2)
+	graph.AddEdge("g", "h", 1)
+	graph.AddEdge("g", "i", 6)
+	graph.AddEdge("h", "i", 7)
+	testGraphNumberOfVertices(graph, 9, t)
+	testGraphNumberOfEdges(graph, 14, t)
+}
+
+// Collects the neighbours of a vertex and the weights of the edges leading to them.
+func neighbourWeights(graph structures.Graph, vertex string) map[string]int {
+	result := make(map[string]int)
+	for neighbour, weight := range graph.Neighbours(vertex) {
+		result[neighbour] = weight
+	}
+	return result
+}
@@ -1,9 +1,22 @@
 package structures_test
 
-import "testing"
+import (
+	"testing"
+
+	"../structures"
+)
 
 func testError(err error, t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
 }
+
+func testModifiedDuringIteration(fn func(), t *testing.T) {
+	defer func() {
+		if recover() != structures.ErrModifiedDuringIteration {
+			t.Error("Iteration should panic, the container was modified")
+		}
+	}()
+	fn()
+}